@@ -120,12 +120,13 @@ func adapterForValue(v reflect.Value, kw string) (ad adapter, ok bool) {
 		return
 	}
 
-	vv := derefValuePtr(v)
-	if f, ok = master[vv.Type()]; ok {
-		debugAdapter(newLItem(f), "adapter deref")
-		ad = buildAdapter(f, vv.Interface())
-		ok = true
-		return
+	// vv is invalid for nil pointers, e.g.: a *big.Int awaiting decoding
+	if vv := derefValuePtr(v); vv.IsValid() {
+		if f, ok = master[vv.Type()]; ok {
+			debugAdapter(newLItem(f), "adapter deref")
+			ad = buildAdapter(f, vv.Interface())
+			return
+		}
 	}
 
 	var err error
@@ -135,10 +136,28 @@ func adapterForValue(v reflect.Value, kw string) (ad adapter, ok bool) {
 	return
 }
 
+/*
+isAdaptedPointer returns a Boolean value indicative of whether v, which
+is a pointer, is itself bound to an adapter (e.g.: *big.Int), in which
+case it must not be dereferenced prior to encoding or decoding.
+*/
+func isAdaptedPointer(v reflect.Value, opts *Options) bool {
+	var kw string
+	if opts != nil {
+		kw = opts.Identifier
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+	_, err := lookupAdapter(v.Type().String(), kw)
+
+	return err == nil
+}
+
 func buildAdapter(f factories, seed any) adapter {
 	return adapter{
 		newCodec: func() Primitive { return f.newEmpty() },
-		toGo:     func(p Primitive) any { return p.(box).getVal() },
+		toGo:     func(p Primitive) (any, error) { return p.(box).getVal(), nil },
 		fromGo: func(g any, p Primitive, _ *Options) (_ error) {
 			p.(box).setVal(seed)
 			if g == nil {
@@ -379,6 +398,19 @@ func RegisterAdapter[T any, GoT any](
 	ctor func(GoT, ...Constraint) (T, error),
 	asGo func(*T) GoT,
 	aliases ...string,
+) {
+	registerAdapter(ctor, func(p *T) (GoT, error) { return asGo(p), nil }, aliases...)
+}
+
+/*
+registerAdapter implements [RegisterAdapter], with the exception that asGo
+may fail, such as when the ASN.1 value cannot be represented by GoT. Any
+such error is returned by [Unmarshal].
+*/
+func registerAdapter[T any, GoT any](
+	ctor func(GoT, ...Constraint) (T, error),
+	asGo func(*T) (GoT, error),
+	aliases ...string,
 ) {
 	mu.Lock()
 	defer mu.Unlock()
@@ -396,11 +428,11 @@ func RegisterAdapter[T any, GoT any](
 	}
 
 	// Build toGo / fromGo that work for BOTH cases
-	toGo := func(p Primitive) (a any) {
+	toGo := func(p Primitive) (a any, err error) {
 		// generic path
 		if bx, ok := p.(interface{ getVal() any }); ok {
 			val := bx.getVal().(T)
-			a = asGo(&val)
+			a, err = asGo(&val)
 		}
 		return
 	}
@@ -439,6 +471,7 @@ func RegisterAdapter[T any, GoT any](
 		}
 	}
 	atomic.AddInt64(&adaptersVer, 1) // cache invalidation
+	forgetFieldOptions()
 }
 
 /*
@@ -501,6 +534,7 @@ func UnregisterAdapter[T any, GoT any](aliases ...string) {
 	}
 
 	atomic.AddInt64(&adaptersVer, 1)
+	forgetFieldOptions()
 }
 
 func isAdapterKeyword(token string) (is bool) {
@@ -540,7 +574,7 @@ with ASN.1 primitives (e.g.: string -> uTF8String)
 */
 type adapter struct {
 	newCodec func() Primitive                     // factory for temp value
-	toGo     func(Primitive) any                  // codec -> plain Go
+	toGo     func(Primitive) (any, error)         // codec -> plain Go
 	fromGo   func(any, Primitive, *Options) error // plain Go -> codec
 }
 
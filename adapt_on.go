@@ -3,6 +3,7 @@
 package asn1plus
 
 import (
+	"math"
 	"math/big"
 	"time"
 )
@@ -226,19 +227,60 @@ func registerTemporalAliasAdapters() {
 	)
 }
 
-func registerNumericalAdapters() {
-	RegisterAdapter[Integer, int](
-		func(n int, cs ...Constraint) (Integer, error) {
-			return NewInteger(int64(n), cs...)
-		},
-		func(p *Integer) int {
-			if p.big {
-				return int(p.Big().Int64())
+/*
+registerIntegerAdapter binds [Integer] to the native Go integer type N. Upon
+decoding, an error is returned if the value cannot be represented by N,
+rather than silently truncating it.
+*/
+func registerIntegerAdapter[N Numerical]() {
+	registerAdapter[Integer, N](
+		func(n N, cs ...Constraint) (Integer, error) {
+			if n < 0 || uint64(n) <= math.MaxInt64 {
+				return NewInteger(int64(n), cs...)
 			}
-			return int(p.native)
+			return NewInteger(new(big.Int).SetUint64(uint64(n)), cs...)
 		},
+		integerToNumerical[N],
 		"", "int", "integer",
 	)
+}
+
+/*
+integerToNumerical returns the value of p as an instance of N, alongside
+an error should p overflow N.
+*/
+func integerToNumerical[N Numerical](p *Integer) (n N, err error) {
+	neg, ok := p.native < 0, true
+	if !p.big {
+		n = N(p.native)
+		ok = int64(n) == p.native
+	} else if bi := p.Big(); bi.IsInt64() {
+		n, neg = N(bi.Int64()), bi.Sign() < 0
+		ok = int64(n) == bi.Int64()
+	} else if ok = bi.IsUint64(); ok {
+		n, neg = N(bi.Uint64()), false
+		ok = uint64(n) == bi.Uint64()
+	}
+
+	if !ok || (n < 0) != neg {
+		n = 0
+		err = adapterErrorf("INTEGER ", p.String(), " overflows ", refTypeOf(n))
+	}
+
+	return
+}
+
+func registerNumericalAdapters() {
+	registerIntegerAdapter[int]()
+	registerIntegerAdapter[int8]()
+	registerIntegerAdapter[int16]()
+	registerIntegerAdapter[int32]()
+	registerIntegerAdapter[int64]()
+	registerIntegerAdapter[uint]()
+	registerIntegerAdapter[uint8]()
+	registerIntegerAdapter[uint16]()
+	registerIntegerAdapter[uint32]()
+	registerIntegerAdapter[uint64]()
 
 	RegisterAdapter[Integer, *big.Int](
 		func(bi *big.Int, cs ...Constraint) (Integer, error) {
@@ -303,6 +345,14 @@ func registerNumericalAdapters() {
 		func(p *Real) *big.Float { return p.Big() },
 		"real16",
 	)
+
+	// exact decimal values; rationals which cannot be
+	// expressed in base 10 (e.g.: 1/3) are rejected.
+	registerAdapter[Real, *big.Rat](
+		wrapRealCtor(10, ratToRealParts),
+		func(p *Real) (*big.Rat, error) { return p.Rat() },
+		"", "real10", "real",
+	)
 }
 
 func registerStringAdapters() {
@@ -359,7 +409,7 @@ func registerStringAdapters() {
 		func(s string, cs ...Constraint) (BMPString, error) {
 			return NewBMPString(s, cs...)
 		},
-		func(p *BMPString) string { return string(*p) },
+		func(p *BMPString) string { return p.String() },
 		"bmp", "bmpstring",
 	)
 
@@ -0,0 +1,46 @@
+//go:build !asn1_no_dprc && !asn1_no_adapter_pf
+
+package asn1plus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSequence_timeFields(t *testing.T) {
+	type Record struct {
+		Created time.Time  `asn1:"gt"`
+		Expires time.Time  `asn1:"utc"`
+		Revoked *time.Time `asn1:"tag:0,gt"`
+	}
+
+	created := time.Date(2024, 2, 29, 15, 57, 3, 0, time.UTC)
+	expires := created.AddDate(1, 0, 0)
+	in := Record{Created: created, Expires: expires, Revoked: &created}
+
+	// 30 31                -- SEQUENCE
+	//   18 0F ...          -- GeneralizedTime "20240229155703Z"
+	//   17 0D ...          -- UTCTime "250301155703Z"
+	//   80 0F ...          -- [0] IMPLICIT GeneralizedTime "20240229155703Z"
+	want := "30 31 " +
+		"180F" + uc(hexstr([]byte("20240229155703Z"))) +
+		"170D" + uc(hexstr([]byte("250301155703Z"))) +
+		"800F" + uc(hexstr([]byte("20240229155703Z")))
+
+	for _, rule := range encodingRules {
+		pkt, err := Marshal(in, With(rule))
+		if err != nil {
+			t.Fatalf("%s failed [%s encoding]: %v", t.Name(), rule, err)
+		} else if got := pkt.Hex(); got != want {
+			t.Fatalf("%s failed [%s encoding]:\n\twant: %s\n\tgot:  %s", t.Name(), rule, want, got)
+		}
+
+		var out Record
+		if err = Unmarshal(pkt, &out); err != nil {
+			t.Fatalf("%s failed [%s decoding]: %v", t.Name(), rule, err)
+		} else if !out.Created.Equal(created) || !out.Expires.Equal(expires) ||
+			out.Revoked == nil || !out.Revoked.Equal(created) {
+			t.Fatalf("%s failed [%s decoding]:\n\twant: %+v\n\tgot:  %+v", t.Name(), rule, in, out)
+		}
+	}
+}
@@ -4,6 +4,7 @@ package asn1plus
 
 import (
 	"fmt"
+	"math/big"
 	"testing"
 	"time"
 )
@@ -540,6 +541,33 @@ func TestWrapTemporalCtor(t *testing.T) {
 	}
 }
 
+func TestGeneralizedTime_timeField(t *testing.T) {
+	type Record struct {
+		When time.Time `asn1:"gt"`
+	}
+
+	want := time.Date(2024, 2, 29, 15, 57, 3, 0, time.UTC)
+	der := append([]byte{0x30, 0x11, 0x18, 0x0F}, "20240229155703Z"...)
+
+	for _, rule := range encodingRules {
+		var rec Record
+		if err := Unmarshal(rule.New(der...), &rec); err != nil {
+			t.Fatalf("%s failed [%s decode]: %v", t.Name(), rule, err)
+		} else if !rec.When.Equal(want) {
+			t.Fatalf("%s failed [%s decode]:\n\twant: %s\n\tgot:  %s",
+				t.Name(), rule, want, rec.When)
+		}
+
+		pkt, err := Marshal(rec, With(rule))
+		if err != nil {
+			t.Fatalf("%s failed [%s encode]: %v", t.Name(), rule, err)
+		} else if got := pkt.Data(); !btseq(got, der) {
+			t.Fatalf("%s failed [%s encode]:\n\twant: %X\n\tgot:  %X",
+				t.Name(), rule, der, got)
+		}
+	}
+}
+
 func TestRealCtor(_ *testing.T) {
 	r := wrapRealCtor[float64](2, func(float64, int) (any, int, error) { return nil, 0, nil })
 	r(float64(9.2))
@@ -641,3 +669,80 @@ func TestAdapterPF_codecov(_ *testing.T) {
 	_ = Unmarshal(pkt, &durer3, With(opts))
 
 }
+
+func TestIntegerAdapter_sized(t *testing.T) {
+	type Wide struct {
+		Value int64
+	}
+	type Narrow struct {
+		Value int32
+	}
+	type Unsigned struct {
+		Value uint64
+	}
+	type Byte struct {
+		Value uint8
+	}
+
+	for _, rule := range encodingRules {
+		// a value larger than int32 must not be truncated
+		pkt, err := Marshal(Wide{1 << 40}, With(rule))
+		if err != nil {
+			t.Fatalf("%s failed [%s encoding]: %v", t.Name(), rule, err)
+		}
+		var narrow Narrow
+		if err = Unmarshal(pkt, &narrow); err == nil {
+			t.Fatalf("%s failed [%s decoding]: expected overflow error, got %d", t.Name(), rule, narrow.Value)
+		}
+
+		// ... nor may a negative value be decoded as unsigned
+		pkt, _ = Marshal(Wide{-1}, With(rule))
+		var b Byte
+		if err = Unmarshal(pkt, &b); err == nil {
+			t.Fatalf("%s failed [%s decoding]: expected sign error, got %d", t.Name(), rule, b.Value)
+		}
+
+		// values within range survive the round trip
+		for _, want := range []uint64{0, 255, 1<<63 + 1, 1<<64 - 1} {
+			if pkt, err = Marshal(Unsigned{want}, With(rule)); err != nil {
+				t.Fatalf("%s failed [%s encoding %d]: %v", t.Name(), rule, want, err)
+			}
+			var got Unsigned
+			if err = Unmarshal(pkt, &got); err != nil {
+				t.Fatalf("%s failed [%s decoding %d]: %v", t.Name(), rule, want, err)
+			} else if got.Value != want {
+				t.Fatalf("%s failed [%s decoding]: want %d, got %d", t.Name(), rule, want, got.Value)
+			}
+		}
+
+		pkt, _ = Marshal(Narrow{-1 << 31}, With(rule))
+		if err = Unmarshal(pkt, &narrow); err != nil || narrow.Value != -1<<31 {
+			t.Fatalf("%s failed [%s decoding]: want %d, got %d (%v)", t.Name(), rule, -1<<31, narrow.Value, err)
+		}
+	}
+}
+
+func TestRealAdapter_rat(t *testing.T) {
+	type Price struct {
+		Amount *big.Rat
+	}
+
+	for _, rule := range encodingRules {
+		want, _ := new(big.Rat).SetString("3.14")
+		pkt, err := Marshal(Price{want}, With(rule))
+		if err != nil {
+			t.Fatalf("%s failed [%s encode]: %v", t.Name(), rule, err)
+		}
+
+		var got Price
+		if err = Unmarshal(pkt, &got); err != nil {
+			t.Fatalf("%s failed [%s decode]: %v", t.Name(), rule, err)
+		} else if got.Amount.Cmp(want) != 0 {
+			t.Fatalf("%s failed [%s decode]: want %s, got %s", t.Name(), rule, want, got.Amount)
+		}
+
+		if _, err = Marshal(Price{big.NewRat(1, 3)}, With(rule)); err == nil {
+			t.Fatalf("%s failed [%s encode]: expected error for 1/3, got nil", t.Name(), rule)
+		}
+	}
+}
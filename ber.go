@@ -26,6 +26,8 @@ type BERPacket struct {
 	id     string
 	data   []byte
 	offset int
+	limit  int
+	names  map[[2]int]string
 }
 
 /*
@@ -87,10 +89,11 @@ Dump returns an error following an attempt to write the receiver
 instance into w.
 
 The variadic wrapAt value defines the maximum number of characters
-displayed per line before the value is wrapped. The default is 24,
-and can be configured no less than 16.
+displayed per line before the value is wrapped. The default is
+[DefaultDumpWidth], and an error is returned if a width less than
+[MinDumpWidth] is supplied.
 */
-func (r *BERPacket) Dump(w io.Writer, wrapAt ...int) error { return dumpPacket(r, w) }
+func (r *BERPacket) Dump(w io.Writer, wrapAt ...int) error { return dumpPacket(r, w, wrapAt...) }
 
 /*
 Len returns the integer length of the underlying byte buffer within
@@ -134,8 +137,8 @@ func (r *BERPacket) Append(data ...byte) {
 		newBuf := append((*bufPtr)[:0], r.data...)
 
 		if cap(r.data) != 0 {
-			old := r.data[:0]
-			bufPool.Put(&old)
+			old := r.data
+			putBuf(&old)
 		}
 		r.data = newBuf
 		debugEvent(EventTrace|EventPDU,
@@ -181,11 +184,13 @@ func (r *BERPacket) Free() {
 	debugEnter(r)
 	defer func() { debugExit() }()
 
+	debugForgetAnnotations(r)
+
 	if c := cap(r.data); c != 0 {
 		debugEvent(EventTrace|EventPDU,
 			r, newLItem(c, "release cap"))
-		buf := r.data[:0]
-		bufPool.Put(&buf)
+		buf := r.data
+		putBuf(&buf)
 	}
 
 	lb := r.Len()
@@ -225,6 +230,20 @@ header to the receiver buffer.
 */
 func (r *BERPacket) WriteTLV(tlv TLV) error { return writeTLV(r, tlv, nil) }
 
+func (r *BERPacket) outputLimit() int     { return r.limit }
+func (r *BERPacket) setOutputLimit(n int) { r.limit = n }
+
+func (r *BERPacket) tagNames() map[[2]int]string         { return r.names }
+func (r *BERPacket) setTagNames(names map[[2]int]string) { r.names = names }
+
+func (r *BERPacket) reset() {
+	debugForgetAnnotations(r)
+	if ZeroOnFree {
+		clear(r.data)
+	}
+	r.data, r.offset = r.data[:0], 0
+}
+
 func newBERPacket(src ...byte) (pkt PDU) {
 	debugEnter(src)
 	defer func() { debugExit(pkt) }()
@@ -20,7 +20,13 @@ size of P.
 
 Note that this type may not be "string cast friendly", as it requires
 specific byte composition involving the tag and UTF-16-centric length
-octets.
+octets. Only payload P is encoded as the content of the ASN.1 BMPString,
+in big-endian order.
+
+Each character occupies a single two-byte code unit, thus characters beyond
+the Basic Multilingual Plane (i.e.: above U+FFFF) cannot be represented and
+are rejected, as are UTF-16 surrogates. Use [UniversalString] for values
+bearing such characters.
 
 [ITU-T Rec. X.680]: https://www.itu.int/rec/T-REC-X.680
 */
@@ -98,18 +104,15 @@ func MustNewBMPString(x any, constraints ...Constraint) BMPString {
 }
 
 func buildBMP(e string) ([]byte, error) {
-	// TagBMPString=0x1E, maxUnits=255, maxBPU=4 bytes (surrogate pairs)
-	return buildText(e, TagBMPString, 255, 4, func(r rune, dst []byte, pos int) (bw, cu int, err error) {
-		if r <= 0xFFFF {
-			dst[pos], dst[pos+1] = byte(r>>8), byte(r)
-			return 2, 1, nil
+	// TagBMPString=0x1E, maxUnits=255, maxBPU=2 bytes
+	return buildText(e, TagBMPString, 255, 2, func(r rune, dst []byte, pos int) (bw, cu int, err error) {
+		if r > 0xFFFF {
+			err = primitiveErrorf("BMPString: character U+", uc(fmtInt(int64(r), 16)),
+				" is outside the Basic Multilingual Plane")
+			return
 		}
-		v := r - 0x10000
-		hi := 0xD800 | (v >> 10)
-		lo := 0xDC00 | (v & 0x3FF)
-		dst[pos], dst[pos+1] = byte(hi>>8), byte(hi)
-		dst[pos+2], dst[pos+3] = byte(lo>>8), byte(lo)
-		return 4, 2, nil
+		dst[pos], dst[pos+1] = byte(r>>8), byte(r)
+		return 2, 1, nil
 	})
 }
 
@@ -164,6 +167,57 @@ func (r BMPString) String() string {
 	return s
 }
 
+/*
+encodeBMPString returns the content octets of b, i.e.: the big-endian
+UTF-16 payload sans the tag and length octets of the receiver's internal
+representation, alongside an error.
+*/
+func encodeBMPString(b BMPString) (wire []byte, err error) {
+	if len(b) == 0 {
+		return
+	} else if err = BMPSpec(b); err == nil {
+		wire = append([]byte(nil), b[2:]...)
+	}
+
+	return
+}
+
+/*
+decodeBMPString returns the [BMPString] bearing the big-endian UTF-16
+content octets wire alongside an error.
+*/
+func decodeBMPString(wire []byte) (b BMPString, err error) {
+	if units := len(wire) / 2; units > 255 {
+		err = primitiveErrorf("BMPString: ", units, " code units exceeds maximum of 255")
+	} else {
+		b = append(BMPString{byte(TagBMPString), byte(units)}, wire...)
+	}
+
+	return
+}
+
+/*
+bmpStringDecoderVerify returns an error if wire, the content octets of a
+BMPString, do not consist of big-endian two-byte code units, each of which
+being a character of the Basic Multilingual Plane. UTF-16 surrogates, with
+which characters beyond that plane would be expressed, are rejected.
+*/
+func bmpStringDecoderVerify(wire []byte) (err error) {
+	if len(wire)%2 != 0 {
+		err = primitiveErrorf("BMPString: byte length not multiple of 2")
+		return
+	}
+
+	for i := 0; i < len(wire) && err == nil; i += 2 {
+		if u := uint16(wire[i])<<8 | uint16(wire[i+1]); 0xD800 <= u && u <= 0xDFFF {
+			err = primitiveErrorf("BMPString: surrogate code unit ",
+				uc(fmtInt(int64(u), 16)), " is not permitted")
+		}
+	}
+
+	return
+}
+
 /*
 IsZero returns a Boolean value indicative of a nil receiver state.
 */
@@ -174,17 +228,17 @@ func init() {
 		var o BMPString
 		switch tv := bmp.(type) {
 		case string:
-			o, _ = NewBMPString(tv)
+			o, err = NewBMPString(tv)
 		case BMPString:
 			o = tv // as-is
 		case Primitive:
-			o, _ = NewBMPString(tv)
+			o, err = NewBMPString(tv)
 		default:
 			err = errorPrimitiveAssertionFailed(o)
 			return
 		}
 
-		if len(o) == 0 {
+		if len(o) == 0 || err != nil {
 			return
 		} else if len(o) == 2 {
 			if o[0] != byte(TagBMPString) || o[1] != 0x0 {
@@ -195,13 +249,18 @@ func init() {
 				err = primitiveErrorf("BMPString: Invalid ASN.1 tag")
 			} else if int(o[1])*2 != len(o[2:]) {
 				err = primitiveErrorf("BMPString: input string encoded length does not match length octet")
+			} else {
+				err = bmpStringDecoderVerify(o[2:])
 			}
 		}
 
 		return
 	}
 
-	RegisterTextAlias[BMPString](TagBMPString,
-		BMPStringConstraintPhase,
-		nil, nil, nil, BMPSpec)
+	registerTextAlias[BMPString](TagBMPString,
+		func() int { return BMPStringConstraintPhase },
+		bmpStringDecoderVerify,
+		decodeBMPString,
+		encodeBMPString,
+		BMPSpec)
 }
@@ -219,6 +219,64 @@ func TestBMPString_UTF16Encoding(t *testing.T) {
 	}
 }
 
+func TestBMPString_roundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		value string
+		wire  []byte // content octets
+	}{
+		{"Hi", []byte{0x00, 0x48, 0x00, 0x69}},
+		{"漢字", []byte{0x6F, 0x22, 0x5B, 0x57}},
+		{"\uFFFD", []byte{0xFF, 0xFD}},
+	} {
+		bmp, err := NewBMPString(tc.value)
+		if err != nil {
+			t.Fatalf("%s failed [NewBMPString %q]: %v", t.Name(), tc.value, err)
+		}
+
+		for _, rule := range encodingRules {
+			var pkt PDU
+			if pkt, err = Marshal(bmp, With(rule)); err != nil {
+				t.Fatalf("%s failed [%s encoding %q]: %v", t.Name(), rule, tc.value, err)
+			}
+
+			want := append([]byte{TagBMPString, byte(len(tc.wire))}, tc.wire...)
+			if got := pkt.Data(); !btseq(got, want) {
+				t.Fatalf("%s failed [%s encoding %q]:\n\twant: %X\n\tgot:  %X",
+					t.Name(), rule, tc.value, want, got)
+			}
+
+			var out BMPString
+			if err = Unmarshal(pkt, &out); err != nil {
+				t.Fatalf("%s failed [%s decoding %q]: %v", t.Name(), rule, tc.value, err)
+			} else if out.String() != tc.value {
+				t.Fatalf("%s failed [%s decoding]:\n\twant: %q\n\tgot:  %q",
+					t.Name(), rule, tc.value, out.String())
+			}
+		}
+	}
+
+	for _, bogus := range [][]byte{
+		{0x1E, 0x03, 0x00, 0x41, 0x00},       // odd length
+		{0x1E, 0x02, 0xD8, 0x3D},             // unpaired high surrogate
+		{0x1E, 0x04, 0xDE, 0x00, 0x00, 0x41}, // unpaired low surrogate
+		{0x1E, 0x04, 0xD8, 0x3D, 0xDE, 0x00}, // surrogate pair (U+1F600)
+	} {
+		var out BMPString
+		if err := Unmarshal(BER.New(bogus...), &out); err == nil {
+			t.Fatalf("%s failed [decoding %X]: expected error, got nil", t.Name(), bogus)
+		}
+	}
+
+	// characters beyond the BMP are left to UniversalString
+	if _, err := NewBMPString("A😀"); err == nil {
+		t.Fatalf("%s failed [NewBMPString]: expected error for non-BMP character, got nil", t.Name())
+	} else if err = BMPSpec("😀"); err == nil {
+		t.Fatalf("%s failed [BMPSpec string]: expected error for non-BMP character, got nil", t.Name())
+	} else if err = BMPSpec(BMPString{0x1E, 0x02, 0xD8, 0x3D, 0xDE, 0x00}); err == nil {
+		t.Fatalf("%s failed [BMPSpec]: expected error for surrogate pair, got nil", t.Name())
+	}
+}
+
 func ExampleBMPString_withConstraints() {
 	// Prohibit use of any digit characters
 	digitConstraint := func(o any) (err error) {
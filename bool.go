@@ -155,7 +155,7 @@ func (c *booleanCodec[T]) write(pkt PDU, o *Options) (n int, err error) {
 func bcdBooleanWrite[T Truthy](c *booleanCodec[T], pkt PDU, o *Options) (off int, err error) {
 	o = deferImplicit(o)
 
-	cc := c.cg.phase(c.cphase, CodecConstraintEncoding)
+	cc := c.cg.phase(o.constraintPhase(c.cphase), CodecConstraintEncoding)
 	if err = cc(c.val); err == nil {
 		var wire []byte = []byte{0x00} // assume FALSE
 		var err error
@@ -199,6 +199,9 @@ func bcdBooleanRead[T Truthy](c *booleanCodec[T], pkt PDU, tlv TLV, o *Options)
 	if err == nil {
 		if len(wire) != 1 {
 			return primitiveErrorf("BOOLEAN: content length ≠ 1")
+		} else if rule := pkt.Type(); rule.In(CER, DER) && wire[0] != 0x00 && wire[0] != 0xFF {
+			// X.690 clause 11.1: TRUE must be encoded as 0xFF
+			return primitiveErrorf("BOOLEAN: ", rule, " requires content of 0x00 or 0xFF")
 		}
 
 		decodeVerify := func() (err error) {
@@ -218,7 +221,7 @@ func bcdBooleanRead[T Truthy](c *booleanCodec[T], pkt PDU, tlv TLV, o *Options)
 			}
 
 			if err == nil {
-				cc := c.cg.phase(c.cphase, CodecConstraintDecoding)
+				cc := c.cg.phase(o.constraintPhase(c.cphase), CodecConstraintDecoding)
 				if err = cc(out); err == nil {
 					c.val = out
 					pkt.AddOffset(1)
@@ -63,6 +63,30 @@ func TestNewBoolean(t *testing.T) {
 	}
 }
 
+func TestBoolean_strictDER(t *testing.T) {
+	for _, rule := range encodingRules {
+		var b Boolean
+		err := UnmarshalBytes([]byte{0x01, 0x01, 0x01}, rule, &b)
+		if rule.In(CER, DER) {
+			if err == nil {
+				t.Fatalf("%s failed [%s]: expected error for BOOLEAN 0x01, got nil", t.Name(), rule)
+			}
+		} else if err != nil {
+			t.Fatalf("%s failed [%s]: %v", t.Name(), rule, err)
+		} else if !b {
+			t.Fatalf("%s failed [%s]: want TRUE, got %s", t.Name(), rule, b)
+		}
+
+		for _, octet := range []byte{0x00, 0xFF} {
+			if err = UnmarshalBytes([]byte{0x01, 0x01, octet}, rule, &b); err != nil {
+				t.Fatalf("%s failed [%s %02X]: %v", t.Name(), rule, octet, err)
+			} else if bool(b) != (octet == 0xFF) {
+				t.Fatalf("%s failed [%s %02X]: unexpected value %s", t.Name(), rule, octet, b)
+			}
+		}
+	}
+}
+
 func TestBoolean_codecov(t *testing.T) {
 	_, _ = NewBoolean(struct{}{})
 	bc := new(booleanCodec[Boolean])
@@ -28,6 +28,18 @@ for possible settings.
 */
 var BitStringConstraintPhase = CodecConstraintDecoding
 
+/*
+StrictDERBitStringPadding declares whether a BIT STRING decoded under
+[DER] is rejected should any of its unused trailing bits be non-zero, as
+is mandated by ITU-T Rec. X.690 clause 11.2.1. This serves to catch
+malformed or malleable encodings, such as tampered signature values.
+
+The default is true. Set to false to tolerate such encodings, e.g.: when
+consuming the output of non-conformant encoders. [BER] does not mandate
+zero padding, and is not affected by this setting.
+*/
+var StrictDERBitStringPadding = true
+
 /*
 NewBitString returns an instance of [BitString] alongside an error
 following an attempt to parse x.
@@ -283,6 +295,69 @@ func (r *BitString) Unset(bit int) {
 	}
 }
 
+/*
+And performs a bitwise AND of the receiver instance and o, writing the
+result into the receiver instance.
+
+Should the bit lengths differ, the shorter value is aligned to the longer
+value by way of trailing zero bits, and the result assumes the longer bit
+length.
+*/
+func (r *BitString) And(o BitString) {
+	r.combine(o, func(x, y byte) byte { return x & y })
+}
+
+/*
+Or performs a bitwise OR of the receiver instance and o, writing the
+result into the receiver instance.
+
+Should the bit lengths differ, the shorter value is aligned to the longer
+value by way of trailing zero bits, and the result assumes the longer bit
+length.
+*/
+func (r *BitString) Or(o BitString) {
+	r.combine(o, func(x, y byte) byte { return x | y })
+}
+
+/*
+Equal returns a Boolean value indicative of the receiver instance and o
+bearing the same BitLength and the same bit values. Unused trailing bits
+within the final octet are not significant.
+*/
+func (r BitString) Equal(o BitString) bool {
+	return r.BitLength == o.BitLength &&
+		btseq(r.aligned(r.BitLength), o.aligned(o.BitLength))
+}
+
+/*
+combine applies op to each octet of the receiver instance and o, both of
+which are first aligned to the greater of the two bit lengths.
+*/
+func (r *BitString) combine(o BitString, op func(x, y byte) byte) {
+	bitLen := max(r.BitLength, o.BitLength)
+	a, b := r.aligned(bitLen), o.aligned(bitLen)
+	for i := range a {
+		a[i] = op(a[i], b[i])
+	}
+
+	r.Bytes, r.BitLength = a, bitLen
+}
+
+/*
+aligned returns a copy of the significant bits of the receiver instance,
+occupying exactly enough octets for bitLen bits. Unused trailing bits, as
+well as bits beyond the receiver's own bit length, are zeroed.
+*/
+func (r BitString) aligned(bitLen int) []byte {
+	b := make([]byte, (bitLen+7)/8)
+	n := copy(b, r.Bytes[:min(len(r.Bytes), (r.BitLength+7)/8)])
+	if rem := r.BitLength % 8; rem > 0 && n > 0 && n*8 > r.BitLength {
+		b[n-1] &^= byte(0xFF >> rem)
+	}
+
+	return b
+}
+
 /*
 NamedBit defines a single bit with its name and the bit index (0-based).
 */
@@ -394,6 +469,78 @@ func (r NamedBits) Names() []string {
 	return names
 }
 
+/*
+SetNames sets the bits associated with each of the input names within the
+receiver instance, extending the underlying [BitString] as needed to hold
+the highest of said bits. Case is not significant.
+
+If any name is not found within the Bits registry of the receiver instance,
+an error is returned and the receiver instance is left unmodified.
+
+See also [NamedBitsFromNames].
+*/
+func (r *NamedBits) SetNames(names ...string) (err error) {
+	bits := make([]int, len(names))
+	for i, name := range names {
+		if bits[i] = r.bitOf(name); bits[i] < 0 {
+			err = primitiveErrorf("BIT STRING: unknown named bit ", name)
+			return
+		}
+	}
+
+	for _, bit := range bits {
+		r.BitString.grow(bit + 1)
+		r.BitString.Set(bit)
+	}
+
+	return
+}
+
+/*
+NamedBitsFromNames returns an instance of [NamedBits] bearing the input
+[NamedBit] definitions, in which the bits associated with the input names
+are set. Names which are not found within defs are ignored; see
+[NamedBits.SetNames] for a variant which reports such names.
+
+This is useful for configuration-driven flag construction, e.g.:
+
+	usage := NamedBitsFromNames(keyUsageBits, "digitalSignature", "keyCertSign")
+*/
+func NamedBitsFromNames(defs []NamedBit, names ...string) NamedBits {
+	nb := NamedBits{Bits: defs}
+	for _, name := range names {
+		if bit := nb.bitOf(name); bit >= 0 {
+			nb.BitString.grow(bit + 1)
+			nb.BitString.Set(bit)
+		}
+	}
+
+	return nb
+}
+
+/*
+bitOf returns the bit position associated with name, or -1 if not found.
+*/
+func (r NamedBits) bitOf(name string) int {
+	for _, bit := range r.Bits {
+		if streqf(bit.Name, name) && bit.Bit >= 0 {
+			return bit.Bit
+		}
+	}
+	return -1
+}
+
+/*
+grow extends the receiver instance to at least bitLen bits, padding with
+zero bits as needed. Existing bits are preserved.
+*/
+func (r *BitString) grow(bitLen int) {
+	if bitLen > r.BitLength {
+		r.Bytes = r.aligned(bitLen)
+		r.BitLength = bitLen
+	}
+}
+
 func assertBitString(x any) (raw []byte, err error) {
 	switch tv := x.(type) {
 	case []byte:
@@ -489,7 +636,7 @@ func (c *bitStringCodec[T]) write(pkt PDU, o *Options) (n int, err error) {
 	case BER, DER:
 		n, err = bcdBitStringWrite(c, pkt, o)
 	case CER:
-		if c.Tag() == TagBitString && len(toBitString(c.val).Bytes) > 1000 {
+		if c.Tag() == TagBitString && 1+len(toBitString(c.val).Bytes) > cerMaxSegment {
 			n, err = cerSegmentedBitStringWrite(c, pkt, o)
 		} else {
 			n, err = bcdBitStringWrite(c, pkt, o)
@@ -504,7 +651,7 @@ func (c *bitStringCodec[T]) write(pkt PDU, o *Options) (n int, err error) {
 func bcdBitStringWrite[T any](c *bitStringCodec[T], pkt PDU, o *Options) (off int, err error) {
 	o = deferImplicit(o)
 
-	cc := c.cg.phase(c.cphase, CodecConstraintEncoding)
+	cc := c.cg.phase(o.constraintPhase(c.cphase), CodecConstraintEncoding)
 	if err = cc(c.val); err == nil {
 		bsVal := toBitString(c.val)
 		remainder := bsVal.BitLength % 8
@@ -539,7 +686,7 @@ func (c *bitStringCodec[T]) read(pkt PDU, tlv TLV, o *Options) (err error) {
 	case BER, DER:
 		err = bcdBitStringRead(c, pkt, tlv, o)
 	case CER:
-		if tlv.Compound && tlv.Length < 0 && tlv.Tag == TagBitString {
+		if tlv.Compound && tlv.Length < 0 && c.Tag() == TagBitString {
 			err = cerSegmentedBitStringRead(c, pkt, tlv, o)
 		} else {
 			err = bcdBitStringRead(c, pkt, tlv, o)
@@ -599,7 +746,7 @@ func bcdBitStringRead[T any](c *bitStringCodec[T], pkt PDU, tlv TLV, o *Options)
 			}
 
 			if err == nil {
-				cc := c.cg.phase(c.cphase, CodecConstraintDecoding)
+				cc := c.cg.phase(o.constraintPhase(c.cphase), CodecConstraintDecoding)
 				if err = cc(out); err == nil {
 					c.val = out
 					pkt.AddOffset(tlv.Length)
@@ -611,8 +758,13 @@ func bcdBitStringRead[T any](c *bitStringCodec[T], pkt PDU, tlv TLV, o *Options)
 	return err
 }
 
+/*
+bitStringCheckDERPadding returns an error if rule is [DER] and any of the
+unused trailing bits of bits are non-zero, unless [StrictDERBitStringPadding]
+has been disabled.
+*/
 func bitStringCheckDERPadding(rule EncodingRule, bits []byte, unused int) (err error) {
-	if rule == DER && len(bits) > 0 && unused > 0 {
+	if rule == DER && StrictDERBitStringPadding && len(bits) > 0 && unused > 0 {
 		last := bits[len(bits)-1]
 		if last&((1<<unused)-1) != 0 {
 			err = primitiveErrorf("DER BIT STRING: non-zero padding")
@@ -159,6 +159,62 @@ func TestBitString_SetUnset(t *testing.T) {
 	}
 }
 
+func TestBitString_AndOrEqual(t *testing.T) {
+	for idx, tc := range []struct {
+		a, b    string
+		and, or string
+	}{
+		{`'1010'B`, `'0110'B`, `'0010'B`, `'1110'B`},
+		{`'1'B`, `'0110'B`, `'0000'B`, `'1110'B`},
+		{`'10000001'B`, `'1'B`, `'10000000'B`, `'10000001'B`},
+		{`'101100001'B`, `'11'B`, `'100000000'B`, `'111100001'B`},
+	} {
+		a, b := MustNewBitString(tc.a), MustNewBitString(tc.b)
+
+		and := MustNewBitString(tc.a)
+		and.And(b)
+		if want := MustNewBitString(tc.and); !and.Equal(want) {
+			t.Fatalf("%s[%d] failed [AND]: want %s, got %s", t.Name(), idx, want, and)
+		}
+
+		or := MustNewBitString(tc.a)
+		or.Or(b)
+		if want := MustNewBitString(tc.or); !or.Equal(want) {
+			t.Fatalf("%s[%d] failed [OR]: want %s, got %s", t.Name(), idx, want, or)
+		}
+
+		// operands are not modified
+		if !b.Equal(MustNewBitString(tc.b)) || !a.Equal(MustNewBitString(tc.a)) {
+			t.Fatalf("%s[%d] failed: operand was modified", t.Name(), idx)
+		}
+	}
+
+	a := MustNewBitString(`'1010'B`)
+	for idx, tc := range []struct {
+		o    BitString
+		want bool
+	}{
+		{MustNewBitString(`'1010'B`), true},
+		{BitString{Bytes: []byte{0xAF}, BitLength: 4}, true}, // unused bits ignored
+		{MustNewBitString(`'10100'B`), false},
+		{MustNewBitString(`'1011'B`), false},
+		{BitString{}, false},
+	} {
+		if got := a.Equal(tc.o); got != tc.want {
+			t.Fatalf("%s[%d] failed [Equal]: want %t, got %t", t.Name(), idx, tc.want, got)
+		}
+	}
+
+	var empty BitString
+	if !empty.Equal(BitString{}) {
+		t.Fatalf("%s failed: expected empty values to be equal", t.Name())
+	}
+	empty.Or(a)
+	if !empty.Equal(a) {
+		t.Fatalf("%s failed [OR empty]: want %s, got %s", t.Name(), a, empty)
+	}
+}
+
 func TestRightAlign(t *testing.T) {
 	input := "'101010'B"
 	bs, err := NewBitString(input)
@@ -253,6 +309,84 @@ func TestNamedBits_SetUnsetAndNames(t *testing.T) {
 	}
 }
 
+func TestStrictDERBitStringPadding(t *testing.T) {
+	// '1010'B with a deliberately non-zero (final) pad bit
+	data := []byte{0x03, 0x02, 0x04, 0xA1}
+
+	for _, tc := range []struct {
+		rule   EncodingRule
+		strict bool
+		fail   bool
+	}{
+		{BER, true, false},
+		{DER, true, true},
+		{DER, false, false},
+	} {
+		if !tc.rule.Enabled() {
+			continue
+		}
+
+		StrictDERBitStringPadding = tc.strict
+		var bs BitString
+		err := Unmarshal(tc.rule.New(data...), &bs)
+		StrictDERBitStringPadding = true
+
+		if tc.fail && err == nil {
+			t.Fatalf("%s failed [%s, strict:%t]: expected padding error, got nil", t.Name(), tc.rule, tc.strict)
+		} else if !tc.fail && err != nil {
+			t.Fatalf("%s failed [%s, strict:%t]: %v", t.Name(), tc.rule, tc.strict, err)
+		} else if !tc.fail && bs.Bits() != `'1010'B` {
+			t.Fatalf("%s failed [%s, strict:%t]: want '1010'B, got %s", t.Name(), tc.rule, tc.strict, bs.Bits())
+		}
+	}
+}
+
+func TestNamedBits_SetNames(t *testing.T) {
+	keyUsage := []NamedBit{
+		{Name: "digitalSignature", Bit: 0},
+		{Name: "nonRepudiation", Bit: 1},
+		{Name: "keyEncipherment", Bit: 2},
+		{Name: "keyCertSign", Bit: 5},
+		{Name: "decipherOnly", Bit: 8},
+	}
+
+	nb := NamedBitsFromNames(keyUsage, "digitalSignature", "KEYCERTSIGN", "bogus")
+	if want := `'100001'B`; nb.BitString.Bits() != want {
+		t.Fatalf("%s failed [from names]: want %s, got %s", t.Name(), want, nb.BitString.Bits())
+	} else if names := nb.Names(); !deepEq(names, []string{"digitalSignature", "keyCertSign"}) {
+		t.Fatalf("%s failed [names]: got %v", t.Name(), names)
+	}
+
+	// extend across an octet boundary
+	if err := nb.SetNames("decipherOnly", "nonRepudiation"); err != nil {
+		t.Fatalf("%s failed [set names]: %v", t.Name(), err)
+	} else if want := `'110001001'B`; nb.BitString.Bits() != want {
+		t.Fatalf("%s failed [set names]: want %s, got %s", t.Name(), want, nb.BitString.Bits())
+	}
+
+	// unknown names produce an error, leaving the value untouched
+	before := nb.BitString
+	if err := nb.SetNames("keyEncipherment", "bogus"); err == nil {
+		t.Fatalf("%s failed: expected error for unknown name, got nil", t.Name())
+	} else if !nb.BitString.Equal(before) || nb.Positive("keyEncipherment") {
+		t.Fatalf("%s failed: value modified despite error: %s", t.Name(), nb.BitString.Bits())
+	}
+
+	// round trip by name
+	pkt, err := Marshal(nb.BitString, With(BER))
+	if err != nil {
+		t.Fatalf("%s failed [encode]: %v", t.Name(), err)
+	}
+	var out BitString
+	if err = Unmarshal(pkt, &out); err != nil {
+		t.Fatalf("%s failed [decode]: %v", t.Name(), err)
+	}
+	got := NamedBits{BitString: out, Bits: keyUsage}
+	if want := []string{"digitalSignature", "nonRepudiation", "keyCertSign", "decipherOnly"}; !deepEq(got.Names(), want) {
+		t.Fatalf("%s failed [round trip]: want %v, got %v", t.Name(), want, got.Names())
+	}
+}
+
 func TestBitStringByteToBinary_Padding(t *testing.T) {
 	got := bitStringByteToBinary(3, 8)
 	want := "00000011"
@@ -75,10 +75,11 @@ Dump returns an error following an attempt to write the receiver
 instance into w.
 
 The variadic wrapAt value defines the maximum number of characters
-displayed per line before the value is wrapped. The default is 24,
-and can be configured no less than 16.
+displayed per line before the value is wrapped. The default is
+[DefaultDumpWidth], and an error is returned if a width less than
+[MinDumpWidth] is supplied.
 */
-func (r *CERPacket) Dump(w io.Writer, wrapAt ...int) error { return dumpPacket(r, w) }
+func (r *CERPacket) Dump(w io.Writer, wrapAt ...int) error { return dumpPacket(r, w, wrapAt...) }
 
 /*
 Len returns the integer length of the underlying byte buffer within
@@ -152,6 +153,14 @@ header to the receiver buffer.
 */
 func (r *CERPacket) WriteTLV(tlv TLV) error { return writeTLV(r, tlv, nil) }
 
+func (r *CERPacket) outputLimit() int     { return r.limit }
+func (r *CERPacket) setOutputLimit(n int) { r.limit = n }
+
+func (r *CERPacket) tagNames() map[[2]int]string         { return r.names }
+func (r *CERPacket) setTagNames(names map[[2]int]string) { r.names = names }
+
+func (r *CERPacket) reset() { (*BERPacket)(r).reset() }
+
 func decodeCERLength(data []byte, offset int) (length int, bytesRead int, err error) {
 	debugEnter(data, newLItem(offset, "off"))
 	defer func() {
@@ -203,51 +212,124 @@ func newCERPacket(src ...byte) PDU {
 	return (*CERPacket)(bp)
 }
 
-func cerSegmentedBitStringReadLoop(sub PDU) (lastUnused byte, full []byte, err error) {
-	for sub.HasMoreData() {
+/*
+cerSegmentedHeader returns the identifier and (indefinite) length octets
+of the outer TLV of a constructed [CER] string bearing tag, as overridden
+by an implicit tag within opts, if any.
+*/
+func cerSegmentedHeader(tag int, opts *Options) (hdr []byte) {
+	tag, cls := effectiveHeader(tag, 0, opts)
+	hdr = encodeTLV(TLV{typ: CER, Class: cls, Tag: tag, Compound: true}, nil)
+	hdr[len(hdr)-1] = indefByte // zero length octet becomes indefinite
+
+	return
+}
+
+/*
+cerSegmentedBadTLV returns an error if outer is not the constructed,
+indefinite-length TLV of a [CER] string bearing tag, as overridden by
+an implicit tag within opts, if any.
+*/
+func cerSegmentedBadTLV(tag int, outer TLV, opts *Options) (err error) {
+	want, cls := effectiveHeader(tag, 0, opts)
+	if outer.Class != cls ||
+		outer.Tag != want ||
+		!outer.Compound ||
+		outer.Length != -1 {
+		err = primitiveErrorf(TagNames[tag], ": not CER indefinite")
+	}
+
+	return
+}
+
+/*
+cerReadSegments returns the segments of the constructed [CER] string
+bearing tag whose content begins at the current offset of pkt, alongside
+the number of octets consumed (including the end-of-contents octets) and
+an error.
+
+Each segment must be a primitive UNIVERSAL TLV bearing tag, and all
+segments save for the last must bear exactly [cerMaxSegment] content
+octets, per § 9.2 of ITU-T Rec. X.690.
+
+Segments are walked from pkt itself, rather than from the value of the
+outer TLV, as the latter ends at the first pair of zero octets -- which
+may well reside within the content of a segment.
+*/
+func cerReadSegments(pkt PDU, tag int) (segs []TLV, n int, err error) {
+	sub := CER.New(pkt.Data()[pkt.Offset():]...)
+	sub.SetOffset(0)
+
+	var eoc bool
+	for sub.HasMoreData() && !eoc && err == nil {
 		var seg TLV
-		if seg, err = sub.TLV(); err == nil {
-			if seg.Class == ClassUniversal && seg.Tag == 0 && seg.Length == 0 {
-				break
-			}
+		if seg, err = sub.TLV(); err != nil {
+			break
+		} else if eoc = seg.Class == ClassUniversal && seg.Tag == 0 && seg.Length == 0; eoc {
+			break
+		}
 
-			if len(seg.Value) == 0 {
-				err = primitiveErrorf("BIT STRING: inner segment too short")
-				return
-			}
-			lastUnused = seg.Value[0]
-			full = append(full, seg.Value[1:]...)
+		idx := len(segs)
+		if seg.Class != ClassUniversal || seg.Tag != tag || seg.Compound {
+			err = primitiveErrorf(TagNames[tag], ": segment ", idx, " is not a primitive ", TagNames[tag])
+		} else if seg.Length > cerMaxSegment {
+			err = primitiveErrorf(TagNames[tag], ": segment ", idx, " exceeds ", cerMaxSegment, " octets")
+		} else if idx > 0 && segs[idx-1].Length != cerMaxSegment {
+			err = primitiveErrorf(TagNames[tag], ": segment ", idx-1, " bears ",
+				segs[idx-1].Length, " octets; CER requires ", cerMaxSegment)
+		} else {
+			segs = append(segs, seg)
 			sub.SetOffset(sub.Offset() + seg.Length)
 		}
 	}
 
+	if err == nil {
+		if !eoc {
+			err = primitiveErrorf(TagNames[tag], ": missing end-of-contents octets")
+		}
+		n = sub.Offset()
+	}
+
 	return
 }
 
+/*
+cerSegmentedBitStringRead returns an error following an attempt to
+reassemble the segments of the constructed indefinite-length [CER] BIT
+STRING outer into the receiver's value. Each segment begins with its own
+unused-bits octet, which must be zero (0) for all segments save for the
+last, per § 8.6.4 of ITU-T Rec. X.690.
+*/
 func cerSegmentedBitStringRead[T any](
 	c *bitStringCodec[T],
 	pkt PDU,
 	outer TLV,
 	opts *Options,
 ) (err error) {
-	if outer.Class != ClassUniversal ||
-		outer.Tag != TagBitString ||
-		!outer.Compound ||
-		outer.Length != -1 {
-		return primitiveErrorf("BIT STRING: cerSegmentedBitStringRead: not CER indefinite")
+	opts = deferImplicit(opts)
+	if err = cerSegmentedBadTLV(TagBitString, outer, opts); err != nil {
+		return
 	}
 
-	sub := CER.New(outer.Value...)
-	sub.SetOffset(0)
+	var segs []TLV
+	var consumed int
+	if segs, consumed, err = cerReadSegments(pkt, TagBitString); err != nil {
+		return
+	}
 
 	var lastUnused byte
 	var full []byte
-	if lastUnused, full, err = cerSegmentedBitStringReadLoop(sub); err != nil {
-		return
+	for i := 0; i < len(segs) && err == nil; i++ {
+		val := segs[i].Value
+		last := i == len(segs)-1
+		if len(val) == 0 || val[0] > 7 || (!last && val[0] != 0) || (len(val) == 1 && val[0] != 0) {
+			err = primitiveErrorf("BIT STRING: invalid unused bits octet in segment ", i)
+		} else {
+			lastUnused = val[0]
+			full = append(full, val[1:]...)
+		}
 	}
 
-	bitLength := len(full)*8 - int(lastUnused)
-
 	for i := 0; i < len(c.decodeVerify) && err == nil; i++ {
 		err = c.decodeVerify[i](full)
 	}
@@ -258,15 +340,16 @@ func cerSegmentedBitStringRead[T any](
 			out, err = c.decodeHook(append([]byte{lastUnused}, full...))
 		} else {
 			out = fromBitString[T](BitString{
-				Bytes:     append([]byte(nil), full...),
-				BitLength: bitLength,
+				Bytes:     full,
+				BitLength: len(full)*8 - int(lastUnused),
 			})
 		}
 
 		if err == nil {
-			cc := c.cg.phase(c.cphase, CodecConstraintDecoding)
+			cc := c.cg.phase(opts.constraintPhase(c.cphase), CodecConstraintDecoding)
 			if err = cc(out); err == nil {
 				c.val = out
+				pkt.AddOffset(consumed)
 			}
 		}
 	}
@@ -274,164 +357,169 @@ func cerSegmentedBitStringRead[T any](
 	return err
 }
 
+/*
+cerSegmentedBitStringWrite returns the number of bytes written to pkt
+alongside an error following an attempt to write the receiver's value in
+the constructed indefinite-length form required by [CER] for BIT STRING
+values whose content would exceed [cerMaxSegment] octets. Each segment is
+a primitive UNIVERSAL BIT STRING of at most [cerMaxSegment] content octets,
+including its leading unused-bits octet, which is zero (0) for all segments
+save for the last.
+*/
 func cerSegmentedBitStringWrite[T any](
 	c *bitStringCodec[T],
 	pkt PDU,
 	opts *Options,
 ) (n int, err error) {
-	const maxSegData = 1000
-
-	cc := c.cg.phase(c.cphase, CodecConstraintEncoding)
-	if err = cc(c.val); err == nil {
-		bs := toBitString(c.val)
-		data := bs.Bytes
-		total := len(data)
-		remBits := bs.BitLength % 8
-		overallUnused := 0
-		if remBits != 0 {
-			overallUnused = 8 - remBits
-		}
+	const maxSegData = cerMaxSegment - 1 // less the unused-bits octet
 
-		hdr := []byte{byte(TagBitString) | cmpndByte, indefByte}
-		pkt.Append(hdr...)
-		n += len(hdr)
+	opts = deferImplicit(opts)
+	cc := c.cg.phase(opts.constraintPhase(c.cphase), CodecConstraintEncoding)
+	if err = cc(c.val); err != nil {
+		return
+	}
 
-		for off := 0; off < total; off += maxSegData {
-			end := off + maxSegData
-			if end > total {
-				end = total
-			}
-			segUnused := 0
-			if end == total {
-				segUnused = overallUnused
-			}
+	bs := toBitString(c.val)
+	data := bs.Bytes
+	total := len(data)
+	overallUnused := 0
+	if remBits := bs.BitLength % 8; remBits != 0 {
+		overallUnused = 8 - remBits
+	}
+
+	hdr := cerSegmentedHeader(TagBitString, opts)
+	pkt.Append(hdr...)
+	n += len(hdr)
 
-			val := make([]byte, 1+(end-off))
-			val[0] = byte(segUnused)
-			copy(val[1:], data[off:end])
-
-			prim := CER.newTLV(
-				ClassUniversal, TagBitString,
-				len(val), false,
-				val...,
-			)
-			enc := encodeTLV(prim, nil)
-			pkt.Append(enc...)
-			n += len(enc)
+	for off := 0; off < total; off += maxSegData {
+		end := min(off+maxSegData, total)
+		segUnused := 0
+		if end == total {
+			segUnused = overallUnused
 		}
 
-		pkt.Append(indefEoC...)
-		n += 2
+		val := make([]byte, 1+(end-off))
+		val[0] = byte(segUnused)
+		copy(val[1:], data[off:end])
+
+		prim := CER.newTLV(
+			ClassUniversal, TagBitString,
+			len(val), false,
+			val...,
+		)
+		enc := encodeTLV(prim, nil)
+		pkt.Append(enc...)
+		n += len(enc)
 	}
 
-	return n, nil
+	pkt.Append(indefEoC...)
+	n += len(indefEoC)
+	pkt.SetOffset(pkt.Len())
+
+	return
 }
 
+/*
+cerSegmentedOctetStringWrite returns the number of bytes written to pkt
+alongside an error following an attempt to write the receiver's value in
+the constructed indefinite-length form required by [CER] for OCTET STRING
+values exceeding [cerMaxSegment] octets. Each segment is a primitive
+UNIVERSAL OCTET STRING of [cerMaxSegment] octets, save for the last, which
+may be shorter. An implicit tag within opts, if any, replaces the tag of
+the outer (constructed) TLV only.
+*/
 func cerSegmentedOctetStringWrite[T TextLike](
 	c *textCodec[T],
 	pkt PDU,
 	opts *Options,
 ) (written int, err error) {
-	const maxSegSize = 1000
-
-	cc := c.cg.phase(c.cphase, CodecConstraintEncoding)
-	if err = cc(c.val); err == nil {
-		var wire []byte
-		if c.encodeHook != nil {
-			wire, err = c.encodeHook(c.val)
-		} else {
-			wire = []byte(c.val)
-		}
-		if err != nil {
-			return 0, err
-		}
+	opts = deferImplicit(opts)
 
-		// outer header: OCTET STRING|constructed, indefinite
-		hdr := []byte{byte(TagOctetString) | cmpndByte, indefByte}
-		pkt.Append(hdr...)
-		written += len(hdr)
+	cc := c.cg.phase(opts.constraintPhase(c.cphase), CodecConstraintEncoding)
+	if err = cc(c.val); err != nil {
+		return
+	}
 
-		// break into 1000‐byte primitive‐OCTET‐STRING TLVs
-		for off := 0; off < len(wire); off += maxSegSize {
-			end := off + maxSegSize
-			if end > len(wire) {
-				end = len(wire)
-			}
-			prim := CER.newTLV(
-				ClassUniversal,
-				TagOctetString,
-				end-off, false,
-				wire[off:end]...,
-			)
-			enc := encodeTLV(prim, nil)
-			pkt.Append(enc...)
-			written += len(enc)
+	var wire []byte
+	if c.encodeHook != nil {
+		if wire, err = c.encodeHook(c.val); err != nil {
+			return
 		}
-
-		// EOC
-		pkt.Append(indefEoC...)
-		written += 2
+	} else {
+		wire = []byte(c.val)
 	}
 
-	return written, nil
-}
-
-func cerOctetStringReadBadTLV(outer TLV) (err error) {
-	if outer.Class != ClassUniversal ||
-		outer.Tag != TagOctetString ||
-		!outer.Compound ||
-		outer.Length != -1 {
-		err = primitiveErrorf("OCTET STRING: cerSegmentedOctetStringRead: not CER indefinite")
+	// outer header: OCTET STRING|constructed, indefinite
+	hdr := cerSegmentedHeader(c.tag, opts)
+	pkt.Append(hdr...)
+	written += len(hdr)
+
+	// break into 1000-octet primitive OCTET STRING TLVs
+	for off := 0; off < len(wire); off += cerMaxSegment {
+		end := min(off+cerMaxSegment, len(wire))
+		prim := CER.newTLV(
+			ClassUniversal,
+			TagOctetString,
+			end-off, false,
+			wire[off:end]...,
+		)
+		enc := encodeTLV(prim, nil)
+		pkt.Append(enc...)
+		written += len(enc)
 	}
 
+	// EOC
+	pkt.Append(indefEoC...)
+	written += len(indefEoC)
+	pkt.SetOffset(pkt.Len())
+
 	return
 }
 
+/*
+cerSegmentedOctetStringRead returns an error following an attempt to
+reassemble the segments of the constructed indefinite-length [CER] OCTET
+STRING outer into the receiver's value.
+*/
 func cerSegmentedOctetStringRead[T TextLike](
 	c *textCodec[T],
 	pkt PDU,
 	outer TLV,
 	opts *Options,
 ) (err error) {
-	// validate the outer TLV
-	if err = cerOctetStringReadBadTLV(outer); err != nil {
+	opts = deferImplicit(opts)
+	if err = cerSegmentedBadTLV(TagOctetString, outer, opts); err != nil {
 		return
 	}
 
-	sub := CER.New(outer.Value...)
-	sub.SetOffset(0)
+	var segs []TLV
+	var consumed int
+	if segs, consumed, err = cerReadSegments(pkt, TagOctetString); err != nil {
+		return
+	}
 
 	var full []byte
-	for sub.HasMoreData() && err == nil {
-		var seg TLV
-		if seg, err = sub.TLV(); err == nil {
-			if seg.Class == ClassUniversal && seg.Tag == 0 && seg.Length == 0 {
-				break
-			}
+	for _, seg := range segs {
+		full = append(full, seg.Value...)
+	}
 
-			full = append(full, seg.Value...)
-			sub.SetOffset(sub.Offset() + seg.Length)
-		}
+	for i := 0; i < len(c.decodeVerify) && err == nil; i++ {
+		err = c.decodeVerify[i](full)
 	}
 
 	if err == nil {
-		for i := 0; i < len(c.decodeVerify) && err == nil; i++ {
-			err = c.decodeVerify[i](full)
+		var val T
+		if c.decodeHook != nil {
+			val, err = c.decodeHook(full)
+		} else {
+			// copy to avoid aliasing original slice
+			val = T(append([]byte(nil), full...))
 		}
-
 		if err == nil {
-			var val T
-			if c.decodeHook != nil {
-				val, err = c.decodeHook(full)
-			} else {
-				// copy to avoid aliasing original slice
-				val = T(append([]byte(nil), full...))
-			}
-			if err == nil {
-				cc := c.cg.phase(c.cphase, CodecConstraintDecoding)
-				if err = cc(val); err == nil {
-					c.val = val
-				}
+			cc := c.cg.phase(opts.constraintPhase(c.cphase), CodecConstraintDecoding)
+			if err = cc(val); err == nil {
+				c.val = val
+				pkt.AddOffset(consumed)
 			}
 		}
 	}
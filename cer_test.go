@@ -70,6 +70,157 @@ func TestPDU_LargeOctetStringCER(t *testing.T) {
 	}
 }
 
+func TestPDU_SegmentedOctetStringCER(t *testing.T) {
+	// include runs of zero octets, which must not be
+	// mistaken for the end-of-contents octets.
+	data := make([]byte, 2500)
+	for i := range data {
+		data[i] = byte(i / 3 % 0x80)
+	}
+
+	pkt, err := Marshal(OctetString(data), With(CER))
+	if err != nil {
+		t.Fatalf("%s failed [CER encoding]: %v", t.Name(), err)
+	}
+
+	// 24 80 | 04 82 03E8 ... | 04 82 03E8 ... | 04 82 01F4 ... | 00 00
+	enc := pkt.Data()
+	if want := 2 + 2*(4+1000) + (4 + 500) + 2; len(enc) != want {
+		t.Fatalf("%s failed [CER segmented length]:\n\twant: %d\n\tgot:  %d",
+			t.Name(), want, len(enc))
+	}
+	for i, seg := range []struct {
+		off int
+		hdr []byte
+	}{
+		{0, []byte{0x24, 0x80}},
+		{2, []byte{0x04, 0x82, 0x03, 0xE8}},
+		{1006, []byte{0x04, 0x82, 0x03, 0xE8}},
+		{2010, []byte{0x04, 0x82, 0x01, 0xF4}},
+		{2514, []byte{0x00, 0x00}},
+	} {
+		if got := enc[seg.off : seg.off+len(seg.hdr)]; !btseq(got, seg.hdr) {
+			t.Fatalf("%s failed [CER segment %d]:\n\twant: %X\n\tgot:  %X",
+				t.Name(), i, seg.hdr, got)
+		}
+	}
+
+	var out OctetString
+	if err = Unmarshal(pkt, &out); err != nil {
+		t.Fatalf("%s failed [CER decoding]: %v", t.Name(), err)
+	} else if !btseq([]byte(out), data) {
+		t.Fatalf("%s failed [CER reassembly]: contents differ", t.Name())
+	}
+
+	// segmented value followed by another component
+	type Seq struct {
+		Large OctetString `asn1:"tag:0"`
+		Small OctetString
+	}
+	in := Seq{Large: OctetString(data), Small: OctetString("x")}
+	if pkt, err = Marshal(in, With(CER)); err != nil {
+		t.Fatalf("%s failed [CER SEQUENCE encoding]: %v", t.Name(), err)
+	}
+	var seq Seq
+	if err = Unmarshal(pkt, &seq); err != nil {
+		t.Fatalf("%s failed [CER SEQUENCE decoding]: %v", t.Name(), err)
+	} else if !btseq([]byte(seq.Large), data) || !btseq(seq.Small, in.Small) {
+		t.Fatalf("%s failed [CER SEQUENCE reassembly]: contents differ", t.Name())
+	}
+
+	// non-final segments must bear exactly 1000 octets
+	bad := []byte{0x24, 0x80, 0x04, 0x01, 0x61, 0x04, 0x01, 0x62, 0x00, 0x00}
+	if err = Unmarshal(CER.New(bad...), &out); err == nil {
+		t.Fatalf("%s failed [CER short segment]: expected error, got nil", t.Name())
+	}
+
+	// segments must be primitive OCTET STRINGs
+	bad = []byte{0x24, 0x80, 0x0C, 0x01, 0x61, 0x00, 0x00}
+	if err = Unmarshal(CER.New(bad...), &out); err == nil {
+		t.Fatalf("%s failed [CER segment tag]: expected error, got nil", t.Name())
+	}
+}
+
+func TestPDU_SegmentedBitStringCER(t *testing.T) {
+	data := make([]byte, 2500)
+	for i := range data {
+		data[i] = byte(i / 3)
+	}
+	data[len(data)-1] = 0xF8 // last three bits unused
+	bs := BitString{Bytes: data, BitLength: len(data)*8 - 3}
+
+	pkt, err := Marshal(bs, With(CER))
+	if err != nil {
+		t.Fatalf("%s failed [CER encoding]: %v", t.Name(), err)
+	}
+
+	// each segment bears at most 1000 content octets, the
+	// first of which is the unused-bits octet. Only the last
+	// segment may declare unused bits.
+	enc := pkt.Data()
+	if want := 2 + 2*(4+1000) + (4 + 503) + 2; len(enc) != want {
+		t.Fatalf("%s failed [CER segmented length]:\n\twant: %d\n\tgot:  %d",
+			t.Name(), want, len(enc))
+	}
+	for i, seg := range []struct {
+		off int
+		hdr []byte
+	}{
+		{0, []byte{0x23, 0x80}},
+		{2, []byte{0x03, 0x82, 0x03, 0xE8, 0x00}},
+		{1006, []byte{0x03, 0x82, 0x03, 0xE8, 0x00}},
+		{2010, []byte{0x03, 0x82, 0x01, 0xF7, 0x03}},
+		{2517, []byte{0x00, 0x00}},
+	} {
+		if got := enc[seg.off : seg.off+len(seg.hdr)]; !btseq(got, seg.hdr) {
+			t.Fatalf("%s failed [CER segment %d]:\n\twant: %X\n\tgot:  %X",
+				t.Name(), i, seg.hdr, got)
+		}
+	}
+
+	var out BitString
+	if err = Unmarshal(pkt, &out); err != nil {
+		t.Fatalf("%s failed [CER decoding]: %v", t.Name(), err)
+	} else if out.BitLength != bs.BitLength {
+		t.Fatalf("%s failed [CER BitLength]:\n\twant: %d\n\tgot:  %d",
+			t.Name(), bs.BitLength, out.BitLength)
+	} else if !btseq(out.Bytes, data) {
+		t.Fatalf("%s failed [CER reassembly]: contents differ", t.Name())
+	}
+
+	// 999 octets plus the unused-bits octet fit within one TLV
+	short := BitString{Bytes: data[:999], BitLength: 999 * 8}
+	if pkt, err = Marshal(short, With(CER)); err != nil {
+		t.Fatalf("%s failed [CER primitive encoding]: %v", t.Name(), err)
+	} else if hdr := pkt.Data()[:4]; !btseq(hdr, []byte{0x03, 0x82, 0x03, 0xE8}) {
+		t.Fatalf("%s failed [CER primitive header]: got %X", t.Name(), hdr)
+	}
+
+	// segmented value followed by another component
+	type Seq struct {
+		Large BitString `asn1:"tag:0"`
+		Small BitString
+	}
+	in := Seq{Large: bs, Small: BitString{Bytes: []byte{0x80}, BitLength: 1}}
+	if pkt, err = Marshal(in, With(CER)); err != nil {
+		t.Fatalf("%s failed [CER SEQUENCE encoding]: %v", t.Name(), err)
+	}
+	var seq Seq
+	if err = Unmarshal(pkt, &seq); err != nil {
+		t.Fatalf("%s failed [CER SEQUENCE decoding]: %v", t.Name(), err)
+	} else if seq.Large.BitLength != bs.BitLength || seq.Small.BitLength != 1 {
+		t.Fatalf("%s failed [CER SEQUENCE BitLength]: got %d, %d",
+			t.Name(), seq.Large.BitLength, seq.Small.BitLength)
+	}
+
+	// only the last segment may declare unused bits
+	bad := append([]byte{0x23, 0x80, 0x03, 0x82, 0x03, 0xE8, 0x01}, data[:999]...)
+	bad = append(bad, 0x03, 0x02, 0x00, 0xFF, 0x00, 0x00)
+	if err = Unmarshal(CER.New(bad...), &out); err == nil {
+		t.Fatalf("%s failed [CER unused bits]: expected error, got nil", t.Name())
+	}
+}
+
 func TestPDU_LargeBitStringCER(t *testing.T) {
 	data := []byte(strrpt("Y", 2001))
 	large := BitString{
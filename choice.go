@@ -2,6 +2,7 @@ package asn1plus
 
 import (
 	"reflect"
+	"slices"
 	"sync"
 )
 
@@ -58,24 +59,69 @@ func NewChoice(v any, tag ...int) Choice {
 	return wrappedChoice{inner: v, tag: t}
 }
 
+/*
+ChoiceValue returns the value residing within [Choice] c as an instance
+of T, alongside a Boolean value indicative of success. This is a safer
+alternative to asserting the return value of the Value method directly.
+
+A value held by pointer (e.g.: *[ObjectIdentifier]) satisfies T of the
+underlying type, and a nested [Choice] is unwrapped until a value of T
+is found. A nil or invalid c, or a value which is not of T, yields the
+zero value of T and false.
+*/
+func ChoiceValue[T any](c Choice) (val T, ok bool) {
+	for c != nil && !ok {
+		switch tv := c.Value().(type) {
+		case T:
+			val, ok = tv, true
+		case *T:
+			if ok = tv != nil; ok {
+				val = *tv
+			}
+			return
+		case Choice:
+			c = tv
+		default:
+			return
+		}
+	}
+
+	return
+}
+
 var (
 	choicesRegistry map[string]Choices
 	chMu            sync.RWMutex
 )
 
 /*
-RegisterChoices associates the input string name and [Choices]
-instances within the central registry of [Choices]. Note that
-case folding is not significant in the registration process,
-however the input [Choices] instance MUST have a length greater
-than zero (0)
+RegisterChoices returns an error following an attempt to associate the
+input string name and [Choices] instances within the central registry
+of [Choices]. Note that case folding is not significant in the
+registration process, however the input [Choices] instance MUST have a
+length greater than zero (0)
+
+If the input [Choices] instance was configured for automatic tagging
+(see [NewChoices]), all alternatives must be EXPLICIT. A registry which
+mixes EXPLICIT and IMPLICIT alternatives is rejected, as such a mixture
+cannot be reliably decoded.
+
+The variadic validate input value, if true, additionally subjects the
+input [Choices] instance to [Choices.Validate] prior to registration.
 */
-func RegisterChoices(name string, choices Choices) {
+func RegisterChoices(name string, choices Choices, validate ...bool) (err error) {
 	if choices.Len() > 0 {
-		chMu.Lock()
-		defer chMu.Unlock()
-		choicesRegistry[lc(name)] = choices
+		if err = choices.checkAutomaticExplicit(); err == nil && len(validate) > 0 && validate[0] {
+			err = choices.Validate()
+		}
+		if err == nil {
+			chMu.Lock()
+			defer chMu.Unlock()
+			choicesRegistry[lc(name)] = choices
+		}
 	}
+
+	return
 }
 
 /*
@@ -120,7 +166,24 @@ type choiceDescriptor struct {
 	tagToType map[int]reflect.Type
 	typeToTag map[reflect.Type]int
 	explicit  map[int]bool
-	class     map[int]int // tag->class
+	class     map[int]int             // tag->class
+	nested    map[int]string          // tag->Choices name, for nested CHOICEs
+	constr    map[int]ConstraintGroup // tag->constraints, run upon decoding
+	names     map[int]string          // tag->identifier, for textual encoding rules
+}
+
+/*
+constrain returns an error following the execution of any constraints
+registered for the alternative bearing tag against val, unless the
+constraint phase of opts is [CodecConstraintNone].
+*/
+func (r *choiceDescriptor) constrain(tag int, val reflect.Value, opts *Options) (err error) {
+	if cg, ok := r.constr[tag]; ok && opts.constraintPhase(CodecConstraintBoth) != CodecConstraintNone {
+		if err = cg.Constrain(val.Interface()); err != nil {
+			err = choiceErrorf("alternative [", tag, "] ", val.Type(), ": ", err)
+		}
+	}
+	return
 }
 
 /*
@@ -156,25 +219,65 @@ func (r Choices) Len() int { return len(r.reg) }
 Register creates a registration within the receiver instance, which
 associates ifacePtr, alt, class, tag and explicit for later use in
 an ASN.1 CHOICE selection.
+
+The variadic args input value accepts an [Options] instance (or pointer
+thereto), which conveys the class, tag and explicit state, as well as any
+number of [Constraint] or [ConstraintGroup] instances. Constraints are
+executed against the alternative each time it is selected while decoding,
+once its value has been decoded, unless all constraints are disabled for
+the operation (e.g.: [WithoutConstraints]).
+
+An alternative which is itself a CHOICE may be registered by supplying
+a [Choice] as concrete, e.g.: (*Choice)(nil), alongside an [Options]
+instance whose Choices field names the registry of [Choices] which
+governs the nested CHOICE. Per ITU-T Rec. X.680, such alternatives are
+always EXPLICIT.
+
+The Name field of the [Options] instance, if set, declares the identifier
+of the alternative. Identifiers are used by the [JER] encoding rule to key
+the alternative, and are required when several alternatives share a type.
 */
 func (r Choices) Register(
 	ifacePtr any,
 	concrete any,
-	opts ...*Options,
+	args ...any,
 ) (err error) {
 
 	class := ClassContextSpecific
 	tag := -1
 	explicit := false
+	var (
+		nested string
+		name   string
+		opts   *Options
+		cg     ConstraintGroup
+	)
+
+	for _, arg := range args {
+		switch tv := arg.(type) {
+		case *Options:
+			opts = tv
+		case Options:
+			opts = &tv
+		case Constraint:
+			cg = append(cg, tv)
+		case func(any) error:
+			cg = append(cg, tv)
+		case ConstraintGroup:
+			cg = append(cg, tv...)
+		}
+	}
 
-	if len(opts) > 0 && opts[0] != nil {
-		if opts[0].Class() != ClassUniversal {
-			class = opts[0].Class()
+	if opts != nil {
+		if opts.Class() != ClassUniversal {
+			class = opts.Class()
 		}
-		if opts[0].HasTag() {
-			tag = opts[0].Tag()
+		if opts.HasTag() {
+			tag = opts.Tag()
 		}
-		explicit = opts[0].Explicit
+		explicit = opts.Explicit
+		nested = opts.Choices
+		name = opts.Name
 	}
 
 	debugEnter(
@@ -186,6 +289,13 @@ func (r Choices) Register(
 
 	defer func() { debugExit(newLItem(err)) }()
 
+	// A CHOICE alternative is recorded under the Choice
+	// interface type, regardless of its implementation.
+	altType := choiceAlternativeType(concrete)
+	if altType == choicePtrType {
+		explicit = true
+	}
+
 	// always group alternatives under the Choice
 	// interface if none was specified
 	var key reflect.Type
@@ -194,7 +304,7 @@ func (r Choices) Register(
 	} else if r.auto {
 		key = choicePtrType
 	} else {
-		key = derefTypePtr(refTypeOf(concrete))
+		key = altType
 	}
 
 	cd, ok := r.reg[key]
@@ -204,6 +314,9 @@ func (r Choices) Register(
 			typeToTag: make(map[reflect.Type]int),
 			explicit:  make(map[int]bool),
 			class:     make(map[int]int), // tag->class
+			nested:    make(map[int]string),
+			constr:    make(map[int]ConstraintGroup),
+			names:     make(map[int]string),
 		}
 		r.reg[key] = cd
 	}
@@ -227,11 +340,125 @@ func (r Choices) Register(
 	}
 
 	// Record the alternative
-	altType := derefTypePtr(refTypeOf(concrete))
 	cd.tagToType[tag] = altType
 	cd.typeToTag[altType] = tag
 	cd.class[tag] = class
 	cd.explicit[tag] = explicit
+	if altType == choicePtrType && nested != "" {
+		cd.nested[tag] = nested
+	}
+	if len(cg) > 0 {
+		cd.constr[tag] = cg
+	}
+	if name != "" {
+		cd.names[tag] = name
+	}
+
+	return
+}
+
+/*
+choiceRecursive returns a Boolean value indicative of v being a slice of
+interface values, such as a SET OF a CHOICE within which the slice type
+itself is an alternative.
+*/
+func choiceRecursive(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Interface
+}
+
+/*
+choiceAlternativeType returns the reflect.Type under which concrete is
+recorded as an alternative. Any implementation of [Choice] -- such as
+an instance returned by [NewChoice] -- yields the Choice interface type.
+*/
+func choiceAlternativeType(concrete any) (typ reflect.Type) {
+	if _, ok := concrete.(Choice); ok {
+		typ = choicePtrType
+	} else if typ = derefTypePtr(refTypeOf(concrete)); typ.Implements(choicePtrType) {
+		typ = choicePtrType
+	}
+
+	return
+}
+
+/*
+checkAutomaticExplicit returns an error if the receiver instance is
+configured for automatic tagging, and any of its alternatives are
+not EXPLICIT. The offending alternative bearing the lowest tag is
+reported.
+*/
+func (r Choices) checkAutomaticExplicit() (err error) {
+	if !r.auto {
+		return
+	}
+
+	bad := -1
+	var badType reflect.Type
+	for _, cd := range r.reg {
+		for tag, explicit := range cd.explicit {
+			if !explicit && (bad < 0 || tag < bad) {
+				bad, badType = tag, cd.tagToType[tag]
+			}
+		}
+	}
+
+	if bad >= 0 {
+		err = choiceErrorf("automatic tagging requires EXPLICIT alternatives; ",
+			badType, " [", bad, "] is IMPLICIT")
+	}
+
+	return
+}
+
+/*
+Validate returns an error if any two alternatives within the receiver
+instance share the same class and tag, as the alternative selected when
+decoding such a tag is undefined. This includes alternatives registered
+under different interfaces, as well as untagged alternatives, which are
+compared by way of the UNIVERSAL tag implied by their types.
+
+As [Choices.Register] only rejects duplicate tags amongst alternatives
+registered under the same interface, it is recommended this method be
+called once all registrations have been made, or that the receiver be
+registered by way of [RegisterChoices] with validation enabled.
+*/
+func (r Choices) Validate() (err error) {
+	type alternative struct {
+		class, tag int
+		typ        reflect.Type
+	}
+
+	var alts []alternative
+	for _, cd := range r.reg {
+		for tag, typ := range cd.tagToType {
+			class := cd.class[tag]
+			if tag < 0 {
+				var ok bool
+				if class, tag, ok = untaggedClassAndTag(refNew(typ).Elem(), &Options{}); !ok {
+					continue // unknowable, e.g.: a nested CHOICE
+				}
+			}
+			alts = append(alts, alternative{class, tag, typ})
+		}
+	}
+
+	slices.SortFunc(alts, func(a, b alternative) int {
+		if a.class != b.class {
+			return a.class - b.class
+		} else if a.tag != b.tag {
+			return a.tag - b.tag
+		} else if a.typ.String() < b.typ.String() {
+			return -1
+		}
+		return 1
+	})
+
+	for i := 1; i < len(alts) && err == nil; i++ {
+		if a, b := alts[i-1], alts[i]; a.class == b.class && a.tag == b.tag {
+			err = choiceErrorf("ambiguous alternatives ", a.typ, " and ", b.typ,
+				" share [", ClassNames[a.class], " ", a.tag, "]")
+		}
+	}
 
 	return
 }
@@ -294,6 +521,19 @@ func (r Choices) lookupDescriptorByTag(tag int) (
 	return
 }
 
+/*
+hasAlternative returns a Boolean value indicative of an alternative
+bearing class and tag having been registered within the receiver.
+*/
+func (r Choices) hasAlternative(class, tag int) (ok bool) {
+	var desc *choiceDescriptor
+	if _, desc, ok = r.lookupDescriptorByTag(tag); ok {
+		ok = desc.class[tag] == class
+	}
+
+	return
+}
+
 func (r *Choices) lookupDescriptorByConcrete(concrete reflect.Type) (iface reflect.Type, desc *choiceDescriptor, ok bool) {
 	for ifaceType, d := range r.reg {
 		if _, exists := d.typeToTag[concrete]; exists {
@@ -347,25 +587,15 @@ func marshalChoiceWrapper(
 
 	typ := pkt.Type()
 
-	// marshal the inner TLV (UNIVERSAL
-	// class) into a temp PDU
-	tmp := typ.New()
-	innerOpts := clearChildOpts(opts)
-	innerOpts.Choices = ""
-	if err = marshalValue(refValueOf(inner), tmp, innerOpts); err != nil {
-		err = choiceErr{err}
-		return
-	}
-	innerBytes := tmp.Data()
-
 	// decide which tag, class, explicit to emit
 	tag := cw.Tag()               // user override or -1
 	class := ClassContextSpecific // default
 	explicit := true              // always explicit for choice
+	var nested string
 
 	if tag < 0 {
 		// no override -> look up registry by concrete type
-		t := derefTypePtr(refTypeOf(inner))
+		t := choiceAlternativeType(inner)
 		_, desc, ok := cho.lookupDescriptorByConcrete(t)
 		if !ok {
 			err = choiceErrorf("marshalChoiceWrapper: no alternative for ", t)
@@ -374,8 +604,34 @@ func marshalChoiceWrapper(
 		tag = desc.typeToTag[t]
 		class = desc.class[tag]
 		explicit = desc.explicit[tag]
+		nested = desc.nested[tag]
+	} else if _, desc, ok := cho.lookupDescriptorByTag(tag); ok {
+		nested = desc.nested[tag]
 	}
 
+	// marshal the inner TLV (UNIVERSAL class) into a temp
+	// PDU. A nested CHOICE is resolved using its own registry.
+	tmp := newSubPacket(pkt)
+	innerOpts := clearChildOpts(opts)
+	innerOpts.Choices = nested
+	if iv := refValueOf(inner); nested == "" && choiceRecursive(iv) {
+		// SET OF or SEQUENCE OF the CHOICE itself (e.g.: an LDAP
+		// "and" filter), the elements of which use this registry.
+		innerOpts.Choices = opts.Choices
+		if innerOpts.Sequence {
+			err = marshalSequenceOfSlice(iv, tmp, innerOpts)
+		} else {
+			err = marshalSet(iv, tmp, innerOpts)
+		}
+	} else {
+		err = marshalValue(iv, tmp, innerOpts)
+	}
+	if err != nil {
+		err = choiceErr{err}
+		return
+	}
+	innerBytes := tmp.Data()
+
 	debugEvent(EventTrace|EventChoice,
 		newLItem([]int{class, tag}, "class/tag"),
 		newLItem(explicit, "explicit"))
@@ -1,6 +1,9 @@
 package asn1plus
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 type testAttributeValueAssertion struct {
 	Desc  OctetString
@@ -309,6 +312,41 @@ func TestChoice_AutomaticTagsUniqueAndExplicit(t *testing.T) {
 	UnregisterChoices("blarg")
 }
 
+func TestChoice_AutomaticTagsMixedExplicit(t *testing.T) {
+	choices := NewChoices(true)
+	choices.Register(nil, Integer{})                                  // -> [0] EXPLICIT
+	choices.Register(nil, ObjectIdentifier{}, (&Options{}).SetTag(5)) // [5] IMPLICIT
+
+	err := RegisterChoices("mixedAuto", choices)
+	if err == nil {
+		UnregisterChoices("mixedAuto")
+		t.Fatalf("%s failed: expected error for mixed tagging, got nil", t.Name())
+	} else if !strings.Contains(err.Error(), "[5] is IMPLICIT") {
+		t.Fatalf("%s failed: unexpected error: %v", t.Name(), err)
+	}
+
+	if _, found := GetChoices("mixedAuto"); found {
+		t.Fatalf("%s failed: rejected registry was registered", t.Name())
+	}
+
+	// The same alternative is accepted when EXPLICIT ...
+	choices = NewChoices(true)
+	choices.Register(nil, Integer{})
+	choices.Register(nil, ObjectIdentifier{}, (&Options{Explicit: true}).SetTag(5))
+	if err = RegisterChoices("mixedAuto", choices); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+	UnregisterChoices("mixedAuto")
+
+	// ... or when automatic tagging is not in use.
+	choices = NewChoices()
+	choices.Register(nil, ObjectIdentifier{}, (&Options{}).SetTag(5))
+	if err = RegisterChoices("mixedAuto", choices); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+	UnregisterChoices("mixedAuto")
+}
+
 func TestChoice_SequenceUniversal(t *testing.T) {
 	// 1) Build a Choices registry with two SEQUENCE‐typed alternatives:
 	//    Alt1 == SEQUENCE {A INTEGER}, Alt2 == SEQUENCE {A INTEGER; B INTEGER}
@@ -596,3 +634,300 @@ func init() {
 	testFilterChoices.Register((*testFilterInterface)(nil), testFilterPresent{}, o.SetTag(7))
 	RegisterChoices("filter", testFilterChoices)
 }
+
+func TestChoices_Validate(t *testing.T) {
+	// alternatives keyed by their concrete types each reside within
+	// their own descriptor, thus Register cannot detect the clash.
+	choices := NewChoices()
+	if err := choices.Register(nil, Integer{}, (&Options{}).SetTag(0)); err != nil {
+		t.Fatalf("%s failed [register]: %v", t.Name(), err)
+	} else if err = choices.Register(nil, OctetString{}, (&Options{}).SetTag(0)); err != nil {
+		t.Fatalf("%s failed [register]: %v", t.Name(), err)
+	}
+
+	err := choices.Validate()
+	if err == nil || !strings.Contains(err.Error(), "[CONTEXT SPECIFIC 0]") {
+		t.Fatalf("%s failed: expected ambiguity error, got %v", t.Name(), err)
+	}
+
+	if err = RegisterChoices("ambiguous", choices, true); err == nil {
+		UnregisterChoices("ambiguous")
+		t.Fatalf("%s failed: expected registration error, got nil", t.Name())
+	} else if _, found := GetChoices("ambiguous"); found {
+		t.Fatalf("%s failed: rejected registry was registered", t.Name())
+	}
+
+	// validation is optional
+	if err = RegisterChoices("ambiguous", choices); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+	UnregisterChoices("ambiguous")
+
+	// distinct classes, distinct tags and distinct untagged types are acceptable
+	choices = NewChoices()
+	choices.Register(nil, Integer{}, (&Options{}).SetTag(0))
+	choices.Register(nil, OctetString{}, (&Options{}).SetClass(ClassApplication).SetTag(0))
+	choices.Register(nil, Boolean(false), (&Options{}).SetTag(1))
+	choices.Register(nil, PrintableString(""))
+	choices.Register(nil, UTF8String(""))
+	if err = choices.Validate(); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	// untagged alternatives implying the same UNIVERSAL tag clash
+	type seqA struct{ A Integer }
+	type seqB struct{ B Integer }
+	choices.Register(nil, seqA{})
+	choices.Register(nil, seqB{})
+	if err = choices.Validate(); err == nil || !strings.Contains(err.Error(), "[UNIVERSAL 16]") {
+		t.Fatalf("%s failed: expected untagged ambiguity error, got %v", t.Name(), err)
+	}
+
+	// automatic tagging yields unique tags
+	auto := NewChoices(true)
+	auto.Register(nil, Integer{})
+	auto.Register(nil, OctetString{})
+	auto.Register(nil, Boolean(false))
+	if err = auto.Validate(); err != nil {
+		t.Fatalf("%s failed [automatic]: %v", t.Name(), err)
+	}
+}
+
+func TestChoice_NestedChoice(t *testing.T) {
+	inner := NewChoices()
+	inner.Register(nil, Integer{}, (&Options{Explicit: true}).SetTag(2))
+	inner.Register(nil, PrintableString(""), (&Options{Explicit: true}).SetTag(3))
+	RegisterChoices("nestedInner", inner)
+	defer UnregisterChoices("nestedInner")
+
+	// the nested CHOICE is EXPLICIT, whether declared so or not
+	outer := NewChoices()
+	outer.Register(nil, OctetString{}, (&Options{Explicit: true}).SetTag(0))
+	outer.Register(nil, (*Choice)(nil), (&Options{Choices: "nestedInner"}).SetTag(1))
+	RegisterChoices("nestedOuter", outer)
+	defer UnregisterChoices("nestedOuter")
+
+	opts := Options{Choices: "nestedOuter"}
+	want := PrintableString("Bill Smith")
+
+	for _, rule := range encodingRules {
+		for idx, ch := range []Choice{
+			NewChoice(NewChoice(want)),
+			NewChoice(NewChoice(want, 3), 1),
+		} {
+			pkt, err := Marshal(ch, With(rule, opts))
+			if err != nil {
+				t.Fatalf("%s[%d] failed [%s encoding]: %v", t.Name(), idx, rule, err)
+			}
+
+			if hexes := "A1 0E A30C130A42696C6C20536D697468"; pkt.Hex() != hexes {
+				t.Fatalf("%s[%d] failed [%s encoding mismatch]:\n\twant: '%s'\n\tgot:  '%s'",
+					t.Name(), idx, rule, hexes, pkt.Hex())
+			}
+
+			var out Choice
+			if err = Unmarshal(pkt, &out, With(opts)); err != nil {
+				t.Fatalf("%s[%d] failed [%s decoding]: %v", t.Name(), idx, rule, err)
+			} else if out.Tag() != 1 {
+				t.Fatalf("%s[%d] failed [%s outer tag]: want 1, got %d", t.Name(), idx, rule, out.Tag())
+			}
+
+			nested, ok := out.Value().(Choice)
+			if !ok {
+				t.Fatalf("%s[%d] failed [%s]: want nested Choice, got %T",
+					t.Name(), idx, rule, out.Value())
+			} else if nested.Tag() != 3 || nested.Value() != want {
+				t.Fatalf("%s[%d] failed [%s nested]: want [3] %q, got [%d] %#v",
+					t.Name(), idx, rule, want, nested.Tag(), nested.Value())
+			}
+		}
+	}
+
+	// non-nested alternatives of the outer CHOICE are unaffected
+	pkt, err := Marshal(NewChoice(OctetString("x")), With(opts))
+	if err != nil {
+		t.Fatalf("%s failed [flat encoding]: %v", t.Name(), err)
+	}
+	var out Choice
+	if err = Unmarshal(pkt, &out, With(opts)); err != nil {
+		t.Fatalf("%s failed [flat decoding]: %v", t.Name(), err)
+	} else if out.Tag() != 0 {
+		t.Fatalf("%s failed [flat tag]: want 0, got %d", t.Name(), out.Tag())
+	}
+}
+
+func TestChoiceValue(t *testing.T) {
+	oid, _ := NewObjectIdentifier(2, 1, 2, 1, 2, 1, 2, 1)
+
+	pdv := EmbeddedPDV{
+		Identification: NewChoice(oid, 4),
+		DataValue:      OctetString("blarg"),
+	}
+
+	for _, rule := range encodingRules {
+		pkt, err := Marshal(pdv, With(rule))
+		if err != nil {
+			t.Fatalf("%s failed [%s encode]: %v", t.Name(), rule, err)
+		}
+
+		var newPDV EmbeddedPDV
+		if err = Unmarshal(pkt, &newPDV); err != nil {
+			t.Fatalf("%s failed [%s decode]: %v", t.Name(), rule, err)
+		}
+
+		if id, ok := ChoiceValue[ObjectIdentifier](newPDV.Identification); !ok || !id.Eq(oid) {
+			t.Fatalf("%s failed [%s OID]: want %s, got %s (ok:%t)", t.Name(), rule, oid, id, ok)
+		}
+
+		if id, ok := ChoiceValue[OctetString](newPDV.Identification); ok || id != nil {
+			t.Fatalf("%s failed [%s mismatch]: want zero value and false, got %v (ok:%t)",
+				t.Name(), rule, id, ok)
+		}
+	}
+
+	// pointers and nested choices are unwrapped uniformly
+	for idx, ch := range []Choice{
+		NewChoice(&oid),
+		NewChoice(NewChoice(oid, 1), 2),
+		NewChoice(NewChoice(&oid)),
+	} {
+		if id, ok := ChoiceValue[ObjectIdentifier](ch); !ok || !id.Eq(oid) {
+			t.Fatalf("%s[%d] failed: want %s, got %s (ok:%t)", t.Name(), idx, oid, id, ok)
+		}
+	}
+
+	// a nested choice may itself be requested
+	if nested, ok := ChoiceValue[Choice](NewChoice(NewChoice(oid, 1), 2)); !ok || nested.Tag() != 1 {
+		t.Fatalf("%s failed: expected nested Choice, got %#v (ok:%t)", t.Name(), nested, ok)
+	}
+
+	var nilOID *ObjectIdentifier
+	for idx, ch := range []Choice{nil, invalidChoice{}, NewChoice(nil), NewChoice(nilOID)} {
+		if _, ok := ChoiceValue[ObjectIdentifier](ch); ok {
+			t.Fatalf("%s[%d] failed: expected false, got true", t.Name(), idx)
+		}
+	}
+}
+
+func TestChoices_RegisterConstraints(t *testing.T) {
+	prefix, _ := NewObjectIdentifier(1, 3, 6, 1, 4, 1)
+	hasPrefix := Constraint(func(x any) (err error) {
+		if oid, _ := x.(ObjectIdentifier); !oid.HasPrefix(prefix) {
+			err = constraintViolationf("OID ", oid, " lacks prefix ", prefix)
+		}
+		return
+	})
+
+	choices := NewChoices()
+	choices.Register(nil, ObjectIdentifier{}, (&Options{Explicit: true}).SetTag(0), hasPrefix)
+	choices.Register(nil, OctetString{}, (&Options{Explicit: true}).SetTag(1),
+		ConstraintGroup{func(x any) (err error) {
+			if len(x.(OctetString)) == 0 {
+				err = constraintViolationf("empty OCTET STRING")
+			}
+			return
+		}})
+	RegisterChoices("constrainedChoice", choices)
+	defer UnregisterChoices("constrainedChoice")
+
+	opts := Options{Choices: "constrainedChoice"}
+	good, _ := NewObjectIdentifier(1, 3, 6, 1, 4, 1, 56521)
+	bad, _ := NewObjectIdentifier(2, 5, 4, 3)
+
+	for _, rule := range encodingRules {
+		for idx, tc := range []struct {
+			ch    Choice
+			valid bool
+		}{
+			{NewChoice(good), true},
+			{NewChoice(bad), false},
+			{NewChoice(OctetString("x")), true},
+			{NewChoice(OctetString{}), false},
+		} {
+			// constraints do not apply to encoding
+			pkt, err := Marshal(tc.ch, With(rule, opts))
+			if err != nil {
+				t.Fatalf("%s[%d] failed [%s encoding]: %v", t.Name(), idx, rule, err)
+			}
+
+			var out Choice
+			err = Unmarshal(pkt, &out, With(opts))
+			if tc.valid && err != nil {
+				t.Fatalf("%s[%d] failed [%s decoding]: %v", t.Name(), idx, rule, err)
+			} else if !tc.valid && err == nil {
+				t.Fatalf("%s[%d] failed [%s decoding]: expected constraint error, got nil",
+					t.Name(), idx, rule)
+			}
+
+			// constraints may be disabled for a single operation
+			if !tc.valid {
+				pkt, _ = Marshal(tc.ch, With(rule, opts))
+				if err = Unmarshal(pkt, &out, With(opts, WithoutConstraints())); err != nil {
+					t.Fatalf("%s[%d] failed [%s unconstrained decoding]: %v", t.Name(), idx, rule, err)
+				}
+			}
+		}
+	}
+}
+
+func TestChoice_InterfaceField(t *testing.T) {
+	choices := NewChoices()
+	o := &Options{Explicit: true}
+	choices.Register((*testFilterInterface)(nil), testFilterAnd{}, o.SetTag(0))
+	choices.Register((*testFilterInterface)(nil), testEqualityMatch{}, o.SetTag(3))
+	choices.Register((*testFilterInterface)(nil), testFilterPresent{}, o.SetTag(7))
+	RegisterChoices("ifaceField", choices)
+	defer UnregisterChoices("ifaceField")
+
+	type searchRequest struct {
+		BaseObject OctetString
+		Filter     testFilterInterface `asn1:"choices:ifaceField"`
+		Extra      testFilterInterface `asn1:"choices:ifaceField,optional"`
+		Attributes []OctetString       `asn1:"sequence"`
+	}
+
+	present := testFilterPresent{Desc: OctetString("objectClass")}
+	eqMatch := testEqualityMatch{Desc: OctetString("cn"), Value: OctetString("Bill Smith")}
+
+	for idx, in := range []searchRequest{
+		{BaseObject: OctetString("dc=example"), Filter: present},
+		{BaseObject: OctetString("dc=example"), Filter: eqMatch, Extra: present},
+		{BaseObject: OctetString("dc=example"), Filter: testFilterAnd{present, eqMatch},
+			Attributes: []OctetString{OctetString("cn")}},
+	} {
+		for _, rule := range encodingRules {
+			pkt, err := Marshal(in, With(rule))
+			if err != nil {
+				t.Fatalf("%s[%d] failed [%s encoding]: %v", t.Name(), idx, rule, err)
+			}
+
+			var out searchRequest
+			if err = Unmarshal(pkt, &out); err != nil {
+				t.Fatalf("%s[%d] failed [%s decoding]: %v", t.Name(), idx, rule, err)
+			}
+
+			// the concrete alternatives are selected by tag
+			switch want := in.Filter.(type) {
+			case testFilterPresent, testEqualityMatch:
+				if !deepEq(out.Filter, want) {
+					t.Fatalf("%s[%d] failed [%s Filter]:\n\twant: %#v\n\tgot:  %#v",
+						t.Name(), idx, rule, want, out.Filter)
+				}
+			case testFilterAnd:
+				and, ok := out.Filter.(testFilterAnd)
+				if !ok || len(and) != len(want) {
+					t.Fatalf("%s[%d] failed [%s Filter]: want %T, got %#v",
+						t.Name(), idx, rule, want, out.Filter)
+				}
+			}
+
+			if !deepEq(out.Extra, in.Extra) {
+				t.Fatalf("%s[%d] failed [%s Extra]:\n\twant: %#v\n\tgot:  %#v",
+					t.Name(), idx, rule, in.Extra, out.Extra)
+			} else if len(out.Attributes) != len(in.Attributes) {
+				t.Fatalf("%s[%d] failed [%s Attributes]: want %d, got %d",
+					t.Name(), idx, rule, len(in.Attributes), len(out.Attributes))
+			}
+		}
+	}
+}
@@ -31,6 +31,7 @@ var (
 	fmtInt     func(int64, int) string                             = strconv.FormatInt
 	fmtFloat   func(float64, byte, int, int) string                = strconv.FormatFloat
 	puint      func(string, int, int) (uint64, error)              = strconv.ParseUint
+	pint       func(string, int, int) (int64, error)               = strconv.ParseInt
 	pbool      func(string) (bool, error)                          = strconv.ParseBool
 	pfloat     func(string, int) (float64, error)                  = strconv.ParseFloat
 	appInt     func([]byte, int64, int) []byte                     = strconv.AppendInt
@@ -38,6 +39,7 @@ var (
 	lc         func(string) string                                 = strings.ToLower
 	uc         func(string) string                                 = strings.ToUpper
 	split      func(string, string) []string                       = strings.Split
+	cut        func(string, string) (string, string, bool)         = strings.Cut
 	join       func([]string, string) string                       = strings.Join
 	idxr       func(string, rune) int                              = strings.IndexRune
 	lidx       func(string, string) int                            = strings.LastIndex
@@ -45,6 +45,31 @@ func (r ConstraintGroup) Constrain(x any) (err error) {
 	return
 }
 
+/*
+ValidateAll returns all errors produced following the execution of all
+[Constraint] instances against x which reside within the receiver instance.
+Unlike [ConstraintGroup.Constrain], evaluation does not stop upon the first
+failure, thus allowing every violation to be surfaced in a single pass. A
+nil slice is returned if x satisfies all constraints.
+*/
+func (r ConstraintGroup) ValidateAll(x any) (errs []error) {
+	debugEvent(EventEnter|EventConstraint, x)
+	defer func() {
+		debugEvent(EventExit|EventConstraint,
+			newLItem(len(errs), "violations"))
+	}()
+
+	for i := 0; i < len(r); i++ {
+		if r[i] != nil {
+			if err := r[i](x); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return
+}
+
 func (r ConstraintGroup) phase(actual, expect int) (funk func(any) error) {
 	funk = func(_ any) error { return nil }
 	if actual == expect || actual == CodecConstraintBoth {
@@ -151,8 +176,17 @@ func collectConstraint(names []string) (group ConstraintGroup, err error) {
 	return
 }
 
-func applyFieldConstraints(val any, names []string, expect rune) (err error) {
-	for _, nm := range names {
+/*
+applyFieldConstraints returns an error following the execution of all
+registered [Constraint] instances named within opts against val, unless
+the constraint phase of opts is [CodecConstraintNone].
+*/
+func applyFieldConstraints(val any, opts *Options, expect rune) (err error) {
+	if opts == nil || opts.constraintPhase(CodecConstraintBoth) == CodecConstraintNone {
+		return
+	}
+
+	for _, nm := range opts.Constraints {
 		if nm = constrDoD(expect, lc(nm)); nm != "" {
 			fn, ok := constraintReg[nm]
 			if !ok {
@@ -3,7 +3,9 @@
 package asn1plus
 
 import (
+	"regexp"
 	"time"
+	"unicode/utf8"
 
 	"golang.org/x/exp/constraints"
 )
@@ -80,6 +82,23 @@ func Intersection(cs ...Constraint) Constraint {
 	}
 }
 
+/*
+Not returns an instance of [Constraint] which negates c, such that the
+returned closure is satisfied only when c is not. Essentially, this is a
+"NOT" operation, e.g.: "must NOT be one of these values".
+
+Note that the error returned by c is discarded, as a failure of c is
+the desired outcome.
+*/
+func Not(c Constraint) Constraint {
+	return func(x any) (err error) {
+		if c(x) == nil {
+			err = constraintViolationf("negated constraint violated")
+		}
+		return
+	}
+}
+
 /*
 From returns an instance of [Constraint] that checks if a string, []byte or [Primitive]
 value contains illegal bytes (characters) as defined via the allowed input value.
@@ -91,16 +110,7 @@ func From(allowed string) Constraint {
 	}
 	return func(x any) (err error) {
 		var s string
-		switch tv := x.(type) {
-		case string:
-			s = tv
-		case []byte:
-			s = string(tv)
-		case Primitive:
-			s = tv.String()
-		default:
-			err = generalErrorf("Assertion failed for string")
-		}
+		s, err = constraintString(x)
 		for i := 0; i < len(s) && err == nil; i++ {
 			if _, ok := allowedSet[rune(s[i])]; !ok {
 				err = constraintViolationf("character ", string(s[i]),
@@ -111,6 +121,141 @@ func From(allowed string) Constraint {
 	}
 }
 
+/*
+FromRanges returns an instance of [Constraint] that checks if a string, []byte
+or [Primitive] value contains characters which fall outside of all of the
+inclusive rune ranges provided, e.g.: {'A', 'Z'}. This is analogous to an
+X.680 PermittedAlphabet constraint expressed using ranges, as opposed to the
+literal character set required by [From].
+*/
+func FromRanges(ranges ...[2]rune) Constraint {
+	return func(x any) (err error) {
+		var s string
+		s, err = constraintString(x)
+		runes := []rune(s)
+		for i := 0; i < len(runes) && err == nil; i++ {
+			var ok bool
+			for j := 0; j < len(ranges) && !ok; j++ {
+				ok = ranges[j][0] <= runes[i] && runes[i] <= ranges[j][1]
+			}
+			if !ok {
+				err = constraintViolationf("character ", string(runes[i]),
+					" at position ", i, " is not allowed")
+			}
+		}
+		return
+	}
+}
+
+/*
+PatternConstraint returns an instance of [Constraint] alongside an error
+following an attempt to compile expr as a regular expression (see the
+[regexp] package for syntax).
+
+The returned closure checks that a string, []byte or [Primitive] value
+matches expr in its entirety, e.g.: "[0-9]{3}-[0-9]{4}" shall reject
+"555-12345". Anchors need not be included within expr. As expr is only
+compiled once, an invalid expression is reported here rather than upon
+each use of the closure.
+*/
+func PatternConstraint(expr string) (Constraint, error) {
+	re, err := regexp.Compile(`^(?:` + expr + `)$`)
+	if err != nil {
+		return nil, constraintViolationf("invalid pattern ", expr, ": ", err)
+	}
+
+	return func(x any) (err error) {
+		var s string
+		if s, err = constraintString(x); err == nil && !re.MatchString(s) {
+			err = constraintViolationf("value ", s, " does not match pattern ", expr)
+		}
+		return
+	}, nil
+}
+
+/*
+SubsetConstraint returns an instance of [Constraint] alongside an error
+following an attempt to resolve name to one of the following ASN.1
+character string alphabets:
+
+  - "printable" ([PrintableString])
+  - "ia5" ([IA5String])
+  - "visible" ([VisibleString])
+  - "numeric" ([NumericString])
+
+The returned closure checks that every character of a string, []byte or
+[Primitive] value belongs to the named alphabet, per the specification
+[Constraint] of the corresponding type (e.g.: [PrintableSpec]). Unlike
+those specifications, zero length values are permitted. This is useful
+when the raw string adapter is used in place of the types above. Case
+is not significant in name.
+*/
+func SubsetConstraint(name string) (Constraint, error) {
+	var spec Constraint
+	switch lc(name) {
+	case "printable":
+		spec = PrintableSpec
+	case "ia5":
+		spec = IA5Spec
+	case "visible":
+		spec = VisibleSpec
+	case "numeric":
+		spec = NumericSpec
+	default:
+		return nil, errorUnknownConstraint(name)
+	}
+
+	return func(x any) (err error) {
+		var s string
+		if s, err = constraintString(x); err == nil && len(s) > 0 {
+			err = spec(s)
+		}
+		return
+	}, nil
+}
+
+/*
+UTF8Constraint returns an instance of [Constraint] which checks that a
+string, []byte or [Primitive] value -- typically an [OctetString] which
+logically bears text -- consists solely of valid UTF-8 sequences.
+
+This is useful as a decode-phase constraint, lest malformed text be
+silently accepted into downstream string handling, e.g.:
+
+	RegisterTaggedConstraint("utf8", UTF8Constraint())
+
+	type MySequence struct {
+		Label OctetString `asn1:"constrained-by:$utf8"`
+	}
+*/
+func UTF8Constraint() Constraint {
+	return func(x any) (err error) {
+		var s string
+		if s, err = constraintString(x); err == nil && !utf8.ValidString(s) {
+			err = constraintViolationf("value is not valid UTF-8")
+		}
+		return
+	}
+}
+
+/*
+constraintString returns the string form of a string, []byte or [Primitive]
+value alongside an error, for use by character-based constraints.
+*/
+func constraintString(x any) (s string, err error) {
+	switch tv := x.(type) {
+	case string:
+		s = tv
+	case []byte:
+		s = string(tv)
+	case Primitive:
+		s = tv.String()
+	default:
+		err = generalErrorf("Assertion failed for string")
+	}
+	return
+}
+
 /*
 Deprecated: RangeConstraint returns an instance of [Constraint] following
 a call of [Range].
@@ -172,6 +317,75 @@ func Size[T Lengthy](minimum, maximum any) Constraint {
 		panic(err)
 	}
 
+	return sizeConstraint[T](min, &max)
+}
+
+/*
+IntegerRangeConstraint returns an instance of [Constraint] that checks if an
+[Integer] value -- or any value accepted by [NewInteger], such as int or
+*big.Int -- is between the specified minimum and maximum, inclusive.
+
+Either bound may be nil, denoting an open-ended range per the MIN and MAX
+keywords of ITU-T Rec. X.680, e.g.:
+
+	INTEGER (0..MAX)  -> IntegerRangeConstraint(&zero, nil)
+	INTEGER (MIN..-1) -> IntegerRangeConstraint(nil, &negOne)
+
+Unlike [Range], this supports arbitrarily large bounds and values.
+*/
+func IntegerRangeConstraint(minimum, maximum *Integer) Constraint {
+	return func(val any) error {
+		v, err := assertInteger(val)
+		if err != nil {
+			return constraintViolationf("type assertion to INTEGER failed")
+		}
+		if (minimum != nil && v.Lt(*minimum)) || (maximum != nil && v.Gt(*maximum)) {
+			return constraintViolationf("value is out of range")
+		}
+		return nil
+	}
+}
+
+/*
+SizeExact returns an instance of [Constraint] which checks that a value's
+logical length is exactly n, e.g.:
+
+	Digest ::= OCTET STRING (SIZE (4))
+
+This is equivalent to calling [Size] with matching minimum and maximum
+values. This function will panic if n is not a valid [Integer] input.
+*/
+func SizeExact[T Lengthy](n any) Constraint {
+	return Size[T](n, n)
+}
+
+/*
+SizeAtLeast returns an instance of [Constraint] which checks that a value's
+logical length is no less than n, with no upper bound, e.g.:
+
+	Names ::= SEQUENCE (SIZE (1..MAX)) OF Name
+
+This function will panic if n is not a valid [Integer] input.
+*/
+func SizeAtLeast[T Lengthy](n any) Constraint {
+	min, err := assertInteger(n)
+	if err != nil {
+		panic(err)
+	}
+
+	return sizeConstraint[T](min, nil)
+}
+
+/*
+sizeConstraint returns the [Constraint] closure shared by [Size] and its
+variants. A nil max denotes an unbounded (MAX) upper bound.
+*/
+func sizeConstraint[T Lengthy](min Integer, max *Integer) Constraint {
+	upper := "MAX"
+	if max != nil {
+		upper = max.String()
+	}
+
 	return func(val any) error {
 		v, ok := val.(T)
 		if !ok {
@@ -179,11 +393,11 @@ func Size[T Lengthy](minimum, maximum any) Constraint {
 		}
 		size, err := NewInteger(v.Len())
 		if err == nil {
-			if size.Lt(min) || size.Gt(max) {
+			if size.Lt(min) || (max != nil && size.Gt(*max)) {
 				err = constraintViolationf(
 					"size ", size.String(),
 					" is out of bounds [", min.String(),
-					", "+max.String(), "]",
+					", "+upper, "]",
 				)
 			}
 		}
@@ -191,6 +405,52 @@ func Size[T Lengthy](minimum, maximum any) Constraint {
 	}
 }
 
+/*
+OIDArcCountConstraint returns an instance of [Constraint] which checks that
+the number of arcs (number forms) within an [ObjectIdentifier] is not outside
+of the specified boundaries, inclusive.
+
+The [Constraint] may be supplied to [NewObjectIdentifier], registered for use
+with the "constrained-by" struct tag via [RegisterTaggedConstraint], or used
+as the spec [Constraint] of [RegisterOIDAlias]. Instances of [RelativeOID] and
+types whose underlying type is that of [ObjectIdentifier] are also supported.
+
+If minimum is greater than maximum, this function will panic.
+*/
+func OIDArcCountConstraint(minimum, maximum int) Constraint {
+	if minimum > maximum {
+		panic("OBJECT IDENTIFIER: constraint prefab error received minimum > maximum")
+	}
+
+	oidType := refTypeOf(ObjectIdentifier{})
+
+	return func(val any) error {
+		var arcs int
+		switch tv := val.(type) {
+		case ObjectIdentifier:
+			arcs = tv.Len()
+		case *ObjectIdentifier:
+			if tv != nil {
+				arcs = tv.Len()
+			}
+		case RelativeOID:
+			arcs = tv.Len()
+		default:
+			v := refValueOf(val)
+			if !v.IsValid() || !v.Type().ConvertibleTo(oidType) {
+				return constraintViolationf("type assertion to OBJECT IDENTIFIER failed")
+			}
+			arcs = v.Len()
+		}
+
+		if arcs < minimum || arcs > maximum {
+			return constraintViolationf("OBJECT IDENTIFIER: an OID must have ",
+				minimum, " to ", maximum, " number forms, found ", arcs)
+		}
+		return nil
+	}
+}
+
 /*
 Deprecated: RecurrenceConstraint returns a [Temporal] [Constraint] following
 a call of [Recurrence].
@@ -4,6 +4,7 @@ package asn1plus
 
 import (
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 	"testing"
@@ -175,6 +176,57 @@ func ExampleSize_octetString() {
 	// CONSTRAINT VIOLATION: size 8 is out of bounds [3, 6]
 }
 
+func ExampleSizeExact_octetString() {
+	// We require that the OctetString's logical length is exactly 4,
+	// i.e.: OCTET STRING (SIZE (4)).
+	constraint := SizeExact[OctetString](4)
+
+	valid := OctetString("abcd")  // length 4 => valid
+	invalid := OctetString("abc") // length 3 => invalid
+
+	if err := constraint(valid); err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Println("valid OK")
+	}
+
+	if err := constraint(invalid); err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Println("invalid OK")
+	}
+
+	// Output:
+	// valid OK
+	// CONSTRAINT VIOLATION: size 3 is out of bounds [4, 4]
+}
+
+func ExampleSizeAtLeast_octetString() {
+	// We require that the OctetString's logical length is at least 1,
+	// i.e.: OCTET STRING (SIZE (1..MAX)).
+	lower, _ := NewInteger(1)
+	constraint := SizeAtLeast[OctetString](lower)
+
+	valid := OctetString("abcdefgh") // length 8 => valid
+	invalid := OctetString("")       // length 0 => invalid
+
+	if err := constraint(valid); err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Println("valid OK")
+	}
+
+	if err := constraint(invalid); err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Println("invalid OK")
+	}
+
+	// Output:
+	// valid OK
+	// CONSTRAINT VIOLATION: size 0 is out of bounds [1, MAX]
+}
+
 func ExampleUnion() {
 	// This user-authored closure evaluates the input string choices
 	// to determine whether at least one satisfies a constraint.
@@ -253,6 +305,35 @@ func ExampleIntersection() {
 	// Output: No school for you, kid.
 }
 
+func ExampleNot() {
+	Allowed := func(choices ...string) Constraint {
+		allowedSet := make(map[string]struct{}, len(choices))
+		for _, choice := range choices {
+			allowedSet[strings.ToLower(choice)] = struct{}{}
+		}
+		return func(x any) (err error) {
+			s, _ := x.(string)
+			if _, ok := allowedSet[strings.ToLower(s)]; !ok {
+				err = fmt.Errorf("value %q is not allowed; expected one of %v", s, choices)
+			}
+			return
+		}
+	}
+
+	// Any tool is permitted, so long as it is NOT
+	// considered to be heavy machinery.
+	heavyMachinery := Allowed("Lathe", "Hydraulic press")
+	notHeavy := Not(heavyMachinery)
+
+	fmt.Printf("A hammer is allowed: %t\n", notHeavy(`hammer`) == nil)
+	fmt.Printf("A lathe is allowed: %t\n", notHeavy(`lathe`) == nil)
+	fmt.Println(notHeavy(`lathe`))
+	// Output:
+	// A hammer is allowed: true
+	// A lathe is allowed: false
+	// CONSTRAINT VIOLATION: negated constraint violated
+}
+
 // ExampleTimePointRange demonstrates the use of [TimePointRangeConstraint].
 func ExampleTimePointRange() {
 	// Define a range from the beginning to the end of 2020.
@@ -457,6 +538,192 @@ func TestFrom(t *testing.T) {
 	}
 }
 
+func ExamplePatternConstraint() {
+	phone, err := PatternConstraint(`[0-9]{3}-[0-9]{4}`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if _, err = NewIA5String("555-1234", phone); err == nil {
+		fmt.Println("valid OK")
+	}
+	if _, err = NewIA5String("555-12345", phone); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// valid OK
+	// CONSTRAINT VIOLATION: value 555-12345 does not match pattern [0-9]{3}-[0-9]{4}
+}
+
+func TestPatternConstraint(t *testing.T) {
+	if c, err := PatternConstraint(`[a-z`); err == nil || c != nil {
+		t.Fatalf("%s failed: expected error for invalid pattern, got nil", t.Name())
+	}
+
+	pat, err := PatternConstraint(`[A-Z][a-z]+`)
+	if err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	for idx, tc := range []struct {
+		val   any
+		valid bool
+	}{
+		{"Jesse", true},
+		{[]byte("Jesse"), true},
+		{PrintableString("Jesse"), true},
+		{"jesse", false},
+		{"Jesse Coretta", false},
+		{struct{}{}, false},
+	} {
+		if err = pat(tc.val); (err == nil) != tc.valid {
+			t.Errorf("%s[%d] failed: want valid=%t, got %v", t.Name(), idx, tc.valid, err)
+		}
+	}
+}
+
+func TestFromRanges(t *testing.T) {
+	letters := FromRanges([2]rune{'A', 'Z'}, [2]rune{'a', 'z'})
+	if err := letters("Jesse"); err != nil {
+		t.Fatalf("%s failed [letters]: %v", t.Name(), err)
+	}
+	want := "CONSTRAINT VIOLATION: character 2 at position 5 is not allowed"
+	if err := letters(PrintableString("Jesse2")); err == nil || err.Error() != want {
+		t.Fatalf("%s failed [letters]:\n\twant: %s\n\tgot:  %v", t.Name(), want, err)
+	}
+	if err := letters(struct{}{}); err == nil {
+		t.Fatalf("%s failed: expected error for struct{}, got nil", t.Name())
+	}
+
+	// Basic Latin letters and digits, plus Greek and Cyrillic
+	multi := FromRanges(
+		[2]rune{'0', '9'},
+		[2]rune{'A', 'Z'},
+		[2]rune{'a', 'z'},
+		[2]rune{0x0391, 0x03C9},
+		[2]rune{0x0410, 0x044F},
+	)
+	for idx, tc := range []struct {
+		val   any
+		valid bool
+	}{
+		{"abc123", true},
+		{[]byte("αβγ"), true},
+		{UTF8String("ЖжΩ9"), true},
+		{"", true},
+		{"abc 123", false},
+		{"日本", false},
+		{"Ωϊ", false}, // U+03CA lies beyond the Greek range
+	} {
+		if err := multi(tc.val); (err == nil) != tc.valid {
+			t.Errorf("%s[%d] failed: want valid=%t, got %v", t.Name(), idx, tc.valid, err)
+		}
+	}
+}
+
+func TestConstraintGroup_ValidateAll(t *testing.T) {
+	grp := ConstraintGroup{
+		From("abc"),
+		FromRanges([2]rune{'0', '9'}),
+		nil,
+		func(x any) (err error) {
+			if s, _ := x.(string); len(s) > 2 {
+				err = fmt.Errorf("value %q is too long", s)
+			}
+			return
+		},
+	}
+
+	if errs := grp.ValidateAll("x"); len(errs) != 2 {
+		t.Fatalf("%s failed: want 2 errors, got %d: %v", t.Name(), len(errs), errs)
+	}
+
+	errs := grp.ValidateAll("xyzzy")
+	if len(errs) != 3 {
+		t.Fatalf("%s failed: want 3 errors, got %d: %v", t.Name(), len(errs), errs)
+	}
+	if first := grp.Constrain("xyzzy"); first == nil || first.Error() != errs[0].Error() {
+		t.Fatalf("%s failed: want first error %v, got %v", t.Name(), errs[0], first)
+	}
+
+	if errs = (ConstraintGroup{From("xyz")}).ValidateAll("xyzzy"); errs != nil {
+		t.Fatalf("%s failed: want nil, got %v", t.Name(), errs)
+	}
+}
+
+func TestIntegerRangeConstraint(t *testing.T) {
+	huge := new(big.Int).Lsh(big.NewInt(1), 80)                 // 2^80
+	lower, _ := NewInteger(new(big.Int).Lsh(big.NewInt(1), 70)) // 2^70
+	upper, _ := NewInteger(new(big.Int).Neg(lower.Big()))       // -2^70
+	zero, _ := NewInteger(0)
+
+	for idx, tc := range []struct {
+		min, max *Integer
+		val      any
+		valid    bool
+	}{
+		// INTEGER (2^70..MAX)
+		{&lower, nil, huge, true},
+		{&lower, nil, lower, true},
+		{&lower, nil, 5, false},
+		{&lower, nil, new(big.Int).Neg(huge), false},
+
+		// INTEGER (MIN..-2^70)
+		{nil, &upper, new(big.Int).Neg(huge), true},
+		{nil, &upper, upper, true},
+		{nil, &upper, 0, false},
+		{nil, &upper, huge, false},
+
+		// INTEGER (0..MAX)
+		{&zero, nil, int64(0), true},
+		{&zero, nil, -1, false},
+
+		// INTEGER (MIN..MAX)
+		{nil, nil, huge, true},
+		{nil, nil, struct{}{}, false},
+	} {
+		err := IntegerRangeConstraint(tc.min, tc.max)(tc.val)
+		if (err == nil) != tc.valid {
+			t.Errorf("%s[%d] failed: want valid=%t, got %v", t.Name(), idx, tc.valid, err)
+		} else if err != nil && tc.val != (struct{}{}) && err.Error() != "CONSTRAINT VIOLATION: value is out of range" {
+			t.Errorf("%s[%d] failed: unexpected error text: %v", t.Name(), idx, err)
+		}
+	}
+}
+
+func TestSubsetConstraint(t *testing.T) {
+	if c, err := SubsetConstraint("klingon"); err == nil || c != nil {
+		t.Fatalf("%s failed: expected error for unknown subset, got nil", t.Name())
+	}
+
+	for idx, tc := range []struct {
+		name    string
+		valid   []any
+		invalid []any
+	}{
+		{"printable", []any{"Jesse Coretta", []byte("(1+2)/3?"), ""}, []any{"jesse@example.com", "naïve", struct{}{}}},
+		{"IA5", []any{"jesse@example.com", IA5String("~|{}")}, []any{"日本"}},
+		{"visible", []any{"jesse@example.com", []byte("~!")}, []any{"tab\there", "new\nline"}},
+		{"numeric", []any{"555 1234", NumericString("0")}, []any{"555-1234", "12a"}},
+	} {
+		subset, err := SubsetConstraint(tc.name)
+		if err != nil {
+			t.Fatalf("%s[%d] failed: %v", t.Name(), idx, err)
+		}
+		for _, val := range tc.valid {
+			if err = subset(val); err != nil {
+				t.Errorf("%s[%d] failed [%s]: want valid %q, got %v", t.Name(), idx, tc.name, val, err)
+			}
+		}
+		for _, val := range tc.invalid {
+			if err = subset(val); err == nil {
+				t.Errorf("%s[%d] failed [%s]: want invalid %v, got nil", t.Name(), idx, tc.name, val)
+			}
+		}
+	}
+}
+
 func TestConstraint_PanicOnDuplicateGroup(t *testing.T) {
 	defer func() {
 		if r := recover(); r == nil {
@@ -546,3 +813,121 @@ func ExampleOctetString_sequenceFieldConstraintGroupViolation() {
 	}
 	// Output: Constraint violation: policy prohibits odd digits
 }
+
+func TestOIDArcCountConstraint(t *testing.T) {
+	arcs := OIDArcCountConstraint(3, 5)
+
+	// constructor-time
+	if _, err := NewObjectIdentifier(1, 3, 6, 1, arcs); err != nil {
+		t.Fatalf("%s failed [constructor]: %v", t.Name(), err)
+	}
+	if _, err := NewObjectIdentifier(1, 3, 6, 1, 4, 1, arcs); err == nil {
+		t.Fatalf("%s failed [constructor]: expected error, got nil", t.Name())
+	}
+
+	// codec-phase, via struct tag
+	RegisterTaggedConstraint("oidArcs3to5", arcs)
+
+	type MySequence struct {
+		Type ObjectIdentifier `asn1:"constrained-by:oidArcs3to5"`
+	}
+
+	if _, err := Marshal(MySequence{MustNewObjectIdentifier(2, 5, 4, 3)}); err != nil {
+		t.Fatalf("%s failed [struct tag]: %v", t.Name(), err)
+	}
+	if _, err := Marshal(MySequence{MustNewObjectIdentifier(2, 5)}); err == nil {
+		t.Fatalf("%s failed [struct tag]: expected error, got nil", t.Name())
+	}
+
+	// codec-phase, via alias spec
+	type arcOID ObjectIdentifier
+	RegisterOIDAlias[arcOID](TagOID, CodecConstraintBoth, nil, nil, nil, arcs)
+	defer func() {
+		unregisterType(refTypeOf(arcOID{}))
+		unregisterType(refTypeOf(&arcOID{}))
+	}()
+
+	pkt, err := Marshal(arcOID(MustNewObjectIdentifier(1, 3, 6, 1)))
+	if err != nil {
+		t.Fatalf("%s failed [alias encode]: %v", t.Name(), err)
+	}
+	var next arcOID
+	if err = Unmarshal(pkt, &next); err != nil {
+		t.Fatalf("%s failed [alias decode]: %v", t.Name(), err)
+	}
+	if _, err = Marshal(arcOID(MustNewObjectIdentifier(1, 3, 6, 1, 4, 1))); err == nil {
+		t.Fatalf("%s failed [alias encode]: expected error, got nil", t.Name())
+	}
+
+	// miscellaneous inputs
+	rel := MustNewRelativeOID(4, 1, 56521)
+	for idx, tc := range []struct {
+		val any
+		ok  bool
+	}{
+		{rel, true},
+		{&ObjectIdentifier{}, false},
+		{(*ObjectIdentifier)(nil), false},
+		{OctetString("1.3.6"), false},
+		{nil, false},
+	} {
+		if err := arcs(tc.val); (err == nil) != tc.ok {
+			t.Fatalf("%s[%d] failed: unexpected result: %v", t.Name(), idx, err)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("%s failed: %v", t.Name(), errorNoPanic)
+		}
+	}()
+	_ = OIDArcCountConstraint(5, 3)
+}
+
+func TestUTF8Constraint(t *testing.T) {
+	valid := UTF8Constraint()
+	for idx, tc := range []struct {
+		value any
+		fail  bool
+	}{
+		{OctetString("plain ascii"), false},
+		{OctetString("Ελληνικά ✓"), false},
+		{[]byte{0xE2, 0x9C, 0x93}, false},
+		{OctetString(""), false},
+		{OctetString([]byte{'a', 0xE2, 0x28, 0xA1}), true}, // invalid continuation byte
+		{[]byte{0xC3}, true},                               // truncated sequence
+		{3.14, true},
+	} {
+		if err := valid(tc.value); tc.fail && err == nil {
+			t.Fatalf("%s[%d] failed: expected error, got nil", t.Name(), idx)
+		} else if !tc.fail && err != nil {
+			t.Fatalf("%s[%d] failed: %v", t.Name(), idx, err)
+		}
+	}
+
+	RegisterTaggedConstraint("testUTF8Only", UTF8Constraint())
+
+	type Labeled struct {
+		Label OctetString `asn1:"constrained-by:$testUTF8Only"`
+	}
+
+	// encoding is unconstrained, but decoding is not
+	bogus := Labeled{Label: OctetString([]byte{0xE2, 0x28, 0xA1})}
+	pkt, err := Marshal(bogus)
+	if err != nil {
+		t.Fatalf("%s failed [encoding]: %v", t.Name(), err)
+	}
+
+	var out Labeled
+	if err = Unmarshal(pkt, &out); err == nil {
+		t.Fatalf("%s failed: expected decode-phase UTF-8 violation, got nil", t.Name())
+	}
+
+	if pkt, err = Marshal(Labeled{Label: OctetString("résumé")}); err != nil {
+		t.Fatalf("%s failed [encoding]: %v", t.Name(), err)
+	} else if err = Unmarshal(pkt, &out); err != nil {
+		t.Fatalf("%s failed [decoding]: %v", t.Name(), err)
+	} else if out.Label.String() != "résumé" {
+		t.Fatalf("%s failed: want résumé, got %s", t.Name(), out.Label)
+	}
+}
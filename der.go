@@ -6,7 +6,10 @@ package asn1plus
 der.go contains DER-focused components. See also ber.go.
 */
 
-import "io"
+import (
+	"io"
+	"slices"
+)
 
 /*
 DERPacket encapsulates an [ITU-T Rec. X.690] DER-encoded byte
@@ -75,10 +78,11 @@ Dump returns an error following an attempt to write the receiver
 instance into w.
 
 The variadic wrapAt value defines the maximum number of characters
-displayed per line before the value is wrapped. The default is 24,
-and can be configured no less than 16.
+displayed per line before the value is wrapped. The default is
+[DefaultDumpWidth], and an error is returned if a width less than
+[MinDumpWidth] is supplied.
 */
-func (r *DERPacket) Dump(w io.Writer, wrapAt ...int) error { return dumpPacket(r, w) }
+func (r *DERPacket) Dump(w io.Writer, wrapAt ...int) error { return dumpPacket(r, w, wrapAt...) }
 
 /*
 Len returns the integer length of the underlying byte buffer within
@@ -152,6 +156,244 @@ header to the receiver buffer.
 */
 func (r *DERPacket) WriteTLV(tlv TLV) error { return writeTLV(r, tlv, nil) }
 
+func (r *DERPacket) outputLimit() int     { return r.limit }
+func (r *DERPacket) setOutputLimit(n int) { r.limit = n }
+
+func (r *DERPacket) tagNames() map[[2]int]string         { return r.names }
+func (r *DERPacket) setTagNames(names map[[2]int]string) { r.names = names }
+
+func (r *DERPacket) reset() { (*BERPacket)(r).reset() }
+
+/*
+Canonicalize returns a new [DER] [PDU] alongside an error following an
+attempt to transcode the [BER] (or [CER]) encoding residing within pkt into
+its distinguished form. The input instance is neither modified nor freed.
+
+Transcoding takes place at the TLV level, thus no Go type is required.
+Specifically:
+
+  - Indefinite lengths are replaced with minimal definite lengths
+  - Constructed (segmented) strings, such as OCTET STRING, are flattened
+  - The elements of each SET and SET OF are sorted by their encodings
+  - BOOLEAN TRUE is encoded as 0xFF
+  - INTEGER and ENUMERATED values bear no redundant leading octets
+  - The unused trailing bits of a BIT STRING are zeroed
+
+Values which cannot be canonicalized without knowledge of the schema, such
+as SEQUENCE components equal to their DEFAULT, or REAL values bearing a
+base other than two (2), are left as-is.
+
+This is useful for computing or verifying signatures over the DER form of
+data which was received in BER form.
+*/
+func Canonicalize(pkt PDU) (der PDU, err error) {
+	if pkt == nil {
+		err = errorNilInput
+		return
+	} else if pkt.Type().textual() {
+		err = errorTextualPDU
+		return
+	}
+
+	data := pkt.Data()
+	var out []byte
+	for off, n := 0, 0; off < len(data) && err == nil; off += n {
+		out, n, err = canonicalizeTLV(out, data[off:], 0)
+	}
+
+	if err == nil {
+		der = DER.New(out...)
+	}
+
+	return
+}
+
+/*
+canonicalizeTLV appends the DER form of the TLV at the start of b to dst,
+returning the result alongside the number of octets of b consumed and an
+error.
+*/
+func canonicalizeTLV(dst, b []byte, depth int) (out []byte, n int, err error) {
+	out = dst
+	if MaxDecodeDepth > 0 && depth > MaxDecodeDepth {
+		err = errorMaxDecodeDepth
+		return
+	}
+
+	var tag, idLen, length, lenLen int
+	if tag, idLen, err = parseTagIdentifier(b); err != nil {
+		return
+	} else if length, lenLen, err = parseLength(b[idLen:]); err != nil {
+		return
+	}
+
+	id := b[0]
+	universal := int(id>>6) == ClassUniversal
+	off := idLen + lenLen
+
+	var content []byte
+	if id&cmpndByte == 0 {
+		if length < 0 {
+			err = errorIndefiniteProhibited
+			return
+		} else if off+length > len(b) {
+			err = errorTruncatedContent
+			return
+		}
+		n = off + length
+		if content = b[off:n]; universal {
+			content, err = canonicalPrimitive(tag, content)
+		}
+	} else {
+		var children [][]byte
+		if children, n, err = canonicalChildren(b, off, length, depth); err != nil {
+			return
+		}
+
+		if universal && isSegmentableTag(tag) {
+			// DER forbids the constructed form of strings
+			id &^= cmpndByte
+			content, err = flattenSegments(tag, children)
+		} else {
+			if universal && tag == TagSet {
+				slices.SortStableFunc(children, bcmp)
+			}
+			for _, child := range children {
+				content = append(content, child...)
+			}
+		}
+	}
+
+	if err == nil {
+		out = append(out, id)
+		out = append(out, b[1:idLen]...)
+		encodeBCDLengthInto(&out, len(content))
+		out = append(out, content...)
+	}
+
+	return
+}
+
+/*
+canonicalChildren returns the DER form of each TLV residing within the
+content octets of the constructed TLV b, which begin at off, alongside the
+total number of octets of b consumed and an error. A negative length
+denotes the indefinite form, in which the content ends with end-of-contents
+octets.
+*/
+func canonicalChildren(b []byte, off, length, depth int) (children [][]byte, n int, err error) {
+	end := len(b)
+	if length >= 0 {
+		if end = off + length; end > len(b) {
+			err = errorTruncatedContent
+			return
+		}
+	}
+
+	for {
+		if length >= 0 && off == end {
+			n = off
+			return
+		} else if off >= end {
+			err = errorNoEOCIndefTLV
+			return
+		} else if length < 0 && off+1 < end && b[off] == 0 && b[off+1] == 0 {
+			n = off + len(indefEoC)
+			return
+		}
+
+		var child []byte
+		var cn int
+		if child, cn, err = canonicalizeTLV(nil, b[off:end], depth+1); err != nil {
+			return
+		}
+		children = append(children, child)
+		off += cn
+	}
+}
+
+/*
+canonicalPrimitive returns the DER form of content, the content octets of
+a primitive UNIVERSAL TLV bearing tag, alongside an error.
+*/
+func canonicalPrimitive(tag int, content []byte) (out []byte, err error) {
+	out = content
+	switch tag {
+	case TagBoolean:
+		if len(content) != 1 {
+			err = primitiveErrorf("BOOLEAN: content length ≠ 1")
+		} else if content[0] != 0 {
+			out = []byte{0xFF}
+		}
+	case TagInteger, TagEnum:
+		if len(content) == 0 {
+			err = primitiveErrorf("INTEGER: empty content")
+		}
+		for len(out) > 1 && ((out[0] == 0x00 && out[1]&0x80 == 0) ||
+			(out[0] == 0xFF && out[1]&0x80 != 0)) {
+			out = out[1:]
+		}
+	case TagBitString:
+		if len(content) == 0 || content[0] > 7 || (len(content) == 1 && content[0] != 0) {
+			err = primitiveErrorf("BIT STRING: invalid unused bits octet")
+		} else if unused := content[0]; unused > 0 {
+			out = append([]byte{}, content...)
+			out[len(out)-1] &^= byte(1<<unused) - 1
+		}
+	}
+
+	return
+}
+
+/*
+flattenSegments returns the content octets of the primitive form of a
+constructed string bearing tag, whose (canonicalized) segments are given.
+*/
+func flattenSegments(tag int, segments [][]byte) (content []byte, err error) {
+	if tag == TagBitString {
+		content = []byte{0}
+	}
+
+	for i, seg := range segments {
+		var sub []byte
+		if sub, err = parseBody(seg, 0, DER); err != nil {
+			return
+		} else if seg[0]&longByte != byte(tag) {
+			err = primitiveErrorf("constructed string segment bears mismatched tag")
+			return
+		}
+
+		if tag == TagBitString {
+			// only the final segment may bear unused bits
+			if len(sub) == 0 || (sub[0] != 0 && i < len(segments)-1) {
+				err = primitiveErrorf("BIT STRING: invalid segment")
+				return
+			}
+			content[0] = sub[0]
+			sub = sub[1:]
+		}
+		content = append(content, sub...)
+	}
+
+	return
+}
+
+/*
+isSegmentableTag returns a Boolean value indicative of whether UNIVERSAL
+tag may be encoded in the constructed form under [BER], i.e.: whether it
+denotes a BIT STRING, OCTET STRING or restricted character string type.
+*/
+func isSegmentableTag(tag int) bool {
+	switch tag {
+	case TagBitString, TagOctetString, TagObjectDescriptor, TagUTF8String,
+		TagNumericString, TagPrintableString, TagT61String, TagVideotexString,
+		TagIA5String, TagUTCTime, TagGeneralizedTime, TagGraphicString,
+		TagVisibleString, TagGeneralString, TagUniversalString, TagBMPString:
+		return true
+	}
+	return false
+}
+
 func newDERPacket(src ...byte) PDU {
 	r := newBERPacket(src...)
 	bp, _ := r.(*BERPacket)
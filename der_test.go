@@ -85,3 +85,50 @@ func TestDERPacket_OutOfBoundsGuards(t *testing.T) {
 		t.Fatalf("Compound(): expected errorOutOfBounds, got %v", err)
 	}
 }
+
+func TestCanonicalize(t *testing.T) {
+	for idx, tc := range []struct {
+		ber []byte
+		der []byte
+	}{
+		{
+			// indefinite-length SEQUENCE { INTEGER 5 (padded), BOOLEAN TRUE (0x01) }
+			[]byte{0x30, 0x80, 0x02, 0x02, 0x00, 0x05, 0x01, 0x01, 0x01, 0x00, 0x00},
+			[]byte{0x30, 0x06, 0x02, 0x01, 0x05, 0x01, 0x01, 0xFF},
+		},
+		{
+			// SET OF OCTET STRING { "b", constructed "a" } in indefinite form
+			[]byte{0x31, 0x80, 0x04, 0x01, 'b', 0x24, 0x80, 0x04, 0x01, 'a', 0x00, 0x00, 0x00, 0x00},
+			[]byte{0x31, 0x06, 0x04, 0x01, 'a', 0x04, 0x01, 'b'},
+		},
+		{
+			// constructed BIT STRING with dirty padding, long-form length
+			[]byte{0x23, 0x81, 0x08, 0x03, 0x02, 0x00, 0xAA, 0x03, 0x02, 0x04, 0xFF},
+			[]byte{0x03, 0x03, 0x04, 0xAA, 0xF0},
+		},
+	} {
+		der, err := Canonicalize(BER.New(tc.ber...))
+		if err != nil {
+			t.Fatalf("%s[%d] failed: %v", t.Name(), idx, err)
+		} else if der.Type() != DER || !btseq(der.Data(), tc.der) {
+			t.Fatalf("%s[%d] failed:\n\twant: %X\n\tgot:  %X", t.Name(), idx, tc.der, der.Data())
+		}
+
+		// DER input must pass through unchanged
+		if again, err := Canonicalize(der); err != nil || !btseq(again.Data(), tc.der) {
+			t.Fatalf("%s[%d] failed [idempotence]: %v", t.Name(), idx, err)
+		}
+	}
+
+	for idx, bad := range [][]byte{
+		{0x30, 0x80, 0x02, 0x01, 0x05}, // missing EOC
+		{0x30, 0x05, 0x02, 0x01},       // truncated
+		{0x24, 0x03, 0x02, 0x01, 0x05}, // mismatched segment
+		{0x01, 0x02, 0x01, 0x01},       // bad BOOLEAN
+		{0x03, 0x02, 0x09, 0x00},       // bad unused bits
+	} {
+		if _, err := Canonicalize(BER.New(bad...)); err == nil {
+			t.Fatalf("%s[%d] failed: expected error, got nil", t.Name(), idx)
+		}
+	}
+}
@@ -0,0 +1,366 @@
+package asn1plus
+
+/*
+desc.go contains components which render approximate ASN.1 notation
+from Go types, for use as a documentation aid.
+*/
+
+import (
+	"reflect"
+	"slices"
+)
+
+/*
+DescribeType returns approximate ASN.1 notation describing the struct type
+of x, which may also be a pointer to a struct, alongside an error. For
+example:
+
+	MySequence ::= SEQUENCE { field0 PrintableString, field1 [1] OCTET STRING OPTIONAL }
+
+Tags, classes, EXPLICIT, OPTIONAL, DEFAULT, SIZE and ENUMERATED names are
+recovered from the "asn1" struct tag of each field, exactly as is done by
+[Marshal] and [Unmarshal]. Component identifiers are taken from the "name:"
+option, if present, else from the Go field name, the first letter of which
+is lowercased. Nested SEQUENCE and SET types are described in-line, save
+for those which refer to themselves, which are described by reference.
+
+CHOICE fields are described using the alternatives of the registered
+[Choices] instance named by the "choices:" option. Interface fields which
+lack such an option, as well as [RawValue] fields, are described as ANY.
+
+This is a developer aid, not a compiler. The return value is meant to be
+read by humans, and may not be accepted verbatim by an ASN.1 compiler.
+*/
+func DescribeType(x any) (desc string, err error) {
+	if x == nil {
+		err = errorNilValue
+		return
+	}
+
+	t := derefTypePtr(refTypeOf(x))
+	if t.Kind() != reflect.Struct || isPrimitive(refNew(t).Elem().Interface()) {
+		err = compositeErrorf("DescribeType: SEQUENCE or SET (struct) required, got ", t)
+		return
+	}
+
+	d := describer{seen: make(map[reflect.Type]bool)}
+	var body string
+	if body, err = d.typeNotation(t, &Options{}); err == nil {
+		desc = describeTypeReference(t) + " ::= " + body
+	}
+
+	return
+}
+
+/*
+describer implements the private ASN.1 notation renderer used by
+[DescribeType]. The seen map records the struct types currently being
+described, so that self-referential types do not recurse infinitely.
+*/
+type describer struct {
+	seen map[reflect.Type]bool
+}
+
+/*
+typeNotation returns the ASN.1 notation for type t, as qualified by
+opts, sans any tag. An error is returned if t cannot be described.
+*/
+func (r describer) typeNotation(t reflect.Type, opts *Options) (s string, err error) {
+	t = derefTypePtr(t)
+	zero := refNew(t).Elem()
+
+	if t.Kind() == reflect.Interface {
+		s, err = r.choiceNotation(opts)
+		return
+	} else if t == rawValueType {
+		s = "ANY"
+		return
+	}
+
+	class, tag, ok := untaggedClassAndTag(zero, opts)
+	if ok && len(opts.EnumNames) > 0 {
+		tag = TagEnum // inline ENUMERATED
+	}
+
+	switch {
+	case ok && class == ClassUniversal && tag != TagSequence && tag != TagSet:
+		s = describeUniversalName(tag) + describeEnumNames(tag, opts) + describeSize(opts, true)
+	case t.Kind() == reflect.Struct:
+		s, err = r.structNotation(t, zero, opts)
+	case t.Kind() == reflect.Slice:
+		keyword := "SET"
+		if opts.Sequence {
+			keyword = "SEQUENCE"
+		}
+		var elem string
+		if elem, err = r.typeNotation(t.Elem(), clearChildOpts(opts)); err == nil {
+			s = keyword + describeSize(opts, false) + " OF " + elem
+		}
+	default:
+		err = compositeErrorf("DescribeType: unsupported type ", t)
+	}
+
+	return
+}
+
+/*
+structNotation returns the SEQUENCE or SET notation for struct type t,
+including the notation of each of its components.
+*/
+func (r describer) structNotation(t reflect.Type, zero reflect.Value, opts *Options) (s string, err error) {
+	keyword := "SEQUENCE"
+	if isSet(zero.Interface(), opts) {
+		keyword = "SET"
+	}
+
+	if r.seen[t] {
+		// self-reference; describe by name
+		s = describeTypeReference(t)
+		return
+	}
+	r.seen[t] = true
+	defer delete(r.seen, t)
+
+	fields := structFields(t)
+	rawIdx := findRawContentIndex(t, fields)
+	auto := automaticTagging(opts, fields)
+
+	var comps []string
+	for i := 0; i < len(fields) && err == nil; i++ {
+		field := fields[i]
+		if field.PkgPath != "" || rawIdx == i {
+			continue
+		}
+
+		var fOpts *Options
+		if fOpts, err = extractOptions(field, i, auto); err != nil {
+			break
+		}
+
+		var comp string
+		switch {
+		case fOpts.Extension:
+			comp = "..."
+		case fOpts.ComponentsOf:
+			comp = "COMPONENTS OF " + describeTypeReference(derefTypePtr(field.Type))
+		default:
+			comp, err = r.componentNotation(field, fOpts)
+		}
+		comps = append(comps, comp)
+	}
+
+	if err == nil {
+		s = keyword + " {}"
+		if len(comps) > 0 {
+			s = keyword + " { " + join(comps, ", ") + " }"
+		}
+	}
+
+	return
+}
+
+/*
+componentNotation returns the notation for a single SEQUENCE or SET
+component, including its identifier, tag and OPTIONAL or DEFAULT state.
+*/
+func (r describer) componentNotation(field reflect.StructField, opts *Options) (s string, err error) {
+	var typ string
+	if isRawField(field, opts) {
+		typ = "ANY"
+	} else if typ, err = r.typeNotation(field.Type, opts); err != nil {
+		return
+	}
+
+	s = describeIdentifier(textFieldName(field, opts)) + " " + describeTag(opts) + typ
+
+	if opts.defaultKeyword != "" {
+		s += " DEFAULT " + opts.defaultKeyword
+	} else if optsHasDefault(opts) {
+		s += " DEFAULT " + describeValue(opts.Default)
+	} else if optsIsOptional(opts) || optsIsOmit(opts) {
+		s += " OPTIONAL"
+	}
+
+	return
+}
+
+/*
+choiceNotation returns the CHOICE notation for the registered [Choices]
+named within opts, or ANY if no such registration exists.
+*/
+func (r describer) choiceNotation(opts *Options) (s string, err error) {
+	s = "ANY"
+	choices, found := GetChoices(deferImplicit(opts).Choices)
+	if !found {
+		return
+	}
+
+	type alternative struct {
+		tag  int
+		typ  reflect.Type
+		desc *choiceDescriptor
+	}
+
+	var alts []alternative
+	for _, cd := range choices.reg {
+		for tag, typ := range cd.tagToType {
+			alts = append(alts, alternative{tag, typ, cd})
+		}
+	}
+	slices.SortFunc(alts, func(a, b alternative) int { return a.tag - b.tag })
+
+	comps := make([]string, len(alts))
+	for i := 0; i < len(alts) && err == nil; i++ {
+		alt := alts[i]
+		altOpts := &Options{Choices: alt.desc.nested[alt.tag]}
+		if alt.tag >= 0 {
+			altOpts.SetClass(alt.desc.class[alt.tag]).SetTag(alt.tag)
+			altOpts.Explicit = alt.desc.explicit[alt.tag]
+		}
+
+		var typ string
+		if typ, err = r.typeNotation(alt.typ, altOpts); err == nil {
+			comps[i] = describeIdentifier(alt.typ.Name()) + " " + describeTag(altOpts) + typ
+		}
+	}
+
+	if err == nil {
+		s = "CHOICE { " + join(comps, ", ") + " }"
+	}
+
+	return
+}
+
+/*
+describeTag returns the tag notation (e.g.: "[APPLICATION 3] EXPLICIT ")
+for opts, or a zero string if no tag is present.
+*/
+func describeTag(opts *Options) (s string) {
+	if opts.HasTag() {
+		var class string
+		if c := opts.Class(); c != ClassContextSpecific {
+			class = ClassNames[c] + " "
+		}
+		s = "[" + class + itoa(opts.Tag()) + "] "
+		if opts.Explicit {
+			s += "EXPLICIT "
+		}
+	}
+
+	return
+}
+
+/*
+describeSize returns the SIZE constraint notation for opts, if any. If
+paren is true, the constraint is parenthesized, as is required when it
+follows a string type.
+*/
+func describeSize(opts *Options, paren bool) (s string) {
+	if opts.HasSize() {
+		if s = "SIZE(" + opts.sizeRange() + ")"; paren {
+			s = "(" + s + ")"
+		}
+		s = " " + s
+	}
+
+	return
+}
+
+/*
+describeEnumNames returns the named number list of an ENUMERATED type,
+e.g.: " { one(1), two(2) }", drawn from opts. A zero string is returned
+if tag is not [TagEnum], or if no names are present.
+*/
+func describeEnumNames(tag int, opts *Options) (s string) {
+	if tag != TagEnum || len(opts.EnumNames) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(opts.EnumNames))
+	for name := range opts.EnumNames {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		if x, y := opts.EnumNames[a], opts.EnumNames[b]; x != y {
+			return x - y
+		} else if a < b {
+			return -1
+		}
+		return 1
+	})
+
+	for i, name := range names {
+		names[i] = name + "(" + itoa(opts.EnumNames[name]) + ")"
+	}
+	if opts.EnumExtensible {
+		names = append(names, "...")
+	}
+
+	return " { " + join(names, ", ") + " }"
+}
+
+/*
+describeValue returns the value notation for DEFAULT value d.
+*/
+func describeValue(d any) (s string) {
+	switch tv := d.(type) {
+	case bool:
+		s = uc(bool2str(tv))
+	case string:
+		s = `"` + tv + `"`
+	default:
+		s = stringifyDefault(d)
+	}
+
+	return
+}
+
+/*
+describeUniversalName returns the ASN.1 type name for UNIVERSAL tag.
+*/
+func describeUniversalName(tag int) (name string) {
+	var ok bool
+	if name, ok = describeNames[tag]; !ok {
+		name = TagNames[tag]
+	}
+
+	return
+}
+
+/*
+describeNames contains the ASN.1 notation for those types whose names,
+as they appear in [TagNames], differ from their ASN.1 notation.
+*/
+var describeNames = map[int]string{
+	TagObjectDescriptor: "ObjectDescriptor",
+	TagRelativeOID:      "RELATIVE-OID",
+	TagCharacterString:  "CHARACTER STRING",
+	TagDate:             "DATE",
+	TagTimeOfDay:        "TIME-OF-DAY",
+	TagDateTime:         "DATE-TIME",
+	TagDuration:         "DURATION",
+}
+
+/*
+describeTypeReference returns the name of t as an ASN.1 type reference,
+which must begin with an uppercase letter.
+*/
+func describeTypeReference(t reflect.Type) (name string) {
+	if name = t.Name(); name == "" {
+		name = "Unnamed"
+	}
+
+	return uc(name[:1]) + name[1:]
+}
+
+/*
+describeIdentifier returns name as an ASN.1 identifier, which must begin
+with a lowercase letter.
+*/
+func describeIdentifier(name string) string {
+	if name == "" {
+		return name
+	}
+
+	return lc(name[:1]) + name[1:]
+}
@@ -0,0 +1,76 @@
+package asn1plus
+
+import "testing"
+
+func TestDescribeType(t *testing.T) {
+	type mySequence struct {
+		Field0 OctetString `asn1:"explicit,tag:0"`
+		Field1 OctetString `asn1:"explicit,tag:1,optional"`
+		Field2 OctetString `asn1:"explicit,tag:2"`
+	}
+
+	want := `MySequence ::= SEQUENCE { field0 [0] EXPLICIT OCTET STRING, ` +
+		`field1 [1] EXPLICIT OCTET STRING OPTIONAL, field2 [2] EXPLICIT OCTET STRING }`
+	for _, x := range []any{mySequence{}, &mySequence{}} {
+		if got, err := DescribeType(x); err != nil {
+			t.Fatalf("%s failed: %v", t.Name(), err)
+		} else if got != want {
+			t.Fatalf("%s failed:\n\twant: %s\n\tgot:  %s", t.Name(), want, got)
+		}
+	}
+}
+
+type testDescribeNode struct {
+	Value    Integer
+	Children []*testDescribeNode `asn1:"sequence,optional"`
+}
+
+func TestDescribeType_composite(t *testing.T) {
+	type Inner struct {
+		Flag Boolean     `asn1:"default:true"`
+		Note UTF8String  `asn1:"name:remark,size:1..64"`
+		Code Enumerated  `asn1:"application,tag:3"`
+		Path RelativeOID `asn1:"omitempty"`
+	}
+
+	type Outer struct {
+		Name  PrintableString
+		Inner Inner     `asn1:"tag:1"`
+		Items []Integer `asn1:"size:1..MAX"`
+		Pick  Choice    `asn1:"choices:describeChoice"`
+		Any   RawValue
+		Ext   []TLV `asn1:"..."`
+	}
+
+	choices := NewChoices()
+	choices.Register(nil, Integer{}, (&Options{Explicit: true}).SetTag(0))
+	choices.Register(nil, testFilterPresent{}, (&Options{}).SetTag(1))
+	RegisterChoices("describeChoice", choices)
+	defer UnregisterChoices("describeChoice")
+
+	want := `Outer ::= SEQUENCE { name PrintableString, inner [1] SEQUENCE { ` +
+		`flag BOOLEAN DEFAULT TRUE, remark UTF8String (SIZE(1..64)), ` +
+		`code [APPLICATION 3] ENUMERATED, path RELATIVE-OID OPTIONAL }, ` +
+		`items SET SIZE(1..MAX) OF INTEGER, pick CHOICE { integer [0] EXPLICIT INTEGER, ` +
+		`testFilterPresent [1] SEQUENCE { desc OCTET STRING } }, any ANY, ... }`
+	if got, err := DescribeType(Outer{}); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	} else if got != want {
+		t.Fatalf("%s failed:\n\twant: %s\n\tgot:  %s", t.Name(), want, got)
+	}
+
+	// self-referential types are described by reference
+	want = `TestDescribeNode ::= SEQUENCE { value INTEGER, ` +
+		`children SEQUENCE OF TestDescribeNode OPTIONAL }`
+	if got, err := DescribeType(testDescribeNode{}); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	} else if got != want {
+		t.Fatalf("%s failed:\n\twant: %s\n\tgot:  %s", t.Name(), want, got)
+	}
+
+	for idx, x := range []any{nil, Integer{}, OctetString("x"), 5} {
+		if _, err := DescribeType(x); err == nil {
+			t.Fatalf("%s[%d] failed: expected error, got nil", t.Name(), idx)
+		}
+	}
+}
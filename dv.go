@@ -32,6 +32,20 @@ within the default registry. If found, the return is assumed to be the
 default value for the field in question. If the value cannot be written
 to the field type, an error will follow.
 
+The registered value may be of any type assignable to the field in
+question, including any [Primitive] (e.g.: [ObjectIdentifier], [Enumerated]
+or [OctetString]) or SEQUENCE. Fields whose values equal the registered
+default are omitted during encoding, and are assigned the default during
+decoding when absent, e.g.:
+
+	oid, _ := NewObjectIdentifier(1, 2, 840, 113549, 1, 1, 11)
+	RegisterDefaultValue("sha256WithRSA", oid)
+
+	type MySequence struct {
+		Algorithm ObjectIdentifier `asn1:"tag:0,default::sha256WithRSA"`
+		Value     OctetString
+	}
+
 If only a single colon were used, it assumes the value should be
 taken literally, which will cause errors in certain cases, or (at
 the very least) will fallback to the inefficient default handler.
@@ -62,6 +76,7 @@ func RegisterDefaultValue(name string, dval any) {
 	}()
 
 	defaultValues[name] = dval
+	forgetFieldOptions()
 }
 
 /*
@@ -86,6 +101,7 @@ func UnregisterDefaultValue(name string) {
 	}()
 
 	delete(defaultValues, name)
+	forgetFieldOptions()
 }
 
 /*
@@ -100,3 +100,55 @@ func TestDefaultValue_Sequence(t *testing.T) {
 
 	//t.Logf("[2] Unmarshaled: %#v\n", dest2)
 }
+
+func TestDefaultValue_ObjectIdentifierEnumerated(t *testing.T) {
+	oid, _ := NewObjectIdentifier(1, 2, 840, 113549, 1, 1, 11)
+	RegisterDefaultValue("myOID", oid)
+	RegisterDefaultValue("myEnum", Enumerated(2))
+	defer UnregisterDefaultValue("myOID")
+	defer UnregisterDefaultValue("myEnum")
+
+	type MySequence struct {
+		Algorithm ObjectIdentifier `asn1:"tag:0,default::myOID"`
+		Value     OctetString
+		Status    Enumerated `asn1:"tag:1,default::myEnum"`
+	}
+
+	opts, err := NewOptions("tag:0,default::myOID")
+	if err != nil {
+		t.Fatalf("%s failed [options]: %v", t.Name(), err)
+	} else if !deepEq(opts.Default, oid) {
+		t.Fatalf("%s failed [resolve]: want %s, got %v", t.Name(), oid, opts.Default)
+	} else if got := stringifyDefault(opts.Default); got != oid.String() {
+		t.Fatalf("%s failed [stringify]: want %s, got %s", t.Name(), oid, got)
+	} else if got := stringifyDefault(Enumerated(2)); got != "2" {
+		t.Fatalf("%s failed [stringify]: want 2, got %s", t.Name(), got)
+	}
+
+	other, _ := NewObjectIdentifier(1, 3, 6, 1)
+	for _, tc := range []struct {
+		value MySequence
+		size  int
+	}{
+		{MySequence{oid, OctetString("test"), 2}, 8},
+		{MySequence{other, OctetString("test"), 5}, 16},
+	} {
+		for _, rule := range encodingRules {
+			pkt, err := Marshal(tc.value, With(rule))
+			if err != nil {
+				t.Fatalf("%s failed [%s encoding]: %v", t.Name(), rule, err)
+			} else if rule != CER && pkt.Len() != tc.size {
+				t.Fatalf("%s failed [%s encoding]: want %d bytes, got %d (%X)",
+					t.Name(), rule, tc.size, pkt.Len(), pkt.Data())
+			}
+
+			var dest MySequence
+			if err = Unmarshal(pkt, &dest); err != nil {
+				t.Fatalf("%s failed [%s decoding]: %v", t.Name(), rule, err)
+			} else if !dest.Algorithm.Eq(tc.value.Algorithm) || dest.Status != tc.value.Status {
+				t.Fatalf("%s failed [%s decoding]:\n\twant: %v\n\tgot:  %v",
+					t.Name(), rule, tc.value, dest)
+			}
+		}
+	}
+}
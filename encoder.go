@@ -0,0 +1,80 @@
+package asn1plus
+
+/*
+encoder.go contains the reusable Encoder type, which amortizes the setup
+cost of Marshal across many encoding operations.
+*/
+
+/*
+Encoder implements a reusable ASN.1 encoder for callers which encode a
+great many values, such as servers marshaling millions of small messages.
+
+Unlike [Marshal], which parses its [EncodingOption] instances and obtains a
+fresh [PDU] upon every call, an Encoder resolves its options once upon
+creation and retains a single [PDU] -- including its underlying buffer --
+which is reused by each call of [Encoder.Marshal]. Once the buffer has grown
+to accommodate the largest value encoded, no further output buffers need be
+obtained.
+
+An Encoder is NOT safe for concurrent use. Each goroutine should create and
+use its own instance, e.g.: one per worker or connection.
+
+Instances of this type should be initialized using [NewEncoder].
+*/
+type Encoder struct {
+	cfg encodingConfig
+	pkt PDU
+	err error
+}
+
+/*
+NewEncoder returns a freshly initialized instance of *[Encoder] which honors
+the input [EncodingOption] instances, as [Marshal] does, for every operation.
+If no [EncodingRule] is specified, the value of [DefaultEncoding] is used.
+
+Should the options be unsupported, the resultant error is returned by each
+call of [Encoder.Marshal].
+*/
+func NewEncoder(with ...EncodingOption) *Encoder {
+	r := &Encoder{cfg: encodingConfig{rule: DefaultEncoding}}
+	for _, o := range with {
+		o(&r.cfg)
+	}
+	r.cfg.resolve()
+	r.err = marshalCheckBadOptions(r.cfg.rule, r.cfg.opts)
+
+	return r
+}
+
+/*
+Marshal returns an instance of [PDU] alongside an error following an attempt
+to encode x, as with [Marshal].
+
+The return [PDU] belongs to the receiver instance. It remains valid only until
+the next call of this method, at which point its content is overwritten. The
+caller must NOT call its Free method, nor submit it to [Unmarshal] (which frees
+its input), and should copy the output of its Data method if the encoding is
+to be retained.
+*/
+func (r *Encoder) Marshal(x any) (pkt PDU, err error) {
+	if r == nil {
+		err = errorNilReceiver
+		return
+	} else if err = r.err; err != nil {
+		return
+	}
+
+	if rs, ok := r.pkt.(pduResetter); ok {
+		rs.reset()
+	} else {
+		if r.pkt != nil {
+			r.pkt.Free()
+		}
+		r.pkt = r.cfg.rule.New()
+	}
+
+	pkt = r.pkt
+	err = marshalConfigured(x, pkt, &r.cfg)
+
+	return
+}
@@ -0,0 +1,114 @@
+package asn1plus
+
+import "testing"
+
+type encoderTestRecord struct {
+	Name PrintableString
+	ID   Integer
+	Data OctetString `asn1:"tag:0,optional"`
+}
+
+func newEncoderTestRecords(t testing.TB) []encoderTestRecord {
+	var recs []encoderTestRecord
+	for i, s := range []string{"Bill Smith", "Jane Doe", "Ana"} {
+		name, err := NewPrintableString(s)
+		if err != nil {
+			t.Fatalf("%s failed: %v", t.Name(), err)
+		}
+		id, _ := NewInteger(i * 1000)
+		recs = append(recs, encoderTestRecord{Name: name, ID: id,
+			Data: OctetString(strrpt("x", i*100))})
+	}
+
+	return recs
+}
+
+func TestEncoder(t *testing.T) {
+	recs := newEncoderTestRecords(t)
+
+	for _, rule := range append(encodingRules, XER, JER, GSER) {
+		if !rule.Enabled() {
+			continue
+		}
+		enc := NewEncoder(With(rule))
+		for i, rec := range recs {
+			want, err := Marshal(rec, With(rule))
+			if err != nil {
+				t.Fatalf("%s[%d] failed [%s Marshal]: %v", t.Name(), i, rule, err)
+			}
+
+			var got PDU
+			if got, err = enc.Marshal(rec); err != nil {
+				t.Fatalf("%s[%d] failed [%s Encoder.Marshal]: %v", t.Name(), i, rule, err)
+			} else if !btseq(got.Data(), want.Data()) {
+				t.Fatalf("%s[%d] failed [%s]:\n\twant: %s\n\tgot:  %s", t.Name(), i,
+					rule, hexstr(want.Data()), hexstr(got.Data()))
+			} else if got.Type() != rule {
+				t.Fatalf("%s[%d] failed [%s]: unexpected PDU type %s", t.Name(), i, rule, got.Type())
+			}
+			want.Free()
+
+			if rule.textual() {
+				continue
+			}
+			// Unmarshal frees its input, thus decode a copy
+			var out encoderTestRecord
+			if err = Unmarshal(rule.New(got.Data()...), &out); err != nil {
+				t.Fatalf("%s[%d] failed [%s Unmarshal]: %v", t.Name(), i, rule, err)
+			} else if !deepEq(out.Name, rec.Name) || !btseq(out.Data, rec.Data) {
+				t.Fatalf("%s[%d] failed [%s]: want %#v, got %#v", t.Name(), i, rule, rec, out)
+			}
+		}
+	}
+
+	// options are honored on every call
+	enc := NewEncoder(With(DER), MaxOutputSize(32))
+	for i := 0; i < 2; i++ {
+		if _, err := enc.Marshal(recs[2]); err == nil {
+			t.Fatalf("%s failed [MaxOutputSize]: expected error, got nil", t.Name())
+		}
+	}
+	if _, err := enc.Marshal(recs[0]); err != nil {
+		t.Fatalf("%s failed [MaxOutputSize]: %v", t.Name(), err)
+	}
+
+	// bad options are reported by each operation
+	if DER.Enabled() {
+		enc = NewEncoder(With(DER, &Options{Indefinite: true}))
+		if _, err := enc.Marshal(recs[0]); err == nil {
+			t.Fatalf("%s failed [bad options]: expected error, got nil", t.Name())
+		}
+	}
+
+	var nilEnc *Encoder
+	if _, err := nilEnc.Marshal(recs[0]); err == nil {
+		t.Fatalf("%s failed [nil receiver]: expected error, got nil", t.Name())
+	}
+}
+
+func BenchmarkMarshal_perCall(b *testing.B) {
+	rec := newEncoderTestRecords(b)[1]
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pkt, err := Marshal(rec, With(DER))
+		if err != nil {
+			b.Fatal(err)
+		}
+		pkt.Free()
+	}
+}
+
+func BenchmarkEncoder_Marshal(b *testing.B) {
+	rec := newEncoderTestRecords(b)[1]
+	enc := NewEncoder(With(DER))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := enc.Marshal(rec); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
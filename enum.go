@@ -5,7 +5,24 @@ enum.go contains all types and methods pertaining to the ASN.1
 ENUMERATED type.
 */
 
-import "reflect"
+import (
+	"reflect"
+	"sync"
+)
+
+var (
+	enumerations map[string]enumeration
+	enMu         sync.RWMutex
+)
+
+/*
+enumeration contains the members of a registered ENUMERATED type, and
+whether it is extensible.
+*/
+type enumeration struct {
+	members    map[string]int
+	extensible bool
+}
 
 /*
 EnumeratedConstraintPhase declares the appropriate phase
@@ -79,6 +96,216 @@ func MustNewEnumerated(x any, constraints ...Constraint) Enumerated {
 	return b
 }
 
+/*
+Name returns the name of the member of the named enumeration, as
+registered via [RegisterEnumeration], whose value matches that of the
+receiver instance, alongside a Boolean value indicative of success.
+
+Case is not significant in the matching of enum.
+*/
+func (r Enumerated) Name(enum string) (name string, ok bool) {
+	if en, err := lookupEnumeration(enum); err == nil {
+		name, ok = inlineEnumName(en.members, int(r))
+	}
+	return
+}
+
+/*
+RegisterEnumeration associates name with the named values of an ENUMERATED
+type in a thread safe manner. This serves to make protocol enumerations
+self-documenting by way of [Enumerated.Name], and allows the members to be
+attached to a SEQUENCE field using the "enum::<name>" struct tag form:
+
+	RegisterEnumeration("color", map[string]int{
+		"red":   0,
+		"green": 1,
+		"blue":  2,
+	})
+
+	type MySequence struct {
+		Color Enumerated `asn1:"enum::color"`
+	}
+
+Such fields are encoded as their integer value, and values which are not
+members of the enumeration produce an error during both encoding and
+decoding, unless the "..." extensibility marker follows, e.g.:
+
+	Color Enumerated `asn1:"enum::color,..."`
+
+As with the "enum:<name>=<value>,..." form, fields of a string kind are
+also supported, in which case the member name is encoded and decoded.
+
+Case is not significant in the matching of name. Members must bear
+distinct values, else fields bearing the "enum::<name>" form fail to
+parse. The members map is copied, thus the caller may modify it freely
+thereafter. Existing
+registrations will be silently overwritten when a duplicate registration
+is executed, which takes effect for all subsequent operations.
+
+See also [RegisterExtensibleEnumeration] and [UnregisterEnumeration].
+*/
+func RegisterEnumeration(name string, members map[string]int) {
+	registerEnumeration(name, members, false)
+}
+
+/*
+RegisterExtensibleEnumeration is identical to [RegisterEnumeration], except
+that the enumeration is marked as extensible, per the X.680 extension marker
+(e.g.: "ENUMERATED { a, b, ... }"). Fields bearing the "enum::<name>" struct
+tag form thus tolerate values which are not members of the enumeration, as
+is needed for forward compatibility with future renditions of a protocol.
+
+Such values are decoded as-is: an [Enumerated] (or other integer kind) field
+holds the raw value, for which [Enumerated.Name] returns false, while a field
+of a string kind holds the value in decimal form.
+*/
+func RegisterExtensibleEnumeration(name string, members map[string]int) {
+	registerEnumeration(name, members, true)
+}
+
+func registerEnumeration(name string, members map[string]int, ext bool) {
+	name = lc(name)
+	if name == "" || len(members) == 0 {
+		return
+	}
+
+	cp := make(map[string]int, len(members))
+	for member, n := range members {
+		cp[member] = n
+	}
+
+	enMu.Lock()
+	defer enMu.Unlock()
+	enumerations[name] = enumeration{members: cp, extensible: ext}
+	forgetFieldOptions()
+}
+
+/*
+UnregisterEnumeration deletes the named enumeration registration in
+a thread safe manner.
+
+See also [RegisterEnumeration].
+*/
+func UnregisterEnumeration(name string) {
+	enMu.Lock()
+	defer enMu.Unlock()
+	delete(enumerations, lc(name))
+	forgetFieldOptions()
+}
+
+func lookupEnumeration(name string) (en enumeration, err error) {
+	enMu.RLock()
+	defer enMu.RUnlock()
+
+	var found bool
+	if en, found = enumerations[lc(name)]; !found {
+		err = errorUnknownEnumeration(name)
+	}
+
+	return
+}
+
+/*
+marshalInlineEnum returns a Boolean value indicative of v having been
+handled as an ENUMERATED value bearing in-line named values, as declared
+by the EnumNames field of opts, alongside an error following an attempt
+to encode v into pkt.
+*/
+func marshalInlineEnum(v reflect.Value, pkt PDU, opts *Options) (handled bool, err error) {
+	if opts == nil || len(opts.EnumNames) == 0 {
+		return
+	}
+	handled = true
+
+	var e Enumerated
+	if e, err = inlineEnumValue(v, opts); err == nil {
+		_, err = marshalPrimitive(refValueOf(e), pkt, opts)
+	}
+
+	return
+}
+
+/*
+unmarshalInlineEnum returns an error following an attempt to decode the
+next ENUMERATED value within pkt into v, which must be of a string or an
+integer kind, per the in-line named values declared within opts.
+*/
+func unmarshalInlineEnum(v reflect.Value, pkt PDU, opts *Options) (err error) {
+	var e Enumerated
+	if err = unmarshalPrimitive(pkt, refValueOf(&e).Elem(), opts); err != nil {
+		return
+	}
+
+	name, known := inlineEnumName(opts.EnumNames, int(e))
+	if !known && !opts.EnumExtensible {
+		err = codecErrorf("unknown ENUMERATED value ", int(e))
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		if !known {
+			name = itoa(int(e))
+		}
+		v.SetString(name)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.OverflowInt(int64(e)) {
+			err = codecErrorf("ENUMERATED ", int(e), " overflows ", v.Type())
+			return
+		}
+		v.SetInt(int64(e))
+	default:
+		err = codecErrorf("unsupported type for ENUMERATED: ", v.Type())
+	}
+
+	return
+}
+
+/*
+inlineEnumValue returns the ENUMERATED value of v, which must be of a
+string or an integer kind, per the in-line named values within opts.
+*/
+func inlineEnumValue(v reflect.Value, opts *Options) (e Enumerated, err error) {
+	switch v.Kind() {
+	case reflect.String:
+		name := v.String()
+		n, ok := opts.EnumNames[name]
+		if !ok && opts.EnumExtensible {
+			n, err = atoi(name)
+			ok = err == nil
+		}
+		if !ok {
+			err = codecErrorf("unknown ENUMERATED name ", name)
+			return
+		}
+		e = Enumerated(n)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e = Enumerated(v.Int())
+		if _, known := inlineEnumName(opts.EnumNames, int(e)); !known && !opts.EnumExtensible {
+			err = codecErrorf("unknown ENUMERATED value ", int(e))
+		}
+	default:
+		err = codecErrorf("unsupported type for ENUMERATED: ", v.Type())
+	}
+
+	return
+}
+
+/*
+inlineEnumName returns the name associated with value n within names,
+alongside a Boolean value indicative of success. Should several names
+share n, the lexically lowest is returned, such that the result does
+not depend upon the order of map iteration.
+*/
+func inlineEnumName(names map[string]int, n int) (name string, ok bool) {
+	for nm, val := range names {
+		if val == n && (!ok || nm < name) {
+			name, ok = nm, true
+		}
+	}
+	return
+}
+
 type enumeratedCodec[T ~int] struct {
 	val  T
 	base *integerCodec[Integer]
@@ -171,6 +398,8 @@ func RegisterEnumeratedAlias[T ~int](
 }
 
 func init() {
+	enumerations = make(map[string]enumeration)
+
 	RegisterEnumeratedAlias[Enumerated](TagEnum,
 		EnumeratedConstraintPhase,
 		nil, nil, nil, nil)
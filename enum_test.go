@@ -125,3 +125,225 @@ func BenchmarkEnumeratedConstructor(b *testing.B) {
 		}
 	}
 }
+
+func TestEnumerated_inlineNames(t *testing.T) {
+	type Color int
+
+	type Paint struct {
+		Name  string `asn1:"enum:red=0,green=1,blue=2"`
+		Code  Color  `asn1:"enum:red=0,green=1,blue=2"`
+		Shade string `asn1:"tag:0,enum:light=5,dark=6,...,optional"`
+	}
+
+	for _, rule := range encodingRules {
+		in := Paint{Name: "green", Code: 2, Shade: "9"}
+		pkt, err := Marshal(in, With(rule))
+		if err != nil {
+			t.Fatalf("%s failed [%s encode]: %v", t.Name(), rule, err)
+		}
+
+		want := []byte{0x30, 0x09, 0x0A, 0x01, 0x01, 0x0A, 0x01, 0x02, 0x80, 0x01, 0x09}
+		if got := pkt.Data(); !btseq(got, want) {
+			t.Fatalf("%s failed [%s encode]:\n\twant: %X\n\tgot:  %X", t.Name(), rule, want, got)
+		}
+
+		var out Paint
+		if err = Unmarshal(pkt, &out); err != nil {
+			t.Fatalf("%s failed [%s decode]: %v", t.Name(), rule, err)
+		} else if out != in {
+			t.Fatalf("%s failed [%s decode]:\n\twant: %#v\n\tgot:  %#v", t.Name(), rule, in, out)
+		}
+
+		if _, err = Marshal(Paint{Name: "purple"}, With(rule)); err == nil {
+			t.Fatalf("%s failed [%s]: expected error for unknown name, got nil", t.Name(), rule)
+		}
+		if _, err = Marshal(Paint{Name: "red", Code: 7}, With(rule)); err == nil {
+			t.Fatalf("%s failed [%s]: expected error for unknown value, got nil", t.Name(), rule)
+		}
+
+		bogus := rule.New(0x30, 0x06, 0x0A, 0x01, 0x03, 0x0A, 0x01, 0x00)
+		if err = Unmarshal(bogus, &out); err == nil {
+			t.Fatalf("%s failed [%s]: expected error decoding unknown value, got nil", t.Name(), rule)
+		}
+
+		// values beyond the range of the field are rejected, not truncated
+		type Small struct {
+			Code int8 `asn1:"enum:low=1,high=300"`
+		}
+		var small Small
+		wide := rule.New(0x30, 0x04, 0x0A, 0x02, 0x01, 0x2C)
+		if err = Unmarshal(wide, &small); err == nil {
+			t.Fatalf("%s failed [%s]: expected overflow error, got %d", t.Name(), rule, small.Code)
+		}
+	}
+
+	opts, err := NewOptions(`asn1:"enum:red=0,green=1,blue=2,..."`)
+	if err != nil {
+		t.Fatalf("%s failed [options]: %v", t.Name(), err)
+	} else if got := opts.String(); got != "universal,enum:red=0,green=1,blue=2,..." {
+		t.Fatalf("%s failed [options]: got %s", t.Name(), got)
+	}
+
+	for _, bogus := range []string{
+		`enum:red`,
+		`enum:red=x`,
+		`enum:red=0,red=1`,
+		`enum:red=0,crimson=0`,
+		`enum:red=0,optional,green=1`,
+	} {
+		if _, err = NewOptions(bogus); err == nil {
+			t.Fatalf("%s failed: expected error for %q, got nil", t.Name(), bogus)
+		}
+	}
+}
+
+func TestEnumerated_registered(t *testing.T) {
+	RegisterEnumeration("TestStatus", map[string]int{
+		"successful": 0,
+		"malformed":  1,
+		"tryLater":   3,
+	})
+	defer UnregisterEnumeration("TestStatus")
+
+	type Response struct {
+		Status Enumerated `asn1:"enum::testStatus"`
+	}
+
+	if name, ok := Enumerated(3).Name("testStatus"); !ok || name != "tryLater" {
+		t.Fatalf("%s failed [name]: want tryLater, got %q (%t)", t.Name(), name, ok)
+	} else if _, ok = Enumerated(2).Name("testStatus"); ok {
+		t.Fatalf("%s failed [name]: expected no match for unknown value", t.Name())
+	} else if _, ok = Enumerated(0).Name("bogus"); ok {
+		t.Fatalf("%s failed [name]: expected no match for unknown enumeration", t.Name())
+	}
+
+	for _, rule := range encodingRules {
+		in := Response{Status: 3}
+		pkt, err := Marshal(in, With(rule))
+		if err != nil {
+			t.Fatalf("%s failed [%s encode]: %v", t.Name(), rule, err)
+		}
+
+		want := []byte{0x30, 0x03, 0x0A, 0x01, 0x03}
+		if got := pkt.Data(); !btseq(got, want) {
+			t.Fatalf("%s failed [%s encode]:\n\twant: %X\n\tgot:  %X", t.Name(), rule, want, got)
+		}
+
+		var out Response
+		if err = Unmarshal(pkt, &out); err != nil {
+			t.Fatalf("%s failed [%s decode]: %v", t.Name(), rule, err)
+		} else if out != in {
+			t.Fatalf("%s failed [%s decode]:\n\twant: %#v\n\tgot:  %#v", t.Name(), rule, in, out)
+		}
+
+		// out-of-range values are rejected in both directions
+		if _, err = Marshal(Response{Status: 2}, With(rule)); err == nil {
+			t.Fatalf("%s failed [%s]: expected error for unknown value, got nil", t.Name(), rule)
+		}
+		bogus := rule.New(0x30, 0x03, 0x0A, 0x01, 0x02)
+		if err = Unmarshal(bogus, &out); err == nil {
+			t.Fatalf("%s failed [%s]: expected error decoding unknown value, got nil", t.Name(), rule)
+		}
+	}
+
+	opts, err := NewOptions(`asn1:"enum::testStatus,..."`)
+	if err != nil {
+		t.Fatalf("%s failed [options]: %v", t.Name(), err)
+	} else if got := opts.TagString(); got != "enum::teststatus,..." {
+		t.Fatalf("%s failed [options]: got %s", t.Name(), got)
+	} else if !opts.EnumExtensible || len(opts.EnumNames) != 3 {
+		t.Fatalf("%s failed [options]: unexpected members %v", t.Name(), opts.EnumNames)
+	}
+
+	if _, err = NewOptions(`enum::unregistered`); err == nil {
+		t.Fatalf("%s failed: expected error for unregistered enumeration, got nil", t.Name())
+	}
+
+	// members sharing a value render the mapping ambiguous
+	RegisterEnumeration("testAmbiguous", map[string]int{"red": 0, "crimson": 0})
+	defer UnregisterEnumeration("testAmbiguous")
+	if _, err = NewOptions(`enum::testAmbiguous`); err == nil {
+		t.Fatalf("%s failed: expected error for duplicate values, got nil", t.Name())
+	} else if name, _ := Enumerated(0).Name("testAmbiguous"); name != "crimson" {
+		t.Fatalf("%s failed [name]: want crimson, got %q", t.Name(), name)
+	}
+}
+
+func TestEnumerated_registeredExtensible(t *testing.T) {
+	RegisterExtensibleEnumeration("TestVersion", map[string]int{
+		"v1": 0,
+		"v2": 1,
+	})
+	defer UnregisterEnumeration("TestVersion")
+
+	type Message struct {
+		Version Enumerated `asn1:"enum::testVersion"`
+		Label   string     `asn1:"tag:0,enum::testVersion"`
+	}
+
+	for _, rule := range encodingRules {
+		// version 5 is unknown to this rendition of the protocol
+		pkt := rule.New(0x30, 0x06, 0x0A, 0x01, 0x05, 0x80, 0x01, 0x01)
+
+		var out Message
+		if err := Unmarshal(pkt, &out); err != nil {
+			t.Fatalf("%s failed [%s decode]: %v", t.Name(), rule, err)
+		} else if out.Version != 5 || out.Label != "v2" {
+			t.Fatalf("%s failed [%s decode]: got %#v", t.Name(), rule, out)
+		} else if _, known := out.Version.Name("testVersion"); known {
+			t.Fatalf("%s failed [%s name]: expected unknown member for raw value %d",
+				t.Name(), rule, out.Version)
+		}
+
+		// the raw value survives re-encoding
+		repkt, err := Marshal(out, With(rule))
+		if err != nil {
+			t.Fatalf("%s failed [%s encode]: %v", t.Name(), rule, err)
+		} else if !btseq(repkt.Data(), pkt.Data()) {
+			t.Fatalf("%s failed [%s encode]:\n\twant: %X\n\tgot:  %X",
+				t.Name(), rule, pkt.Data(), repkt.Data())
+		}
+	}
+}
+
+func TestEnumerated_reregistered(t *testing.T) {
+	type Paint struct {
+		Color string `asn1:"enum::testColor"`
+	}
+
+	RegisterEnumeration("TestColor", map[string]int{"red": 0, "green": 1})
+	defer UnregisterEnumeration("TestColor")
+
+	for _, rule := range encodingRules {
+		if pkt, err := Marshal(Paint{Color: "green"}, With(rule)); err != nil {
+			t.Fatalf("%s failed [%s initial]: %v", t.Name(), rule, err)
+		} else if want := []byte{0x30, 0x03, 0x0A, 0x01, 0x01}; !btseq(pkt.Data(), want) {
+			t.Fatalf("%s failed [%s initial]:\n\twant: %X\n\tgot:  %X", t.Name(), rule, want, pkt.Data())
+		}
+	}
+
+	// re-registration must take effect for fields already encountered
+	RegisterEnumeration("TestColor", map[string]int{"red": 0, "green": 2, "blue": 3})
+	for _, rule := range encodingRules {
+		for color, n := range map[string]byte{"green": 2, "blue": 3} {
+			pkt, err := Marshal(Paint{Color: color}, With(rule))
+			if err != nil {
+				t.Fatalf("%s failed [%s %s]: %v", t.Name(), rule, color, err)
+			} else if want := []byte{0x30, 0x03, 0x0A, 0x01, n}; !btseq(pkt.Data(), want) {
+				t.Fatalf("%s failed [%s %s]:\n\twant: %X\n\tgot:  %X", t.Name(), rule, color, want, pkt.Data())
+			}
+
+			var out Paint
+			if err = Unmarshal(pkt, &out); err != nil || out.Color != color {
+				t.Fatalf("%s failed [%s %s decode]: got %q, %v", t.Name(), rule, color, out.Color, err)
+			}
+		}
+	}
+
+	UnregisterEnumeration("TestColor")
+	for _, rule := range encodingRules {
+		if _, err := Marshal(Paint{Color: "green"}, With(rule)); err == nil {
+			t.Fatalf("%s failed [%s unregistered]: expected error, got nil", t.Name(), rule)
+		}
+	}
+}
@@ -32,9 +32,12 @@ const (
 this package.
 */
 const (
-	BER EncodingRule = 1 << iota // 1
-	CER                          // 2
-	DER                          // 4
+	BER  EncodingRule = 1 << iota // 1
+	CER                           // 2
+	DER                           // 4
+	XER                           // 8
+	JER                           // 16
+	GSER                          // 32
 )
 
 /*
@@ -49,7 +52,7 @@ encoding rules within this package.  This is essentially the
 "master list" of all possible encoding rules in this package,
 but does not reflect which rules are LOADED.
 */
-var allEncodingRules []EncodingRule = []EncodingRule{BER, CER, DER}
+var allEncodingRules []EncodingRule = []EncodingRule{BER, CER, DER, XER, JER, GSER}
 
 /*
 Enabled returns a Boolean value indicative of whether support for
@@ -83,6 +86,20 @@ func (r EncodingRule) canonicalOrdering() (ord bool) {
 	return
 }
 
+/*
+textual returns a Boolean value indicative of whether the receiver
+instance produces character-based output, as opposed to the binary
+tag/length/value encoding used by [BER] and its descendants.
+*/
+func (r EncodingRule) textual() (txt bool) {
+	switch r {
+	case XER, JER, GSER:
+		txt = true
+	}
+
+	return
+}
+
 /*
 In returns a Boolean instance indicative of r being present within e.
 */
@@ -148,8 +165,29 @@ func (r EncodingRule) newTLV(class, tag, length int, compound bool, value ...byt
 type EncodingOption func(*encodingConfig)
 
 type encodingConfig struct {
-	rule EncodingRule
-	opts *Options
+	rule      EncodingRule
+	opts      *Options
+	maxOutput int
+	tagNames  map[[2]int]string
+	fallback  []EncodingRule
+	observed  *EncodingRule
+	trailing  *int
+	strict    bool
+	noConstr  bool
+	annotate  bool
+}
+
+/*
+resolve applies any deferred settings to the receiver instance once
+all [EncodingOption] instances have been applied, regardless of the
+order in which they were given.
+*/
+func (r *encodingConfig) resolve() {
+	if r.noConstr {
+		// copy, so as not to alter the caller's instance
+		o := *deferImplicit(r.opts)
+		r.opts = o.SetConstraintPhase(CodecConstraintNone)
+	}
 }
 
 /*
@@ -159,10 +197,14 @@ in-line as a variadic input value to [Marshal] and [Unmarshal].
 
 It is unnecessary -- but harmless -- to include an [EncodingRule] when submitting to
 [Unmarshal], as the input [PDU] instance knows what [EncodingRule] it implements.
+
+Instances of [EncodingOption], such as those returned by [MaxOutputSize], may also
+be supplied and are applied in the order given.
 */
 func With(args ...any) EncodingOption {
 	var rule EncodingRule = DefaultEncoding
 	var opts *Options
+	var extra []EncodingOption
 
 	for i := 0; i < len(args); i++ {
 		switch tv := args[i].(type) {
@@ -174,12 +216,144 @@ func With(args ...any) EncodingOption {
 			opts = &tv
 		case *Options:
 			opts = tv
+		case EncodingOption:
+			extra = append(extra, tv)
 		}
 	}
 
 	return func(cfg *encodingConfig) {
 		cfg.rule = rule
 		cfg.opts = opts
+		for _, o := range extra {
+			o(cfg)
+		}
+	}
+}
+
+/*
+MaxOutputSize returns an [EncodingOption] which limits the cumulative size
+of the encoding produced by [Marshal] to n bytes. If the limit is exceeded,
+[Marshal] returns an error. For binary encoding rules, such as [BER] and its
+descendants, the process is aborted as soon as the partial output passes
+the threshold, rather than upon completion.
+
+A value of zero (0) or less disables the limit, which is the default.
+
+This is useful for transports which impose a hard message-size limit.
+*/
+func MaxOutputSize(n int) EncodingOption {
+	return func(cfg *encodingConfig) {
+		cfg.maxOutput = n
+	}
+}
+
+/*
+DumpTagNames returns an [EncodingOption] which associates names with
+tags, keyed by class and tag number, for use in the output of the Dump
+method of the resultant [PDU]. For example, {[ClassContextSpecific], 0}
+may be mapped to "[filter and]" to label such TLVs in place of the
+default "[CONTEXT SPECIFIC 0]" label.
+
+Names are used verbatim, and take precedence over the names within
+[TagNames]. Tags absent from names are labeled as usual.
+*/
+func DumpTagNames(names map[[2]int]string) EncodingOption {
+	return func(cfg *encodingConfig) {
+		cfg.tagNames = names
+	}
+}
+
+/*
+FallbackRules returns an [EncodingOption] which instructs [Unmarshal] to
+retry a failed decoding operation under each of the given rules, in the
+order given, should decoding fail under the rule of the input [PDU]. The
+first successful attempt wins; if all attempts fail, the error produced
+by the initial attempt is returned.
+
+This is useful for tolerant consumers which accept input produced under
+several rules, e.g.: indefinite-length [BER] received where [DER] was
+expected. Rules which are disabled, or which match that of the input
+[PDU], are skipped. See also [ObservedRule].
+*/
+func FallbackRules(rules ...EncodingRule) EncodingOption {
+	return func(cfg *encodingConfig) {
+		cfg.fallback = append(cfg.fallback, rules...)
+	}
+}
+
+/*
+ObservedRule returns an [EncodingOption] which instructs [Unmarshal] to
+write the [EncodingRule] under which decoding ultimately succeeded into
+dst. This is normally the rule of the input [PDU], unless decoding only
+succeeded by way of [FallbackRules]. dst is not modified upon failure.
+*/
+func ObservedRule(dst *EncodingRule) EncodingOption {
+	return func(cfg *encodingConfig) {
+		cfg.observed = dst
+	}
+}
+
+/*
+WithStrictTrailing returns an [EncodingOption] which instructs [Unmarshal]
+to return an error if any bytes follow the top-level value within the input
+[PDU], rather than silently ignoring them. Such bytes often indicate framing
+errors, such as the inadvertent concatenation of several encodings.
+
+This only applies to binary encoding rules, such as [BER] and its descendants.
+See also [TrailingBytes] and [UnmarshalN].
+*/
+func WithStrictTrailing() EncodingOption {
+	return func(cfg *encodingConfig) {
+		cfg.strict = true
+	}
+}
+
+/*
+TrailingBytes returns an [EncodingOption] which instructs [Unmarshal] to
+write the number of bytes which follow the top-level value within the input
+[PDU] into dst. As [Unmarshal] frees the input [PDU], this is the means by
+which the value of [Remaining] is reported following a successful decoding
+operation. dst is not modified upon failure.
+
+This only applies to binary encoding rules, such as [BER] and its descendants.
+See also [WithStrictTrailing].
+*/
+func TrailingBytes(dst *int) EncodingOption {
+	return func(cfg *encodingConfig) {
+		cfg.trailing = dst
+	}
+}
+
+/*
+WithoutConstraints returns an [EncodingOption] which disables all
+[Constraint] evaluation performed during a single [Marshal] or [Unmarshal]
+operation. This includes the constraints of all codecs, regardless of
+their configured phase (e.g.: [IntegerConstraintPhase]), as well as any
+constraints declared via [Options] or struct tags.
+
+This is intended for hot paths in which values were already validated
+upstream. Note that constraints supplied to type constructors, such as
+[NewInteger], are unaffected, as are the base specifications enforced
+by type constructors. See also [Options.SetConstraintPhase].
+*/
+func WithoutConstraints() EncodingOption {
+	return func(cfg *encodingConfig) {
+		cfg.noConstr = true
+	}
+}
+
+/*
+WithFieldAnnotations returns an [EncodingOption] which instructs [Marshal]
+to record the byte range of each encoded SEQUENCE field within the resultant
+[PDU], alongside the Go field name, for use in its Dump output.
+
+This option has no effect unless the package is built with the "asn1_debug"
+tag, in which case the recorded annotations may be obtained from the function
+FieldAnnotations.
+*/
+func WithFieldAnnotations() EncodingOption {
+	return func(cfg *encodingConfig) {
+		cfg.annotate = true
 	}
 }
 
@@ -195,6 +369,12 @@ func (r EncodingRule) String() string {
 		s = `CER`
 	case DER:
 		s = `DER`
+	case XER:
+		s = `XER`
+	case JER:
+		s = `JER`
+	case GSER:
+		s = `GSER`
 	}
 
 	return s
@@ -213,6 +393,10 @@ func (r EncodingRule) OID() ObjectIdentifier {
 		oid = cerOID
 	case DER:
 		oid = derOID
+	case XER:
+		oid = xerOID
+	case JER:
+		oid = jerOID
 	}
 
 	return oid
@@ -221,12 +405,15 @@ func (r EncodingRule) OID() ObjectIdentifier {
 /*
 prebuilt list of enabled encoding rules for use
 in test/op iteration.
+
+Note that character-based rules, such as [XER], [JER] and [GSER], are
+not included, as they do not implement the TLV model.
 */
 var encodingRules []EncodingRule
 
 func init() {
 	for _, r := range allEncodingRules {
-		if r.Enabled() {
+		if r.Enabled() && !r.textual() {
 			encodingRules = append(encodingRules, r)
 		}
 	}
@@ -27,6 +27,33 @@ var (
 	errorNoPanic              error = mkerr("expected panic, got success")
 )
 
+/*
+Exported sentinel errors, which may be matched against any error returned by
+this package using [errors.Is], regardless of how deeply the sentinel may be
+wrapped, e.g.:
+
+	if errors.Is(err, asn1plus.ErrTruncatedValue) {
+		// wait for more input ...
+	}
+
+The text of a sentinel is not considered stable, and should not be
+matched as a string.
+*/
+var (
+	ErrTruncatedContent     error = errorTruncatedContent     // content octets end prematurely
+	ErrTruncatedLength      error = errorTruncatedLength      // length octets end prematurely
+	ErrTruncatedTag         error = errorTruncatedTag         // identifier octets end prematurely
+	ErrTruncatedTLV         error = errorTruncTLV             // TLV ends prematurely
+	ErrTruncatedValue       error = errorTruncDefLen          // definite length exceeds available octets
+	ErrMissingEOC           error = errorNoEOCIndefTLV        // indefinite length value lacks end-of-contents
+	ErrIndefiniteProhibited error = errorIndefiniteProhibited // indefinite length forbidden by rule
+	ErrLengthTooLarge       error = errorLengthTooLarge       // length octets exceed four (4)
+	ErrTagTooLarge          error = errorTagTooLarge          // tag number exceeds limit
+	ErrElementTooLarge      error = errorElementTooLarge      // element exceeds maximum length
+	ErrMaxDecodeDepth       error = errorMaxDecodeDepth       // see MaxDecodeDepth
+	ErrRuleNotImplemented   error = errorRuleNotImplemented   // encoding rule disabled or unsupported
+)
+
 /*
 options errors.
 */
@@ -64,24 +91,29 @@ var (
 	errorRuleNotImplemented = codecErr{mkerr("encoding rule not yet implemented or is deactivated")}
 	errorLengthTooLarge     = codecErr{mkerr("length bytes too large (>4 octets)")}
 	errorInvalidPacket      = codecErr{mkerr("invalid Packet instance")}
-	errorEmptyLength        = codecErr{mkerr("length bytes not found")}
+	errorEmptyLength        = codecErr{&wrapErr{msg: "length bytes not found", errs: []error{errorTruncatedLength}}}
 	errorTruncatedTag       = codecErr{mkerr("truncated high-tag-number form")}
 	errorTruncatedContent   = codecErr{mkerr("packet content is truncated")}
 	errorTruncatedLength    = codecErr{mkerr("packet length is truncated")}
 	errorTagTooLarge        = codecErr{mkerr("tag too large (≥ 2^28)")}
 	errorOutOfBounds        = codecErr{mkerr("content and offset out of bounds")}
 	errorNilValue           = codecErr{mkerr("invalid or nil value")}
+	errorTextualPDU         = codecErr{mkerr("operation not supported by character-based encoding rule")}
+	errorReadOnlyPDU        = codecErr{mkerr("operation not permitted on read-only PDU")}
+	errorMaxDecodeDepth     = codecErr{mkerr("maximum decoding depth exceeded")}
+	errorElementTooLarge    = codecErr{mkerr("element length exceeds maximum")}
 )
 
 /*
 primitive errors
 */
 var (
-	errorNegativeInteger = primitiveErr{mkerr("Integer is negative")}
-	errorMinOIDArcs      = primitiveErr{mkerr("OBJECT IDENTIFIER: an OID must have two (2) or more number forms")}
-	errorMinRelOIDArcs   = primitiveErr{mkerr("RELATIVE-OID must have at least one arc")}
-	errorBadUTCTime      = primitiveErr{mkerr("UTCTime is invalid")}
-	errorBadGT           = primitiveErr{mkerr("GeneralizedTime is invalid")}
+	errorNegativeInteger  = primitiveErr{mkerr("Integer is negative")}
+	errorNegativeDuration = primitiveErr{mkerr("DURATION cannot be negative")}
+	errorMinOIDArcs       = primitiveErr{mkerr("OBJECT IDENTIFIER: an OID must have two (2) or more number forms")}
+	errorMinRelOIDArcs    = primitiveErr{mkerr("RELATIVE-OID must have at least one arc")}
+	errorBadUTCTime       = primitiveErr{mkerr("UTCTime is invalid")}
+	errorBadGT            = primitiveErr{mkerr("GeneralizedTime is invalid")}
 )
 
 /*
@@ -92,6 +124,7 @@ var (
 	errorComponentsNotAnonymous = compositeErr{mkerr("'COMPONENTS OF' requires field to be anonymous")}
 	errorExtensionNotFieldZero  = compositeErr{mkerr("EXTENSION: []TLV use is limited to field 0")}
 	errorAbsentNotNilPtr        = compositeErr{mkerr("ABSENT fields must be always be a nil pointer")}
+	errorSetOfOrdering          = compositeErr{mkerr("DER: SET OF elements not in canonical order")}
 )
 
 /*
@@ -143,6 +176,47 @@ func (r optionsErr) Error() string    { return `OPTIONS ERROR: ` + r.e.Error() }
 func (r primitiveErr) Error() string  { return `PRIMITIVE ERROR: ` + r.e.Error() }
 func (r tLVErr) Error() string        { return `TLV ERROR: ` + r.e.Error() }
 
+func (r adapterErr) Unwrap() error    { return r.e }
+func (r choiceErr) Unwrap() error     { return r.e }
+func (r classErr) Unwrap() error      { return r.e }
+func (r codecErr) Unwrap() error      { return r.e }
+func (r compositeErr) Unwrap() error  { return r.e }
+func (r constraintErr) Unwrap() error { return r.e }
+func (r generalErr) Unwrap() error    { return r.e }
+func (r optionsErr) Unwrap() error    { return r.e }
+func (r primitiveErr) Unwrap() error  { return r.e }
+func (r tLVErr) Unwrap() error        { return r.e }
+
+/*
+fieldPathErr annotates an error produced while decoding a SEQUENCE or
+SET component with the path of field names leading to the offending
+component, e.g.: "MySequence.Field2.Deep.Field2".
+*/
+type fieldPathErr struct {
+	path []string
+	e    error
+}
+
+func (r fieldPathErr) Error() string { return join(r.path, ".") + ": " + r.e.Error() }
+func (r fieldPathErr) Unwrap() error { return r.e }
+
+/*
+withFieldPath returns err following the prepending of name to its field
+path. If err does not already bear a field path, one is started.
+*/
+func withFieldPath(name string, err error) error {
+	if len(name) == 0 || err == nil {
+		return err
+	}
+
+	if fe, ok := err.(fieldPathErr); ok {
+		fe.path = append([]string{name}, fe.path...)
+		return fe
+	}
+
+	return fieldPathErr{path: []string{name}, e: err}
+}
+
 func errorPrimitiveAssertionFailed(x any) error {
 	return primitiveErrorf("Assertion failed for ", refTypeOf(x))
 }
@@ -155,10 +229,34 @@ func errorNamedDefaultNotFound(name string) (err error) {
 	if len(name) > 0 {
 		name = ":" + name
 	}
-	err = generalErrorf(errorDefaultNotFound.Error(), ": ", name)
+	err = generalErrorf(errorDefaultNotFound, ": ", name)
 	return
 }
 
+func errorBadDumpWidth(width int) error {
+	return codecErrorf("Dump: width ", width, " is less than the minimum of ", MinDumpWidth)
+}
+
+func errorSequenceOrdering(name string, tlv TLV) error {
+	comp := "[" + ClassNames[tlv.Class] + " " + itoa(tlv.Tag) + "]"
+	if name == "" {
+		return compositeErrorf("SEQUENCE: unexpected component ", comp)
+	}
+	return compositeErrorf("SEQUENCE: component ", comp, " of field ", name, " appears out of order")
+}
+
+func errorBadSize(expr string) error {
+	return optionsErrorf("invalid SIZE expression: " + expr)
+}
+
+func errorTrailingData(n int) error {
+	return codecErrorf("Unmarshal: ", n, " trailing bytes follow the top-level value")
+}
+
+func errorUnknownEnumeration(n string) error {
+	return optionsErrorf("unknown or unregistered ENUMERATED: " + n)
+}
+
 func errorUnknownConstraint(n string) error {
 	return generalErrorf("unknown or unregistered constraint: " + n)
 }
@@ -193,6 +291,22 @@ func errorTLVNoData(r PDU) (err error) {
 
 var errCache sync.Map
 
+/*
+wrapErr is an error composed of a message and the errors embedded within
+it, which remain reachable by way of [errors.Is] and [errors.As].
+*/
+type wrapErr struct {
+	msg  string
+	errs []error
+}
+
+func (r *wrapErr) Error() string   { return r.msg }
+func (r *wrapErr) Unwrap() []error { return r.errs }
+
+/*
+mkerrf returns an error whose message is the concatenation of parts. Any
+error among parts is retained, much like the "%w" verb of [fmt.Errorf].
+*/
 func mkerrf(parts ...any) error {
 	if len(parts) == 0 {
 		return nil
@@ -208,6 +322,7 @@ func mkerrf(parts ...any) error {
 		}
 	}
 
+	var errs []error
 	b := newStrBuilder()
 	for _, p := range parts {
 		switch v := p.(type) {
@@ -217,6 +332,7 @@ func mkerrf(parts ...any) error {
 			b.WriteString(v.String())
 		case error:
 			b.WriteString(v.Error())
+			errs = append(errs, v)
 		case string:
 			b.WriteString(v)
 		case reflect.Type:
@@ -229,6 +345,11 @@ func mkerrf(parts ...any) error {
 	}
 	msg := b.String()
 
+	if len(errs) > 0 {
+		// not cached, as the wrapped errors vary
+		return &wrapErr{msg: msg, errs: errs}
+	}
+
 	if v, hit := errCache.Load(msg); hit {
 		return v.(error)
 	}
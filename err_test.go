@@ -1,6 +1,8 @@
 package asn1plus
 
 import (
+	"errors"
+	"strings"
 	"testing"
 )
 
@@ -20,3 +22,58 @@ func TestExpectError(t *testing.T) {
 	mkerrf()
 	mkerrf(nil)
 }
+
+func TestErrorsIs(t *testing.T) {
+	type Inner struct {
+		Name OctetString
+	}
+	type Outer struct {
+		Inner Inner
+	}
+
+	// 30 07 30 05 04 03 'a' 'b' 'c', with the OCTET STRING length overstated
+	nested := []byte{0x30, 0x07, 0x30, 0x05, 0x04, 0x09, 'a', 'b', 'c'}
+
+	for idx, tc := range []struct {
+		rule EncodingRule
+		data []byte
+		want error
+	}{
+		{BER, nested, ErrTruncatedValue},
+		{BER, []byte{0x04, 0x85, 0x01, 0x01, 0x01, 0x01, 0x01}, ErrLengthTooLarge},
+		{DER, []byte{0x30, 0x80, 0x30, 0x80, 0x04, 0x01, 'a', 0x00, 0x00, 0x00, 0x00}, ErrIndefiniteProhibited},
+	} {
+		if !tc.rule.Enabled() {
+			continue
+		}
+
+		var out Outer
+		err := UnmarshalBytes(tc.data, tc.rule, &out)
+		if !errors.Is(err, tc.want) {
+			t.Fatalf("%s[%d] failed: want errors.Is(%v), got %v", t.Name(), idx, tc.want, err)
+		}
+
+		// must survive additional wrapping by the caller
+		if wrapped := compositeErrorf("caller: ", err); !errors.Is(wrapped, tc.want) {
+			t.Fatalf("%s[%d] failed: sentinel lost through wrapping: %v", t.Name(), idx, wrapped)
+		}
+	}
+
+	// truncated top-level elements, by way of Unmarshal
+	for idx, tc := range []struct {
+		data []byte
+		want error
+	}{
+		{[]byte{0x04, 0x07, 'a', 'b', 'c'}, ErrTruncatedValue},
+		{[]byte{0x04}, ErrTruncatedLength},
+		{[]byte{0x04, 0x82, 0x01}, ErrTruncatedLength},
+	} {
+		var out OctetString
+		err := Unmarshal(BER.New(tc.data...), &out)
+		if !errors.Is(err, tc.want) {
+			t.Fatalf("%s[truncated %d] failed: want errors.Is(%v), got %v", t.Name(), idx, tc.want, err)
+		} else if msg := err.Error(); strings.Count(msg, "(") != strings.Count(msg, ")") {
+			t.Fatalf("%s[truncated %d] failed: unbalanced error message: %s", t.Name(), idx, msg)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package asn1plus
+
+import "testing"
+
+/*
+fuzzSeeds contains well-formed and malformed encodings used to seed each
+of the fuzz targets below.
+*/
+var fuzzSeeds = [][]byte{
+	{},
+	{0x00},
+	{0x00, 0x00},
+	{0x04, 0x00},
+	{0x04, 0x03, 'a', 'b', 'c'},
+	{0x04, 0x81, 0x01, 'a'},
+	{0x04, 0x84, 0xFF, 0xFF, 0xFF, 0xFF},
+	{0x04, 0x85, 0x01, 0x01, 0x01, 0x01, 0x01},
+	{0x24, 0x80, 0x04, 0x01, 'a', 0x00, 0x00},
+	{0x24, 0x80, 0x24, 0x80, 0x04, 0x01, 'a', 0x00, 0x00, 0x00, 0x00},
+	{0x24, 0x80, 0x04, 0x05, 'a'},
+	{0x1F, 0x81, 0x80, 0x80, 0x80, 0x00, 0x00},
+	{0x1F, 0x81},
+	{0x30, 0x06, 0x02, 0x01, 0x05, 0x04, 0x01, 'x'},
+	{0x30, 0x80, 0x02, 0x01, 0x05, 0x00, 0x00},
+}
+
+func FuzzParseTLV(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed, 0)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte, off int) {
+		for _, rule := range encodingRules {
+			pkt := rule.New(data...)
+			pkt.SetOffset(0)
+			for pkt.HasMoreData() {
+				start := pkt.Offset()
+				tlv, err := pkt.TLV()
+				if err != nil {
+					break
+				} else if tlv.Length < 0 {
+					pkt.AddOffset(len(tlv.Value))
+				} else {
+					pkt.AddOffset(tlv.Length)
+				}
+				if pkt.Offset() <= start {
+					break
+				}
+			}
+			pkt.Free()
+
+			if body, err := parseBody(data, off, rule); err == nil && len(body) > len(data) {
+				t.Fatalf("%s failed [%s parseBody]: body exceeds input", t.Name(), rule)
+			}
+			if full, err := parseFullBytes(data, off, rule); err == nil && len(full) > len(data) {
+				t.Fatalf("%s failed [%s parseFullBytes]: TLV exceeds input", t.Name(), rule)
+			}
+		}
+
+		if _, idLen, err := parseTagIdentifier(data); err == nil && idLen > len(data) {
+			t.Fatalf("%s failed [parseTagIdentifier]: identifier exceeds input", t.Name())
+		}
+		if length, lenLen, err := parseLength(data); err == nil && (lenLen > len(data) || length < -1) {
+			t.Fatalf("%s failed [parseLength]: bogus length %d (%d octets)", t.Name(), length, lenLen)
+		}
+	})
+}
+
+func FuzzUnmarshalOctetString(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, rule := range encodingRules {
+			var oct OctetString
+			_ = Unmarshal(rule.New(data...), &oct)
+
+			var b []byte
+			_ = Unmarshal(rule.New(data...), &b)
+		}
+	})
+}
+
+/*
+fuzzRecord is a SEQUENCE bearing temporal, INTEGER, BIT STRING and string
+components, used by FuzzUnmarshal.
+*/
+type fuzzRecord struct {
+	When  GeneralizedTime
+	Count Integer
+	Flags BitString
+	Name  PrintableString
+}
+
+/*
+fuzzTargets contains the factories of the values into which FuzzUnmarshal
+decodes its input. Build-specific test files may append to it.
+*/
+var fuzzTargets = []func() any{
+	func() any { return new(fuzzRecord) },
+}
+
+func FuzzUnmarshal(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+	for _, rule := range encodingRules {
+		when, _ := NewGeneralizedTime("20240102030405Z")
+		count, _ := NewInteger(48)
+		if pkt, err := Marshal(fuzzRecord{When: when, Count: count,
+			Flags: BitString{Bytes: []byte{0xA0}, BitLength: 4},
+			Name:  PrintableString("Jesse")}, With(rule)); err == nil {
+			f.Add(append([]byte(nil), pkt.Data()...))
+			pkt.Free()
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, rule := range encodingRules {
+			for _, target := range fuzzTargets {
+				_ = Unmarshal(rule.New(data...), target())
+			}
+		}
+	})
+}
+
+func FuzzFindEOC(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if i, err := findEOC(data); err == nil {
+			if i < 0 || i+1 >= len(data) || data[i] != 0x00 || data[i+1] != 0x00 {
+				t.Fatalf("%s failed: bogus EOC index %d for %X", t.Name(), i, data)
+			}
+		}
+	})
+}
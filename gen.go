@@ -145,7 +145,7 @@ func init() {
 		return
 	}
 
-	RegisterTextAlias[GeneralString](TagGeneralString,
-		GeneralStringConstraintPhase,
+	registerTextAlias[GeneralString](TagGeneralString,
+		func() int { return GeneralStringConstraintPhase },
 		nil, nil, nil, GeneralSpec)
 }
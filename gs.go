@@ -22,6 +22,10 @@ for the constraining of values during codec operations.
 See the [CodecConstraintNone], [CodecConstraintEncoding],
 [CodecConstraintDecoding] and [CodecConstraintBoth] constants
 for possible settings.
+
+This includes [GraphicSpec], which rejects characters outside of the
+graphic repertoire, such as control characters. By default, this
+check is applied only when decoding.
 */
 var GraphicStringConstraintPhase = CodecConstraintDecoding
 
@@ -114,6 +118,8 @@ func init() {
 		switch tv := gs.(type) {
 		case string:
 			err = graphicStringDecoderVerify([]byte(tv))
+		case []byte:
+			err = graphicStringDecoderVerify(tv)
 		case Primitive:
 			err = graphicStringDecoderVerify([]byte(tv.String()))
 		default:
@@ -122,8 +128,7 @@ func init() {
 		return
 	}
 
-	RegisterTextAlias[GraphicString](TagGraphicString,
-		GraphicStringConstraintPhase,
-		graphicStringDecoderVerify,
-		nil, nil, GraphicSpec)
+	registerTextAlias[GraphicString](TagGraphicString,
+		func() int { return GraphicStringConstraintPhase },
+		nil, nil, nil, GraphicSpec)
 }
@@ -172,3 +172,33 @@ func BenchmarkGraphicStringConstructor(b *testing.B) {
 		}
 	}
 }
+
+func TestGraphicString_controlCharacters(t *testing.T) {
+	for _, bad := range []string{"line\nbreak", "bell\x07", "del\x7F", "nul\x00"} {
+		if _, err := NewGraphicString(bad); err == nil {
+			t.Fatalf("%s failed: expected error for %q, got nil", t.Name(), bad)
+		}
+
+		enc := append([]byte{0x19, byte(len(bad))}, bad...)
+		for _, rule := range encodingRules {
+			var got GraphicString
+			if err := Unmarshal(rule.New(enc...), &got); err == nil {
+				t.Fatalf("%s failed [%s decode]: expected error for %q, got nil", t.Name(), rule, bad)
+			}
+
+			// decode-phase constraints may be disabled per operation
+			opts := (&Options{}).SetConstraintPhase(CodecConstraintNone)
+			if err := Unmarshal(rule.New(enc...), &got, With(opts)); err != nil {
+				t.Fatalf("%s failed [%s unconstrained decode]: %v", t.Name(), rule, err)
+			} else if string(got) != bad {
+				t.Fatalf("%s failed [%s unconstrained decode]: want %q, got %q", t.Name(), rule, bad, got)
+			}
+
+			// ... or extended to the encoding phase
+			opts = (&Options{}).SetConstraintPhase(CodecConstraintBoth)
+			if _, err := Marshal(GraphicString(bad), With(rule, opts)); err == nil {
+				t.Fatalf("%s failed [%s encode]: expected error for %q, got nil", t.Name(), rule, bad)
+			}
+		}
+	}
+}
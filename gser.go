@@ -0,0 +1,391 @@
+//go:build !asn1_no_gser
+
+package asn1plus
+
+/*
+gser.go contains GSER-focused components.
+*/
+
+import (
+	"io"
+	"math"
+	"reflect"
+	"strings"
+)
+
+/*
+GSERPacket encapsulates an [RFC 3641] GSER-encoded byte slice and an offset.
+
+The Generic String Encoding Rules produce a human-readable representation
+of ASN.1 values, such as those commonly found within LDAP and X.509 contexts.
+Instances of this type contain character-based content and, as such, do not
+implement the tag/length/value model. Methods which rely upon TLV parsing
+will return an error.
+
+GSER support is limited to encoding (marshaling) only.
+
+[RFC 3641]: https://datatracker.ietf.org/doc/html/rfc3641
+*/
+type GSERPacket textPacket
+
+/*
+Type returns [GSER], identifying the receiver as a Generic String Encoding
+Rules [PDU] qualifier.
+*/
+func (r GSERPacket) Type() EncodingRule { return GSER }
+
+/*
+ID returns the unique string identifier associated with the receiver instance.
+
+Note that if this package is not compiled or run with "-tags asn1_debug", this
+method will always return a zero string.
+*/
+func (r GSERPacket) ID() string { return textPacket(r).ID() }
+
+/*
+Class returns -1 alongside an error, as GSER does not implement ASN.1 class
+identifiers in its encoding.
+*/
+func (r GSERPacket) Class() (int, error) { return textPacket(r).Class() }
+
+/*
+Tag returns -1 alongside an error, as GSER does not implement ASN.1 tag
+identifiers in its encoding.
+*/
+func (r GSERPacket) Tag() (int, error) { return textPacket(r).Tag() }
+
+/*
+Compound returns false alongside an error, as GSER does not implement ASN.1
+compound identifiers in its encoding.
+*/
+func (r GSERPacket) Compound() (bool, error) { return textPacket(r).Compound() }
+
+/*
+Bytes returns the GSER content of the underlying buffer, from the current
+offset onward, alongside an error.
+*/
+func (r GSERPacket) Bytes() ([]byte, error) { return textPacket(r).Bytes() }
+
+/*
+FullBytes returns the complete GSER content of the underlying buffer
+alongside an error.
+*/
+func (r GSERPacket) FullBytes() ([]byte, error) { return textPacket(r).FullBytes() }
+
+/*
+Hex returns the GSER text within the receiver instance. Unlike binary
+encoding rules, there is no need to represent GSER content as hexadecimal.
+*/
+func (r GSERPacket) Hex() string { return textPacket(r).Hex() }
+
+/*
+Dump returns an error following an attempt to write the receiver
+instance into w, placing each component upon its own line.
+
+The variadic input values are accepted for interface compatibility,
+but are ignored as GSER values are never wrapped.
+*/
+func (r *GSERPacket) Dump(w io.Writer, _ ...int) error {
+	return dumpGSER(w, string(r.data))
+}
+
+/*
+Len returns the integer length of the underlying byte buffer within
+the receiver instance.
+*/
+func (r GSERPacket) Len() int { return textPacket(r).Len() }
+
+/*
+HasMoreData returns a Boolean value indicative of whether there are more bytes remaining
+to be processed.
+*/
+func (r GSERPacket) HasMoreData() bool { return textPacket(r).HasMoreData() }
+
+/*
+Data returns the underlying byte slice.
+*/
+func (r *GSERPacket) Data() []byte { return (*textPacket)(r).Data() }
+
+/*
+Append appends data to the receiver instance.
+*/
+func (r *GSERPacket) Append(data ...byte) { (*textPacket)(r).Append(data...) }
+
+/*
+Offset returns the current offset position index of the underlying value within the receiver
+instance.
+*/
+func (r *GSERPacket) Offset() int { return (*textPacket)(r).Offset() }
+
+/*
+SetOffset replaces the current offset position index of the underlying value within the receiver
+instance with a user-supplied value.
+
+Supplying an integer of negative one (-1) will set the offset to the final character in the
+underlying buffer if non-zero in length.
+
+If no variadic input is provided, the offset position index is set to zero (0).
+*/
+func (r *GSERPacket) SetOffset(offset ...int) { r.offset = setPacketOffset(r, offset...) }
+
+/*
+AddOffset increments or decrements the current offset according to n. Though
+negative input is permitted, the product of offset + n must not be negative
+itself, nor may it exceed the receiver's buffer length.
+*/
+func (r *GSERPacket) AddOffset(n int) { r.offset = incPacketOffset(r, n) }
+
+/*
+Free frees the receiver instance.
+*/
+func (r *GSERPacket) Free() { (*textPacket)(r).Free() }
+
+func (r *GSERPacket) reset() { (*textPacket)(r).reset() }
+
+/*
+PeekTLV returns an empty [TLV] alongside an error, as GSER does not implement
+the tag/length/value model.
+*/
+func (r *GSERPacket) PeekTLV() (TLV, error) { return (*textPacket)(r).PeekTLV() }
+
+/*
+TLV returns an empty [TLV] alongside an error, as GSER does not implement
+the tag/length/value model.
+*/
+func (r *GSERPacket) TLV() (TLV, error) { return (*textPacket)(r).TLV() }
+
+/*
+WriteTLV returns an error, as GSER does not implement the tag/length/value
+model.
+*/
+func (r *GSERPacket) WriteTLV(tlv TLV) error { return (*textPacket)(r).WriteTLV(tlv) }
+
+func newGSERPacket(src ...byte) PDU {
+	r := GSERPacket(newTextPacket(src...))
+	return &r
+}
+
+/*
+marshalGSER returns an error following an attempt to write v into pkt
+as a GSER value.
+*/
+func marshalGSER(v reflect.Value, pkt PDU, opts *Options) (err error) {
+	debugEnter(v, opts, pkt)
+	defer func() { debugExit(newLItem(err)) }()
+
+	bld := newStrBuilder()
+	if err = gserWriteValue(&bld, v, opts); err == nil {
+		pkt.Append([]byte(bld.String())...)
+	}
+
+	return
+}
+
+/*
+gserWriteValue writes v into bld as a GSER value.
+*/
+func gserWriteValue(bld *strings.Builder, v reflect.Value, opts *Options) (err error) {
+	if !v.IsValid() {
+		err = errorNilValue
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			err = errorNilValue
+			return
+		}
+	}
+
+	if v.CanInterface() {
+		if ch, ok := v.Interface().(Choice); ok {
+			err = gserWriteChoice(bld, ch)
+			return
+		}
+	}
+
+	if k := v.Kind(); k == reflect.Ptr || k == reflect.Interface {
+		err = gserWriteValue(bld, v.Elem(), opts)
+		return
+	}
+
+	var prim Primitive
+	if prim, err = textPrimitive(v, opts); err != nil || prim != nil {
+		if err == nil {
+			gserWritePrimitive(bld, prim)
+		}
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		var n int
+		bld.WriteByte('{')
+		err = textEachField(v, opts, func(name string, fv reflect.Value, fOpts *Options) error {
+			if n++; n > 1 {
+				bld.WriteByte(',')
+			}
+			bld.WriteString(" " + gserIdentifier(name) + " ")
+			return gserWriteValue(bld, fv, fOpts)
+		})
+		if err == nil {
+			bld.WriteString(" }")
+		}
+	case reflect.Slice, reflect.Array:
+		bld.WriteByte('{')
+		for i := 0; i < v.Len() && err == nil; i++ {
+			if i > 0 {
+				bld.WriteByte(',')
+			}
+			bld.WriteByte(' ')
+			err = gserWriteValue(bld, v.Index(i), nil)
+		}
+		if err == nil {
+			bld.WriteString(" }")
+		}
+	default:
+		err = compositeErrorf("GSER: unsupported type ", v.Kind().String())
+	}
+
+	return
+}
+
+/*
+gserWriteChoice writes the selected alternative of ch into bld using the
+"identifier:value" syntax, where identifier is derived from the type name
+of the alternative.
+*/
+func gserWriteChoice(bld *strings.Builder, ch Choice) (err error) {
+	alt := refValueOf(ch.Value())
+	if !alt.IsValid() {
+		err = errorNilValue
+		return
+	}
+
+	bld.WriteString(gserIdentifier(textTypeNameOf(derefTypePtr(alt.Type()))) + ":")
+	err = gserWriteValue(bld, alt, nil)
+
+	return
+}
+
+/*
+gserWritePrimitive writes prim into bld as a GSER value.
+*/
+func gserWritePrimitive(bld *strings.Builder, prim Primitive) {
+	s := prim.String()
+
+	switch prim.Tag() {
+	case TagBoolean:
+		bld.WriteString(uc(s))
+	case TagNull:
+		bld.WriteString("NULL")
+	case TagInteger, TagEnum, TagOID, TagRelativeOID, TagBitString:
+		bld.WriteString(s)
+	case TagReal:
+		bld.WriteString(gserRealString(prim))
+	case TagOctetString:
+		bld.WriteString("'" + uc(hexstr([]byte(s))) + "'H")
+	default:
+		bld.WriteString(`"` + replaceAll(s, `"`, `""`) + `"`)
+	}
+}
+
+/*
+gserRealString returns the GSER representation of REAL prim.
+*/
+func gserRealString(prim Primitive) (s string) {
+	var f float64
+	if r, ok := prim.(Real); ok {
+		f = r.Float()
+	}
+
+	switch {
+	case math.IsInf(f, 1):
+		s = "PLUS-INFINITY"
+	case math.IsInf(f, -1):
+		s = "MINUS-INFINITY"
+	default:
+		s = fmtFloat(f, 'g', -1, 64)
+	}
+
+	return
+}
+
+/*
+gserIdentifier returns name as a valid ASN.1 identifier, which must begin
+with a lowercase letter. Upper snake case names (e.g.: "OBJECT_IDENTIFIER")
+are converted to lower camel case (e.g.: "objectIdentifier").
+*/
+func gserIdentifier(name string) string {
+	if name == "" {
+		return name
+	}
+
+	if uc(name) == name {
+		words := split(lc(name), "_")
+		for i := 1; i < len(words); i++ {
+			if words[i] != "" {
+				words[i] = uc(words[i][:1]) + words[i][1:]
+			}
+		}
+		return join(words, "")
+	}
+
+	return lc(name[:1]) + name[1:]
+}
+
+/*
+dumpGSER writes the GSER value doc into w, placing each component upon
+its own line and indenting nested components by two spaces per level.
+*/
+func dumpGSER(w io.Writer, doc string) (err error) {
+	bld := newStrBuilder()
+	var depth int
+	var quoted bool
+
+	newline := func() {
+		bld.WriteByte('\n')
+		bld.WriteString(strrpt("  ", depth))
+	}
+
+	for i := 0; i < len(doc); i++ {
+		c := doc[i]
+		switch {
+		case c == '"':
+			quoted = !quoted
+			bld.WriteByte(c)
+		case quoted:
+			bld.WriteByte(c)
+		case c == '{':
+			if i+2 < len(doc) && doc[i+1:i+3] == " }" {
+				bld.WriteString("{ }")
+				i += 2
+				continue
+			}
+			bld.WriteByte(c)
+			depth++
+			newline()
+			i++ // skip the following space
+		case c == ',':
+			bld.WriteByte(c)
+			newline()
+			i++ // skip the following space
+		case c == ' ' && i+1 < len(doc) && doc[i+1] == '}':
+			depth--
+			newline()
+		default:
+			bld.WriteByte(c)
+		}
+	}
+	bld.WriteByte('\n')
+
+	_, err = w.Write([]byte(bld.String()))
+	return
+}
+
+func init() {
+	activeEncodingRules |= GSER
+	pDUConstructors[GSER] = newGSERPacket
+	textMarshalers[GSER] = marshalGSER
+}
@@ -0,0 +1,105 @@
+//go:build !asn1_no_gser
+
+package asn1plus
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+func ExampleGSERPacket() {
+	type MySequence struct {
+		Name PrintableString
+		Age  Integer
+	}
+
+	nint, _ := NewInteger(48)
+	mine := MySequence{PrintableString("Jesse"), nint}
+
+	pkt, err := Marshal(mine, With(GSER))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(pkt.Hex())
+	// Output: { name "Jesse", age 48 }
+}
+
+func ExampleGSERPacket_Dump() {
+	abs, _ := NewObjectIdentifier(1, 3, 6, 1, 4, 1, 56521, 101, 1)
+	xfr, _ := NewObjectIdentifier(2, 1, 2, 1)
+
+	pdv := EmbeddedPDV{
+		Identification: NewChoice(Syntaxes{abs, xfr}, 0),
+		DataValue:      OctetString("hello"),
+	}
+
+	pkt, err := Marshal(pdv, With(GSER))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if err = pkt.Dump(os.Stdout); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// {
+	//   identification syntaxes:{
+	//     abstract 1.3.6.1.4.1.56521.101.1,
+	//     transfer 2.1.2.1
+	//   },
+	//   dataValue '68656C6C6F'H
+	// }
+}
+
+func TestGSER_codecov(t *testing.T) {
+	type Inner struct {
+		Flag Boolean
+		Note UTF8String `asn1:"name:remark"`
+	}
+
+	type Outer struct {
+		Inner Inner
+		Items []Enumerated
+		Pick  Choice
+		Inf   Real
+		Gone  *Integer `asn1:"optional"`
+		Blank Null
+	}
+
+	oid, _ := NewObjectIdentifier(1, 3, 6, 1)
+	outer := Outer{
+		Inner: Inner{Flag: true, Note: UTF8String(`say "hi"`)},
+		Items: []Enumerated{1, 2},
+		Pick:  NewChoice(oid),
+		Inf:   NewRealPlusInfinity(),
+	}
+
+	pkt, err := Marshal(outer, With(GSER))
+	if err != nil {
+		t.Fatalf("%s failed [GSER marshal]: %v", t.Name(), err)
+	}
+
+	want := `{ inner { flag TRUE, remark "say ""hi""" }, items { 1, 2 }, ` +
+		`pick objectIdentifier:1.3.6.1, inf PLUS-INFINITY, blank NULL }`
+	if got := pkt.Hex(); got != want {
+		t.Fatalf("%s failed [GSER output]:\n\twant: %s\n\tgot:  %s", t.Name(), want, got)
+	}
+
+	if err = Unmarshal(pkt, &outer); err == nil {
+		t.Fatalf("%s failed: expected unmarshal error, got nil", t.Name())
+	}
+
+	if pkt.Type() != GSER || pkt.Type().String() != `GSER` {
+		t.Fatalf("%s failed [GSER identity]", t.Name())
+	}
+	if _, err = pkt.TLV(); err == nil {
+		t.Fatalf("%s failed: expected TLV error, got nil", t.Name())
+	}
+	if err = Walk(pkt, nil); err != errorTextualPDU {
+		t.Fatalf("%s failed: want %v, got %v", t.Name(), errorTextualPDU, err)
+	}
+}
@@ -138,7 +138,7 @@ func init() {
 		return
 	}
 
-	RegisterTextAlias[IA5String](TagIA5String,
-		IA5StringConstraintPhase,
+	registerTextAlias[IA5String](TagIA5String,
+		func() int { return IA5StringConstraintPhase },
 		nil, nil, nil, IA5Spec)
 }
@@ -108,9 +108,48 @@ ASN.1 primitive.
 func (_ Integer) IsPrimitive() bool { return true }
 
 /*
-IsZero returns a Boolean value indicative of a nil receiver state.
+IsZero returns a Boolean value indicative of the receiver instance
+representing zero (0), as is the case with a zero [Integer].
 */
-func (r Integer) IsZero() bool { return &r == nil }
+func (r Integer) IsZero() bool { return r.Sign() == 0 }
+
+/*
+Sign returns -1, 0 or +1, depending on whether the receiver instance
+is negative, zero (0) or positive respectively.
+*/
+func (r Integer) Sign() (sign int) {
+	if r.big {
+		sign = r.bigInt.Sign()
+	} else if r.native < 0 {
+		sign = -1
+	} else if r.native > 0 {
+		sign = 1
+	}
+
+	return
+}
+
+/*
+Abs returns the absolute value of the receiver instance as a new
+instance of [Integer]. The receiver is not modified.
+
+Note that the absolute value of [math.MinInt64] overflows int64, and
+is thus returned in *[big.Int] form.
+*/
+func (r Integer) Abs() (i Integer) {
+	switch {
+	case r.big:
+		i = bigToInteger(newBigInt(0).Abs(r.bigInt))
+	case r.native == math.MinInt64:
+		i = bigToInteger(newBigInt(0).Neg(newBigInt(r.native)))
+	case r.native < 0:
+		i = Integer{native: -r.native}
+	default:
+		i = r
+	}
+
+	return
+}
 
 /*
 String returns the string representation of the receiver instance.
@@ -210,6 +249,62 @@ Le returns a bool indicative of r being less than or equal to x.
 */
 func (r Integer) Le(x any) bool { return r.cmpAny(x) <= 0 }
 
+/*
+Add returns a new instance of [Integer] bearing the sum of the receiver
+instance and o. The result is promoted to *[big.Int] form if it would
+overflow int64, and is otherwise held in native form. Neither operand is
+modified.
+*/
+func (r Integer) Add(o Integer) (i Integer) {
+	if !r.big && !o.big {
+		// overflow occurs only if both operands share a sign
+		// which differs from that of the sum.
+		if sum := r.native + o.native; (r.native^sum)&(o.native^sum) >= 0 {
+			return Integer{native: sum}
+		}
+	}
+
+	return bigToInteger(newBigInt(0).Add(r.Big(), o.Big()))
+}
+
+/*
+Sub returns a new instance of [Integer] bearing the difference of the
+receiver instance and o. The result is promoted to *[big.Int] form if it
+would overflow int64, and is otherwise held in native form. Neither
+operand is modified.
+*/
+func (r Integer) Sub(o Integer) (i Integer) {
+	if !r.big && !o.big {
+		// overflow occurs only if the operands differ in sign
+		// and the sign of the difference differs from r.
+		if diff := r.native - o.native; (r.native^o.native)&(r.native^diff) >= 0 {
+			return Integer{native: diff}
+		}
+	}
+
+	return bigToInteger(newBigInt(0).Sub(r.Big(), o.Big()))
+}
+
+/*
+Mul returns a new instance of [Integer] bearing the product of the
+receiver instance and o. The result is promoted to *[big.Int] form if it
+would overflow int64, and is otherwise held in native form. Neither
+operand is modified.
+*/
+func (r Integer) Mul(o Integer) (i Integer) {
+	if !r.big && !o.big {
+		a, b := r.native, o.native
+		if a == 0 || b == 0 {
+			return
+		} else if prod := a * b; prod/b == a && !(a == -1 && b == math.MinInt64) &&
+			!(b == -1 && a == math.MinInt64) {
+			return Integer{native: prod}
+		}
+	}
+
+	return bigToInteger(newBigInt(0).Mul(r.Big(), o.Big()))
+}
+
 func (r Integer) cmpAny(x any) (result int) {
 	switch t := x.(type) {
 	case Integer:
@@ -345,15 +440,20 @@ func bEToInteger(b []byte) (i Integer) {
 func strToInteger(num string) (i Integer, err error) {
 	if _i, ok := newBigInt(0).SetString(num, 10); !ok {
 		err = primitiveErrorf("INTEGER: invalid string value ", num)
-	} else if _i.IsInt64() {
-		i = Integer{native: _i.Int64()}
 	} else {
-		i = Integer{big: true, bigInt: _i}
+		i = bigToInteger(_i)
 	}
 
 	return
 }
 
+/*
+bigToInteger returns num as a normalized instance of [Integer]: values
+which fit within int64 are held in native form, while all others retain
+num as their *[big.Int] form. This is the normalization step applied to
+all values which may have originated as *[big.Int], such as the results
+of arithmetic, lest small values be needlessly big-backed.
+*/
 func bigToInteger(num *big.Int) (i Integer) {
 	if i.big = !num.IsInt64(); i.big {
 		i.bigInt = num
@@ -526,7 +626,7 @@ func bcdIntegerWrite[T any](c *integerCodec[T], pkt PDU, o *Options) (off int, e
 
 	intVal := toInt(c.val)
 
-	cc := c.cg.phase(c.cphase, CodecConstraintEncoding)
+	cc := c.cg.phase(o.constraintPhase(c.cphase), CodecConstraintEncoding)
 	if err = cc(intVal); err == nil {
 		var wire []byte
 		if c.encodeHook != nil {
@@ -570,6 +670,9 @@ func bcdIntegerRead[T any](c *integerCodec[T], pkt PDU, tlv TLV, o *Options) err
 	o = deferImplicit(o)
 
 	wire, err := primitiveCheckRead(c.tag, pkt, tlv, o)
+	if err == nil && c.decodeHook == nil {
+		err = integerMinimalCheck(pkt.Type(), wire)
+	}
 	if err == nil {
 
 		decodeVerify := func() (err error) {
@@ -587,16 +690,11 @@ func bcdIntegerRead[T any](c *integerCodec[T], pkt PDU, tlv TLV, o *Options) err
 				t, err = c.decodeHook(wire)
 				out = toInt(t)
 			} else {
-				bi := decodeIntegerContent(wire)
-				if bi.IsInt64() {
-					out = Integer{native: bi.Int64()}
-				} else {
-					out = Integer{big: true, bigInt: bi}
-				}
+				out = bigToInteger(decodeIntegerContent(wire))
 			}
 
 			if err == nil {
-				cc := c.cg.phase(c.cphase, CodecConstraintDecoding)
+				cc := c.cg.phase(o.constraintPhase(c.cphase), CodecConstraintDecoding)
 				if err = cc(out); err == nil {
 					c.val = fromInt[T](out)
 					pkt.AddOffset(tlv.Length)
@@ -608,6 +706,22 @@ func bcdIntegerRead[T any](c *integerCodec[T], pkt PDU, tlv TLV, o *Options) err
 	return err
 }
 
+/*
+integerMinimalCheck returns an error if rule is [CER] or [DER] and the
+INTEGER (or ENUMERATED) content octets within wire are not minimal, i.e.:
+the first nine (9) bits are all zeros or all ones, per § 8.3.2 of ITU-T
+Rec. X.690. [BER] decoders accept such encodings as-is.
+*/
+func integerMinimalCheck(rule EncodingRule, wire []byte) (err error) {
+	if rule.In(CER, DER) && len(wire) > 1 &&
+		((wire[0] == 0x00 && wire[1]&0x80 == 0) ||
+			(wire[0] == 0xFF && wire[1]&0x80 != 0)) {
+		err = primitiveErrorf("INTEGER: ", rule, " requires minimal content octets")
+	}
+
+	return
+}
+
 func RegisterIntegerAlias[T any](
 	tag int,
 	cphase int,
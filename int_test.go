@@ -2,6 +2,7 @@ package asn1plus
 
 import (
 	"fmt"
+	"math"
 	"math/big"
 	"testing"
 )
@@ -380,3 +381,170 @@ func BenchmarkIntegerConstructor(b *testing.B) {
 		}
 	}
 }
+
+func TestInteger_SignIsZeroAbs(t *testing.T) {
+	huge, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	hugeAbs, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	minAbs := new(big.Int).Neg(big.NewInt(math.MinInt64))
+
+	for idx, tc := range []struct {
+		in   any
+		sign int
+		abs  *big.Int
+		big  bool // expected IsBig state of Abs
+	}{
+		{0, 0, big.NewInt(0), false},
+		{42, 1, big.NewInt(42), false},
+		{-42, -1, big.NewInt(42), false},
+		{int64(math.MaxInt64), 1, big.NewInt(math.MaxInt64), false},
+		{int64(math.MinInt64), -1, minAbs, true},
+		{hugeAbs, 1, hugeAbs, true},
+		{huge, -1, hugeAbs, true},
+	} {
+		i, err := NewInteger(tc.in)
+		if err != nil {
+			t.Fatalf("%s[%d] failed: %v", t.Name(), idx, err)
+		}
+
+		if got := i.Sign(); got != tc.sign {
+			t.Fatalf("%s[%d] failed [Sign]: want %d, got %d", t.Name(), idx, tc.sign, got)
+		} else if i.IsZero() != (tc.sign == 0) {
+			t.Fatalf("%s[%d] failed [IsZero]: want %t, got %t", t.Name(), idx, tc.sign == 0, i.IsZero())
+		}
+
+		abs := i.Abs()
+		if abs.Big().Cmp(tc.abs) != 0 || abs.IsBig() != tc.big {
+			t.Fatalf("%s[%d] failed [Abs]: want %s (big:%t), got %s (big:%t)",
+				t.Name(), idx, tc.abs, tc.big, abs, abs.IsBig())
+		} else if i.Sign() < 0 && i.Sign() == abs.Sign() {
+			t.Fatalf("%s[%d] failed [Abs]: receiver was modified", t.Name(), idx)
+		}
+	}
+
+	// the zero value is zero
+	var zero Integer
+	if !zero.IsZero() || zero.Sign() != 0 || !zero.Abs().IsZero() {
+		t.Fatalf("%s failed: zero Integer not recognized as such", t.Name())
+	}
+
+	// a big receiver is not modified by Abs
+	i, _ := NewInteger(huge)
+	i.Abs()
+	if i.Big().Cmp(huge) != 0 {
+		t.Fatalf("%s failed: Abs modified big receiver: %s", t.Name(), i)
+	}
+}
+
+func TestInteger_Arithmetic(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	var operands []Integer
+	for _, x := range []any{
+		0, 1, -1, 7, -7, 3037000500, -3037000500,
+		int64(math.MaxInt64), int64(math.MinInt64),
+		huge, new(big.Int).Neg(huge),
+	} {
+		i, err := NewInteger(x)
+		if err != nil {
+			t.Fatalf("%s failed: %v", t.Name(), err)
+		}
+		operands = append(operands, i)
+	}
+
+	for _, op := range []struct {
+		name string
+		fn   func(Integer, Integer) Integer
+		ref  func(z, x, y *big.Int) *big.Int
+	}{
+		{"Add", Integer.Add, (*big.Int).Add},
+		{"Sub", Integer.Sub, (*big.Int).Sub},
+		{"Mul", Integer.Mul, (*big.Int).Mul},
+	} {
+		for _, a := range operands {
+			for _, b := range operands {
+				aStr, bStr := a.String(), b.String()
+				want := op.ref(new(big.Int), a.Big(), b.Big())
+				got := op.fn(a, b)
+				if got.Big().Cmp(want) != 0 {
+					t.Fatalf("%s failed [%s(%s, %s)]: want %s, got %s",
+						t.Name(), op.name, a, b, want, got)
+				} else if got.IsBig() == want.IsInt64() {
+					t.Fatalf("%s failed [%s(%s, %s)]: want big:%t, got big:%t",
+						t.Name(), op.name, a, b, !want.IsInt64(), got.IsBig())
+				} else if a.String() != aStr || b.String() != bStr {
+					t.Fatalf("%s failed [%s(%s, %s)]: operand was modified",
+						t.Name(), op.name, aStr, bStr)
+				}
+			}
+		}
+	}
+
+	// e.g.: deriving the next sibling arc of an OID
+	arc, _ := NewInteger(int64(math.MaxInt64))
+	next := arc.Add(MustNewInteger(1))
+	if !next.IsBig() || next.String() != "9223372036854775808" {
+		t.Fatalf("%s failed: expected big promotion, got %s (big:%t)", t.Name(), next, next.IsBig())
+	} else if back := next.Sub(MustNewInteger(1)); back.IsBig() || back.Native() != math.MaxInt64 {
+		t.Fatalf("%s failed: expected native demotion, got %s (big:%t)", t.Name(), back, back.IsBig())
+	}
+}
+
+func TestInteger_ArithmeticNormalization(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	hugeInt, _ := NewInteger(huge)
+	two := MustNewInteger(2)
+
+	for idx, tc := range []struct {
+		got  Integer
+		want int64
+	}{
+		{two.Add(two), 4},
+		{two.Mul(two), 4},
+		{two.Sub(two), 0},
+		{hugeInt.Sub(hugeInt), 0},
+		{hugeInt.Add(two).Sub(hugeInt), 2},
+		{hugeInt.Mul(two).Sub(hugeInt).Sub(hugeInt), 0},
+		{Integer{big: true, bigInt: big.NewInt(4)}.Add(Integer{}), 4}, // unnormalized operand
+	} {
+		if tc.got.IsBig() || tc.got.Native() != tc.want {
+			t.Fatalf("%s[%d] failed: want native %d, got %s (big:%t)",
+				t.Name(), idx, tc.want, tc.got, tc.got.IsBig())
+		}
+	}
+}
+
+func TestInteger_MinimalEncoding(t *testing.T) {
+	for _, tc := range []struct {
+		rule  EncodingRule
+		input []byte
+		want  int64
+		ok    bool
+	}{
+		{BER, []byte{0x02, 0x02, 0x00, 0x7F}, 127, true},
+		{BER, []byte{0x02, 0x02, 0xFF, 0x80}, -128, true},
+		{CER, []byte{0x02, 0x02, 0x00, 0x7F}, 0, false},
+		{DER, []byte{0x02, 0x02, 0x00, 0x7F}, 0, false},
+		{DER, []byte{0x02, 0x02, 0xFF, 0x80}, 0, false},
+		{DER, []byte{0x02, 0x02, 0x00, 0x80}, 128, true},
+		{DER, []byte{0x02, 0x02, 0xFF, 0x7F}, -129, true},
+		{DER, []byte{0x02, 0x01, 0x00}, 0, true},
+	} {
+		if !tc.rule.Enabled() {
+			continue
+		}
+
+		var i Integer
+		err := Unmarshal(tc.rule.New(tc.input...), &i)
+		if tc.ok {
+			if err != nil {
+				t.Errorf("%s failed [%s %X]: %v", t.Name(), tc.rule, tc.input, err)
+			} else if i.Native() != tc.want {
+				t.Errorf("%s failed [%s %X]:\n\twant: %d\n\tgot:  %s",
+					t.Name(), tc.rule, tc.input, tc.want, i)
+			}
+		} else if err == nil {
+			t.Errorf("%s failed [%s %X]: expected error, got %s",
+				t.Name(), tc.rule, tc.input, i)
+		}
+	}
+}
@@ -0,0 +1,898 @@
+//go:build !asn1_no_jer
+
+package asn1plus
+
+/*
+jer.go contains JER-focused components.
+*/
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+)
+
+/*
+JERPacket encapsulates an [ITU-T Rec. X.697] JER-encoded (JSON) byte
+slice and an offset.
+
+Unlike [BERPacket] and its descendants, instances of this type contain
+character-based content and, as such, do not implement the tag/length/value
+model. Methods which rely upon TLV parsing will return an error.
+
+[ITU-T Rec. X.697]: https://www.itu.int/rec/T-REC-X.697
+*/
+type JERPacket textPacket
+
+/*
+Type returns [JER], identifying the receiver as an ASN.1 JSON Encoding
+Rules [PDU] qualifier.
+*/
+func (r JERPacket) Type() EncodingRule { return JER }
+
+/*
+ID returns the unique string identifier associated with the receiver instance.
+
+Note that if this package is not compiled or run with "-tags asn1_debug", this
+method will always return a zero string.
+*/
+func (r JERPacket) ID() string { return textPacket(r).ID() }
+
+/*
+Class returns -1 alongside an error, as JER does not implement ASN.1 class
+identifiers in its encoding.
+*/
+func (r JERPacket) Class() (int, error) { return textPacket(r).Class() }
+
+/*
+Tag returns -1 alongside an error, as JER does not implement ASN.1 tag
+identifiers in its encoding.
+*/
+func (r JERPacket) Tag() (int, error) { return textPacket(r).Tag() }
+
+/*
+Compound returns false alongside an error, as JER does not implement ASN.1
+compound identifiers in its encoding.
+*/
+func (r JERPacket) Compound() (bool, error) { return textPacket(r).Compound() }
+
+/*
+Bytes returns the JSON content of the underlying buffer, from the current
+offset onward, alongside an error.
+*/
+func (r JERPacket) Bytes() ([]byte, error) { return textPacket(r).Bytes() }
+
+/*
+FullBytes returns the complete JSON content of the underlying buffer
+alongside an error.
+*/
+func (r JERPacket) FullBytes() ([]byte, error) { return textPacket(r).FullBytes() }
+
+/*
+Hex returns the JSON text within the receiver instance. Unlike binary
+encoding rules, there is no need to represent JER content as hexadecimal.
+*/
+func (r JERPacket) Hex() string { return textPacket(r).Hex() }
+
+/*
+Dump returns an error following an attempt to write the receiver
+instance into w as indented JSON.
+
+The variadic input values are accepted for interface compatibility,
+but are ignored as JSON values are never wrapped.
+*/
+func (r *JERPacket) Dump(w io.Writer, _ ...int) (err error) {
+	var out bytes.Buffer
+	if err = json.Indent(&out, r.data, "", "  "); err == nil {
+		out.WriteByte('\n')
+		_, err = w.Write(out.Bytes())
+	}
+
+	return
+}
+
+/*
+Len returns the integer length of the underlying byte buffer within
+the receiver instance.
+*/
+func (r JERPacket) Len() int { return textPacket(r).Len() }
+
+/*
+HasMoreData returns a Boolean value indicative of whether there are more bytes remaining
+to be processed.
+*/
+func (r JERPacket) HasMoreData() bool { return textPacket(r).HasMoreData() }
+
+/*
+Data returns the underlying byte slice.
+*/
+func (r *JERPacket) Data() []byte { return (*textPacket)(r).Data() }
+
+/*
+Append appends data to the receiver instance.
+*/
+func (r *JERPacket) Append(data ...byte) { (*textPacket)(r).Append(data...) }
+
+/*
+Offset returns the current offset position index of the underlying value within the receiver
+instance.
+*/
+func (r *JERPacket) Offset() int { return (*textPacket)(r).Offset() }
+
+/*
+SetOffset replaces the current offset position index of the underlying value within the receiver
+instance with a user-supplied value.
+
+Supplying an integer of negative one (-1) will set the offset to the final character in the
+underlying buffer if non-zero in length.
+
+If no variadic input is provided, the offset position index is set to zero (0).
+*/
+func (r *JERPacket) SetOffset(offset ...int) { r.offset = setPacketOffset(r, offset...) }
+
+/*
+AddOffset increments or decrements the current offset according to n. Though
+negative input is permitted, the product of offset + n must not be negative
+itself, nor may it exceed the receiver's buffer length.
+*/
+func (r *JERPacket) AddOffset(n int) { r.offset = incPacketOffset(r, n) }
+
+/*
+Free frees the receiver instance.
+*/
+func (r *JERPacket) Free() { (*textPacket)(r).Free() }
+
+func (r *JERPacket) reset() { (*textPacket)(r).reset() }
+
+/*
+PeekTLV returns an empty [TLV] alongside an error, as JER does not implement
+the tag/length/value model.
+*/
+func (r *JERPacket) PeekTLV() (TLV, error) { return (*textPacket)(r).PeekTLV() }
+
+/*
+TLV returns an empty [TLV] alongside an error, as JER does not implement
+the tag/length/value model.
+*/
+func (r *JERPacket) TLV() (TLV, error) { return (*textPacket)(r).TLV() }
+
+/*
+WriteTLV returns an error, as JER does not implement the tag/length/value
+model.
+*/
+func (r *JERPacket) WriteTLV(tlv TLV) error { return (*textPacket)(r).WriteTLV(tlv) }
+
+func newJERPacket(src ...byte) PDU {
+	r := JERPacket(newTextPacket(src...))
+	return &r
+}
+
+/*
+jerMaxSafeInteger is the largest magnitude an INTEGER may bear before it
+is encoded as a JSON string rather than a JSON number, as many consumers
+parse JSON numbers as IEEE 754 doubles.
+*/
+const jerMaxSafeInteger = 1 << 53
+
+/*
+marshalJER returns an error following an attempt to write v into pkt
+as a JSON value.
+*/
+func marshalJER(v reflect.Value, pkt PDU, opts *Options) (err error) {
+	debugEnter(v, opts, pkt)
+	defer func() { debugExit(newLItem(err)) }()
+
+	bld := newStrBuilder()
+	if err = jerWriteValue(&bld, v, opts); err == nil {
+		pkt.Append([]byte(bld.String())...)
+	}
+
+	return
+}
+
+/*
+jerWriteValue writes v into bld as a JSON value.
+*/
+func jerWriteValue(bld *strings.Builder, v reflect.Value, opts *Options) (err error) {
+	if !v.IsValid() {
+		err = errorNilValue
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			err = errorNilValue
+			return
+		}
+	}
+
+	if v.CanInterface() {
+		if ch, ok := v.Interface().(Choice); ok {
+			err = jerWriteChoice(bld, ch, opts)
+			return
+		}
+	}
+
+	if k := v.Kind(); k == reflect.Ptr || k == reflect.Interface {
+		err = jerWriteValue(bld, v.Elem(), opts)
+		return
+	}
+
+	var prim Primitive
+	if prim, err = textPrimitive(v, opts); err != nil || prim != nil {
+		if err == nil {
+			jerWritePrimitive(bld, prim)
+		}
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		bld.WriteByte('{')
+		if err = jerWriteFields(bld, v, opts); err == nil {
+			bld.WriteByte('}')
+		}
+	case reflect.Slice, reflect.Array:
+		bld.WriteByte('[')
+		for i := 0; i < v.Len() && err == nil; i++ {
+			if i > 0 {
+				bld.WriteByte(',')
+			}
+			err = jerWriteValue(bld, v.Index(i), nil)
+		}
+		if err == nil {
+			bld.WriteByte(']')
+		}
+	default:
+		err = compositeErrorf("JER: unsupported type ", v.Kind().String())
+	}
+
+	return
+}
+
+/*
+jerWriteFields writes each exported field of struct v into bld as JSON
+object members keyed by field identifier.
+*/
+func jerWriteFields(bld *strings.Builder, v reflect.Value, opts *Options) error {
+	var n int
+	return textEachField(v, opts, func(name string, fv reflect.Value, fOpts *Options) error {
+		if n++; n > 1 {
+			bld.WriteByte(',')
+		}
+		bld.WriteString(jerQuote(name) + ":")
+		return jerWriteValue(bld, fv, fOpts)
+	})
+}
+
+/*
+jerWriteChoice writes the selected alternative of ch into bld as a JSON
+object bearing a single member keyed by the alternative's type name.
+*/
+func jerWriteChoice(bld *strings.Builder, ch Choice, opts *Options) (err error) {
+	alt := refValueOf(ch.Value())
+	if !alt.IsValid() {
+		err = errorNilValue
+		return
+	}
+
+	var key string
+	if key, err = jerChoiceKey(ch, derefTypePtr(alt.Type()), opts); err != nil {
+		return
+	}
+
+	bld.WriteString("{" + jerQuote(key) + ":")
+	if err = jerWriteValue(bld, alt, nil); err == nil {
+		bld.WriteByte('}')
+	}
+
+	return
+}
+
+/*
+jerChoiceKey returns the JSON member name for the selected alternative of
+ch, whose value is of type typ, per the [Choices] named within opts. When
+no such [Choices] are declared, the textual name of typ is used.
+*/
+func jerChoiceKey(ch Choice, typ reflect.Type, opts *Options) (key string, err error) {
+	key = textTypeNameOf(typ)
+	if !optsHasChoices(opts) {
+		return
+	}
+
+	reg, found := GetChoices(opts.Choices)
+	if !found {
+		return
+	}
+
+	for _, cd := range reg.reg {
+		tag := ch.Tag()
+		if tag < 0 {
+			if tag, found = cd.typeToTag[typ]; !found {
+				continue
+			}
+		} else if _, found = cd.tagToType[tag]; !found {
+			continue
+		}
+		key, err = jerAlternativeKey(cd, tag)
+		break
+	}
+
+	return
+}
+
+/*
+jerAlternativeKey returns the JSON member name of the alternative within
+cd bearing tag. Per ITU-T Rec. X.697, this is the identifier declared upon
+registration. Failing that, the textual name of the alternative's type is
+used, unless another unnamed alternative shares that name, in which case an
+error is returned.
+*/
+func jerAlternativeKey(cd *choiceDescriptor, tag int) (key string, err error) {
+	if key = cd.names[tag]; key != "" {
+		return
+	}
+
+	key = textTypeNameOf(cd.tagToType[tag])
+	for t, typ := range cd.tagToType {
+		if t != tag && cd.names[t] == "" && textTypeNameOf(typ) == key {
+			err = choiceErrorf("JER: alternatives of type ", key,
+				" require identifiers")
+			break
+		}
+	}
+
+	return
+}
+
+/*
+jerWritePrimitive writes prim into bld as a JSON value.
+*/
+func jerWritePrimitive(bld *strings.Builder, prim Primitive) {
+	s := prim.String()
+
+	switch prim.Tag() {
+	case TagBoolean:
+		bld.WriteString(s)
+	case TagNull:
+		bld.WriteString("null")
+	case TagInteger, TagEnum:
+		if n, err := pint(s, 10, 64); err == nil && -jerMaxSafeInteger <= n && n <= jerMaxSafeInteger {
+			bld.WriteString(s)
+		} else {
+			bld.WriteString(jerQuote(s))
+		}
+	case TagReal:
+		bld.WriteString(jerRealString(prim))
+	case TagOctetString:
+		bld.WriteString(jerQuote(uc(hexstr([]byte(s)))))
+	case TagBitString:
+		bs, _ := prim.(BitString)
+		bld.WriteString(`{"value":` + jerQuote(uc(hexstr(bs.Bytes))) +
+			`,"length":` + itoa(bs.BitLength) + `}`)
+	default:
+		bld.WriteString(jerQuote(s))
+	}
+}
+
+/*
+jerRealString returns the JSON representation of REAL prim. Special
+values, which JSON numbers cannot represent, are returned as strings.
+*/
+func jerRealString(prim Primitive) (s string) {
+	var f float64
+	if r, ok := prim.(Real); ok {
+		f = r.Float()
+	}
+
+	switch {
+	case math.IsInf(f, 1):
+		s = `"INF"`
+	case math.IsInf(f, -1):
+		s = `"-INF"`
+	case math.IsNaN(f):
+		s = `"NaN"`
+	default:
+		s = fmtFloat(f, 'g', -1, 64)
+	}
+
+	return
+}
+
+/*
+jerQuote returns s as a quoted JSON string.
+*/
+func jerQuote(s string) string {
+	bld := newStrBuilder()
+	bld.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			bld.WriteByte('\\')
+			bld.WriteRune(r)
+		case r == '\n':
+			bld.WriteString(`\n`)
+		case r == '\r':
+			bld.WriteString(`\r`)
+		case r == '\t':
+			bld.WriteString(`\t`)
+		case r < 0x20:
+			bld.WriteString(`\u00`)
+			bld.WriteByte(hexDigits[r>>4])
+			bld.WriteByte(hexDigits[r&0xF])
+		default:
+			bld.WriteRune(r)
+		}
+	}
+	bld.WriteByte('"')
+
+	return bld.String()
+}
+
+/*
+unmarshalJER returns an error following an attempt to decode the JSON
+content of pkt into v.
+*/
+func unmarshalJER(pkt PDU, v reflect.Value, opts *Options) (err error) {
+	debugEnter(v, opts, pkt)
+	defer func() { debugExit(newLItem(err)) }()
+
+	dec := json.NewDecoder(bytes.NewReader(pkt.Data()))
+	dec.UseNumber()
+
+	var node any
+	if err = dec.Decode(&node); err != nil {
+		err = codecErrorf("JER: ", err)
+	} else {
+		err = jerReadValue(v, node, opts)
+	}
+
+	return
+}
+
+/*
+jerReadValue writes the decoded JSON node into v.
+*/
+func jerReadValue(v reflect.Value, node any, opts *Options) (err error) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if node == nil {
+			v.Set(reflect.Zero(v.Type()))
+			return
+		}
+		if v.IsNil() {
+			v.Set(refNew(v.Type().Elem()))
+		}
+		err = jerReadValue(v.Elem(), node, opts)
+		return
+	case reflect.Interface:
+		err = jerReadChoice(v, node, opts)
+		return
+	}
+
+	if isPrimitive(toPtr(v).Interface()) {
+		err = jerReadPrimitive(v, node)
+		return
+	}
+
+	var adapted bool
+	if adapted, err = jerReadAdapted(v, node, opts); adapted {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		obj, ok := node.(map[string]any)
+		if !ok {
+			err = jerTypeError(v, node)
+		} else {
+			err = jerReadFields(v, obj, opts)
+		}
+	case reflect.Slice:
+		err = jerReadSlice(v, node)
+	default:
+		err = jerReadNative(v, node)
+	}
+
+	return
+}
+
+/*
+jerReadAdapted writes the decoded JSON node into v by way of the adapter
+registered for its type, if any. As with the BER decoder, the node is first
+decoded into the adapter's [Primitive] -- thereby subjecting it to the same
+parsing and validation -- before conversion to the Go type through toGo.
+
+The Boolean return value indicates whether an adapter was used.
+*/
+func jerReadAdapted(v reflect.Value, node any, opts *Options) (adapted bool, err error) {
+	opts = deferImplicit(opts)
+
+	var ad adapter
+	if ad, adapted = adapterForValue(v, opts.Identifier); !adapted {
+		return
+	}
+
+	codec := ad.newCodec()
+	bx, ok := codec.(box)
+	if !ok {
+		adapted = false
+		return
+	}
+
+	pv := refNew(refTypeOf(bx.getVal()))
+	if _, ok = pv.Interface().(Primitive); !ok {
+		adapted = false
+		return
+	}
+
+	if err = jerReadPrimitive(pv.Elem(), node); err != nil {
+		return
+	}
+	bx.setVal(pv.Elem().Interface())
+
+	var a any
+	if a, err = ad.toGo(codec); err == nil {
+		goVal := refValueOf(a)
+		if !goVal.Type().AssignableTo(v.Type()) {
+			err = codecErrorf("type mismatch decoding ", opts.Identifier)
+		} else {
+			err = refSetValue(v, goVal)
+		}
+	}
+
+	return
+}
+
+/*
+jerReadFields writes the members of obj into the fields of struct v.
+*/
+func jerReadFields(v reflect.Value, obj map[string]any, opts *Options) (err error) {
+	typ := v.Type()
+	fields := structFields(typ)
+	rawIdx := findRawContentIndex(typ, fields)
+	auto := automaticTagging(opts, fields)
+
+	for i := 0; i < len(fields) && err == nil; i++ {
+		field := fields[i]
+		if field.PkgPath != "" || rawIdx == i {
+			continue
+		}
+
+		var fOpts *Options
+		if fOpts, err = extractOptions(field, i, auto); err != nil || fOpts.Extension {
+			continue
+		}
+		fOpts.copyPhase(opts)
+
+		fv := v.Field(i)
+		if fOpts.ComponentsOf {
+			if !field.Anonymous {
+				err = errorComponentsNotAnonymous
+			} else {
+				err = jerReadFields(componentsOfValue(fv, true), obj, fOpts)
+			}
+			continue
+		}
+
+		name := textFieldName(field, fOpts)
+		if member, found := obj[name]; found {
+			if err = jerReadValue(fv, member, fOpts); err == nil {
+				err = applyFieldConstraints(fv.Interface(), fOpts, '$')
+			}
+		} else if optsHasDefault(fOpts) {
+			err = refSetValue(fv, refValueOf(fOpts.Default))
+		} else if !(fOpts.Optional || fOpts.OmitEmpty || fOpts.Absent) {
+			err = compositeErrorf("JER: missing required member ", name)
+		}
+	}
+
+	return
+}
+
+/*
+jerReadSlice writes the elements of the JSON array node into slice v.
+*/
+func jerReadSlice(v reflect.Value, node any) (err error) {
+	if v.Type().Elem().Kind() == reflect.Uint8 {
+		// []byte is rendered as a hexadecimal string
+		err = jerReadNative(v, node)
+		return
+	}
+
+	arr, ok := node.([]any)
+	if !ok {
+		err = jerTypeError(v, node)
+		return
+	}
+
+	out := refMkSl(v.Type(), len(arr), len(arr))
+	for i := 0; i < len(arr) && err == nil; i++ {
+		err = jerReadValue(out.Index(i), arr[i], nil)
+	}
+	if err == nil {
+		v.Set(out)
+	}
+
+	return
+}
+
+/*
+jerReadChoice writes the CHOICE alternative held within node into the
+interface value v, using the [Choices] registered under opts.Choices.
+*/
+func jerReadChoice(v reflect.Value, node any, opts *Options) (err error) {
+	obj, ok := node.(map[string]any)
+	if !ok || len(obj) != 1 {
+		err = choiceErrorf("JER: CHOICE must be an object with exactly one member")
+		return
+	} else if !optsHasChoices(opts) {
+		err = choiceErrorf("JER: no Choices declared for ", v.Type())
+		return
+	}
+
+	reg, found := GetChoices(opts.Choices)
+	if !found {
+		err = choiceErrorf("JER: Choices not found: ", opts.Choices)
+		return
+	}
+
+	for key, member := range obj {
+		altType, tag, ok := jerLookupAlternative(reg, key)
+		if !ok {
+			err = errorNoChoiceForType
+			return
+		}
+
+		alt := refNew(altType).Elem()
+		if err = jerReadValue(alt, member, nil); err == nil {
+			if v.Type() == choicePtrType {
+				v.Set(refValueOf(NewChoice(alt.Interface(), tag)))
+			} else if alt.Type().AssignableTo(v.Type()) {
+				v.Set(alt)
+			} else {
+				err = choiceErrorf("JER: alternative ", key, " not assignable to ", v.Type())
+			}
+		}
+	}
+
+	return
+}
+
+/*
+jerLookupAlternative returns the type and tag of the alternative within
+reg whose key, per jerAlternativeKey, matches name.
+*/
+func jerLookupAlternative(reg Choices, name string) (t reflect.Type, tag int, ok bool) {
+	for _, cd := range reg.reg {
+		for altTag, altType := range cd.tagToType {
+			if key, err := jerAlternativeKey(cd, altTag); err == nil && key == name {
+				t, tag, ok = altType, altTag, true
+				return
+			}
+		}
+	}
+
+	return
+}
+
+/*
+jerReadPrimitive writes the decoded JSON node into the [Primitive]
+value v.
+*/
+func jerReadPrimitive(v reflect.Value, node any) (err error) {
+	prim := toPtr(v).Interface().(Primitive)
+
+	var val any
+	switch tag := prim.Tag(); tag {
+	case TagBoolean:
+		b, ok := node.(bool)
+		val, err = Boolean(b), jerAssert(ok, v, node)
+	case TagNull:
+		val, err = Null{}, jerAssert(node == nil, v, node)
+	case TagInteger:
+		val, err = NewInteger(jerNumberString(node))
+	case TagEnum:
+		var n int
+		if n, err = atoi(jerNumberString(node)); err == nil {
+			val = Enumerated(n)
+		}
+	case TagReal:
+		val, err = jerParseReal(node)
+	case TagOctetString:
+		var b []byte
+		if b, err = jerHexString(node); err == nil {
+			val = OctetString(b)
+		}
+	case TagBitString:
+		val, err = jerParseBitString(node)
+	default:
+		s, ok := node.(string)
+		if err = jerAssert(ok, v, node); err == nil {
+			if parse, found := jerStringParsers[tag]; found {
+				val, err = parse(s)
+			} else if v.Kind() == reflect.String {
+				val = s
+			} else {
+				err = primitiveErrorf("JER: no decoder for ", TagNames[tag])
+			}
+		}
+	}
+
+	if err == nil {
+		rv := refValueOf(val)
+		if !rv.Type().ConvertibleTo(v.Type()) {
+			err = jerTypeError(v, node)
+		} else {
+			v.Set(rv.Convert(v.Type()))
+		}
+	}
+
+	return
+}
+
+/*
+jerReadNative writes the decoded JSON node into v, which is assumed to
+be a native Go type supported by an adapter (e.g.: int, string).
+*/
+func jerReadNative(v reflect.Value, node any) (err error) {
+	switch v.Kind() {
+	case reflect.Bool:
+		b, ok := node.(bool)
+		if err = jerAssert(ok, v, node); err == nil {
+			v.SetBool(b)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		if n, err = pint(jerNumberString(node), 10, 64); err == nil {
+			v.SetInt(n)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		if n, err = puint(jerNumberString(node), 10, 64); err == nil {
+			v.SetUint(n)
+		}
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if f, err = pfloat(jerNumberString(node), 64); err == nil {
+			v.SetFloat(f)
+		}
+	case reflect.String:
+		s, ok := node.(string)
+		if err = jerAssert(ok, v, node); err == nil {
+			v.SetString(s)
+		}
+	case reflect.Slice:
+		var b []byte
+		if b, err = jerHexString(node); err == nil {
+			v.SetBytes(b)
+		}
+	default:
+		err = jerTypeError(v, node)
+	}
+
+	return
+}
+
+func jerParseReal(node any) (r Real, err error) {
+	switch tv := node.(type) {
+	case string:
+		switch tv {
+		case "INF":
+			r = NewRealPlusInfinity()
+		case "-INF":
+			r = NewRealMinusInfinity()
+		default:
+			err = primitiveErrorf("JER: invalid REAL ", tv)
+		}
+	case json.Number:
+		var f float64
+		if f, err = tv.Float64(); err == nil {
+			var mant any
+			var exp int
+			if mant, exp, err = float64ToRealParts(f, 2); err == nil {
+				r, err = NewReal(mant, 2, exp)
+			}
+		}
+	default:
+		err = primitiveErrorf("JER: invalid REAL ", node)
+	}
+
+	return
+}
+
+func jerParseBitString(node any) (bs BitString, err error) {
+	obj, ok := node.(map[string]any)
+	if !ok {
+		err = primitiveErrorf("JER: BIT STRING must be an object")
+		return
+	}
+
+	if bs.Bytes, err = jerHexString(obj["value"]); err == nil {
+		var n int
+		if n, err = atoi(jerNumberString(obj["length"])); err == nil {
+			if n < 0 || n > len(bs.Bytes)*8 {
+				err = primitiveErrorf("JER: invalid BIT STRING length ", n)
+			} else {
+				bs.BitLength = n
+			}
+		}
+	}
+
+	return
+}
+
+func jerHexString(node any) (b []byte, err error) {
+	if s, ok := node.(string); !ok {
+		err = primitiveErrorf("JER: expected hexadecimal string, got ", node)
+	} else {
+		b, err = hex.DecodeString(s)
+	}
+
+	return
+}
+
+func jerNumberString(node any) (s string) {
+	switch tv := node.(type) {
+	case json.Number:
+		s = tv.String()
+	case string:
+		s = tv
+	}
+
+	return
+}
+
+func jerAssert(ok bool, v reflect.Value, node any) (err error) {
+	if !ok {
+		err = jerTypeError(v, node)
+	}
+	return
+}
+
+func jerTypeError(v reflect.Value, node any) error {
+	return codecErrorf("JER: cannot decode ", refTypeOf(node), " into ", v.Type())
+}
+
+/*
+jerStringParsers contains string-based [Primitive] constructors, keyed
+by ASN.1 tag, for use during JER decoding.
+*/
+var jerStringParsers = map[int]func(string) (any, error){
+	TagNumericString:    jerParser(NewNumericString),
+	TagPrintableString:  jerParser(NewPrintableString),
+	TagIA5String:        jerParser(NewIA5String),
+	TagUTF8String:       jerParser(NewUTF8String),
+	TagVisibleString:    jerParser(NewVisibleString),
+	TagUniversalString:  jerParser(NewUniversalString),
+	TagBMPString:        jerParser(NewBMPString),
+	TagObjectDescriptor: jerParser(NewObjectDescriptor),
+	TagTime:             jerParser(NewTime),
+	TagDate:             jerParser(NewDate),
+	TagDateTime:         jerParser(NewDateTime),
+	TagTimeOfDay:        jerParser(NewTimeOfDay),
+	TagDuration:         jerParser(NewDuration),
+	TagGeneralizedTime:  jerParser(NewGeneralizedTime),
+	TagOID: func(s string) (any, error) {
+		return NewObjectIdentifier(s)
+	},
+	TagRelativeOID: func(s string) (any, error) {
+		return NewRelativeOID(s)
+	},
+}
+
+func jerParser[T any](fn func(any, ...Constraint) (T, error)) func(string) (any, error) {
+	return func(s string) (any, error) { return fn(s) }
+}
+
+func init() {
+	activeEncodingRules |= JER
+	pDUConstructors[JER] = newJERPacket
+	textMarshalers[JER] = marshalJER
+	textUnmarshalers[JER] = unmarshalJER
+}
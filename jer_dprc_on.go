@@ -0,0 +1,15 @@
+//go:build !asn1_no_jer && !asn1_no_dprc
+
+package asn1plus
+
+/*
+jer_dprc_on.go contains JER components for the deprecated ASN.1 types.
+*/
+
+func init() {
+	jerStringParsers[TagT61String] = jerParser(NewT61String)
+	jerStringParsers[TagVideotexString] = jerParser(NewVideotexString)
+	jerStringParsers[TagGraphicString] = jerParser(NewGraphicString)
+	jerStringParsers[TagGeneralString] = jerParser(NewGeneralString)
+	jerStringParsers[TagUTCTime] = jerParser(NewUTCTime)
+}
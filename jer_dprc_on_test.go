@@ -0,0 +1,39 @@
+//go:build !asn1_no_jer && !asn1_no_dprc
+
+package asn1plus
+
+import "testing"
+
+func TestJER_deprecatedTypes(t *testing.T) {
+	type Legacy struct {
+		When    UTCTime
+		Graphic GraphicString
+		General GeneralString
+	}
+
+	when, _ := NewUTCTime("240102030405Z")
+	in := Legacy{When: when, Graphic: GraphicString("abc"), General: GeneralString("def")}
+
+	pkt, err := Marshal(in, With(JER))
+	if err != nil {
+		t.Fatalf("%s failed [JER encoding]: %v", t.Name(), err)
+	}
+
+	want := `{"When":"240102030405Z","Graphic":"abc","General":"def"}`
+	if got := pkt.Hex(); got != want {
+		t.Fatalf("%s failed [JER encoding]:\n\twant: %s\n\tgot:  %s", t.Name(), want, got)
+	}
+
+	var out Legacy
+	if err = Unmarshal(pkt, &out); err != nil {
+		t.Fatalf("%s failed [JER decoding]: %v", t.Name(), err)
+	} else if !out.When.Cast().Equal(in.When.Cast()) ||
+		out.Graphic != in.Graphic || out.General != in.General {
+		t.Fatalf("%s failed [JER decoding]:\n\twant: %v\n\tgot:  %v", t.Name(), in, out)
+	}
+
+	bogus := `{"When":"24010203041","Graphic":"abc","General":"def"}`
+	if err = Unmarshal(JER.New([]byte(bogus)...), &out); err == nil {
+		t.Fatalf("%s failed: expected error for truncated UTCTime, got nil", t.Name())
+	}
+}
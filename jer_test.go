@@ -0,0 +1,280 @@
+//go:build !asn1_no_jer
+
+package asn1plus
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+func ExampleJERPacket() {
+	type MySequence struct {
+		Name PrintableString `asn1:"name:name"`
+		Age  Integer         `asn1:"name:age"`
+	}
+
+	nint, _ := NewInteger(48)
+	mine := MySequence{PrintableString("Jesse"), nint}
+
+	pkt, err := Marshal(mine, With(JER))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(pkt.Hex())
+
+	var mine2 MySequence
+	if err = Unmarshal(pkt, &mine2); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Printf("Decoded value: %s,%s", mine2.Name, mine2.Age)
+	// Output:
+	// {"name":"Jesse","age":48}
+	// Decoded value: Jesse,48
+}
+
+func ExampleJERPacket_Dump() {
+	type MySequence struct {
+		Name    PrintableString
+		Payload OctetString
+		Flags   BitString
+		Empty   Null
+	}
+
+	mine := MySequence{
+		Name:    PrintableString("Jesse"),
+		Payload: OctetString("hi"),
+		Flags:   BitString{Bytes: []byte{0xA0}, BitLength: 4},
+	}
+
+	pkt, err := Marshal(mine, With(JER))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if err = pkt.Dump(os.Stdout); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// {
+	//   "Name": "Jesse",
+	//   "Payload": "6869",
+	//   "Flags": {
+	//     "value": "A0",
+	//     "length": 4
+	//   },
+	//   "Empty": null
+	// }
+}
+
+func TestJER_nestedSequenceChoice(t *testing.T) {
+	choices := NewChoices()
+	choices.Register(nil, ObjectIdentifier{}, &Options{})
+	choices.Register(nil, PrintableString(""), (&Options{}).SetTag(1))
+	RegisterChoices("jerChoices", choices)
+	defer UnregisterChoices("jerChoices")
+
+	type Inner struct {
+		Flag  Boolean
+		Count Enumerated
+		Note  UTF8String `asn1:"optional"`
+	}
+
+	type Outer struct {
+		ID      Integer
+		Inner   Inner
+		Items   []Integer `asn1:"sequence"`
+		Data    OctetString
+		Pick    Choice   `asn1:"choices:jerChoices"`
+		Missing *Integer `asn1:"optional"`
+	}
+
+	big, _ := NewInteger("123456789012345678901234567890")
+	i1, _ := NewInteger(1)
+	i2, _ := NewInteger(-2)
+	oid, _ := NewObjectIdentifier(1, 3, 6, 1, 4, 1, 56521)
+
+	outer := Outer{
+		ID:    big,
+		Inner: Inner{Flag: true, Count: 3, Note: UTF8String("a\"b")},
+		Items: []Integer{i1, i2},
+		Data:  OctetString("hi"),
+		Pick:  NewChoice(oid),
+	}
+
+	pkt, err := Marshal(outer, With(JER))
+	if err != nil {
+		t.Fatalf("%s failed [JER marshal]: %v", t.Name(), err)
+	}
+
+	want := `{"ID":"123456789012345678901234567890",` +
+		`"Inner":{"Flag":true,"Count":3,"Note":"a\"b"},` +
+		`"Items":[1,-2],"Data":"6869",` +
+		`"Pick":{"OBJECT_IDENTIFIER":"1.3.6.1.4.1.56521"}}`
+	if got := pkt.Hex(); got != want {
+		t.Fatalf("%s failed [JER output]:\n\twant: %s\n\tgot:  %s", t.Name(), want, got)
+	}
+
+	var outer2 Outer
+	if err = Unmarshal(pkt, &outer2); err != nil {
+		t.Fatalf("%s failed [JER unmarshal]: %v", t.Name(), err)
+	}
+
+	if !outer2.ID.Eq(big) || outer2.Inner != outer.Inner ||
+		len(outer2.Items) != 2 || !outer2.Items[1].Eq(i2) ||
+		!btseq(outer2.Data, outer.Data) || outer2.Missing != nil {
+		t.Fatalf("%s failed [JER round-trip]:\n\twant: %#v\n\tgot:  %#v", t.Name(), outer, outer2)
+	}
+
+	if got, ok := outer2.Pick.Value().(ObjectIdentifier); !ok || !got.Eq(oid) {
+		t.Fatalf("%s failed [JER CHOICE]: want %s, got %v", t.Name(), oid, outer2.Pick.Value())
+	}
+
+	// second alternative
+	outer.Pick = NewChoice(PrintableString("hello"), 1)
+	if pkt, err = Marshal(outer, With(JER)); err != nil {
+		t.Fatalf("%s failed [JER marshal]: %v", t.Name(), err)
+	}
+	if err = Unmarshal(pkt, &outer2); err != nil {
+		t.Fatalf("%s failed [JER unmarshal]: %v", t.Name(), err)
+	} else if got := outer2.Pick.Value(); got != PrintableString("hello") || outer2.Pick.Tag() != 1 {
+		t.Fatalf("%s failed [JER CHOICE]: want hello, got %v", t.Name(), got)
+	}
+
+	// alternatives sharing a type are keyed by their identifiers
+	same := NewChoices()
+	same.Register(nil, OctetString{}, (&Options{Name: "first"}).SetTag(0))
+	same.Register(nil, OctetString{}, (&Options{Name: "second"}).SetTag(1))
+	RegisterChoices("jerSameType", same)
+	defer UnregisterChoices("jerSameType")
+
+	type Picker struct {
+		Pick Choice `asn1:"choices:jerSameType"`
+	}
+
+	for _, tag := range []int{0, 1} {
+		in := Picker{Pick: NewChoice(OctetString("hi"), tag)}
+		if pkt, err = Marshal(in, With(JER)); err != nil {
+			t.Fatalf("%s failed [JER marshal %d]: %v", t.Name(), tag, err)
+		}
+
+		want := `{"Pick":{"` + []string{"first", "second"}[tag] + `":"6869"}}`
+		if got := pkt.Hex(); got != want {
+			t.Fatalf("%s failed [JER output %d]:\n\twant: %s\n\tgot:  %s", t.Name(), tag, want, got)
+		}
+
+		var out Picker
+		if err = Unmarshal(pkt, &out); err != nil {
+			t.Fatalf("%s failed [JER unmarshal %d]: %v", t.Name(), tag, err)
+		} else if out.Pick.Tag() != tag || !btseq(out.Pick.Value().(OctetString), OctetString("hi")) {
+			t.Fatalf("%s failed [JER CHOICE %d]: got tag %d, value %v", t.Name(), tag, out.Pick.Tag(), out.Pick.Value())
+		}
+	}
+
+	// without identifiers, such alternatives cannot be told apart
+	anon := NewChoices()
+	anon.Register(nil, OctetString{}, (&Options{}).SetTag(0))
+	anon.Register(nil, OctetString{}, (&Options{}).SetTag(1))
+	RegisterChoices("jerAnonymous", anon)
+	defer UnregisterChoices("jerAnonymous")
+
+	type AnonPicker struct {
+		Pick Choice `asn1:"choices:jerAnonymous"`
+	}
+
+	if _, err = Marshal(AnonPicker{Pick: NewChoice(OctetString("hi"), 1)}, With(JER)); err == nil {
+		t.Fatalf("%s failed: expected error for ambiguous alternatives, got nil", t.Name())
+	}
+	var anonOut AnonPicker
+	if err = Unmarshal(JER.New([]byte(`{"Pick":{"OCTET_STRING":"6869"}}`)...), &anonOut); err == nil {
+		t.Fatalf("%s failed: expected error for ambiguous alternatives, got nil", t.Name())
+	}
+}
+
+func TestJER_adaptedFields(t *testing.T) {
+	if !isAdapterKeyword("gt") || !isAdapterKeyword("printable") {
+		t.Skip("adapters not enabled")
+	}
+
+	type Adapted struct {
+		When  time.Time `asn1:"gt"`
+		Name  string    `asn1:"printable"`
+		Count int
+	}
+
+	in := Adapted{
+		When:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Name:  "Jesse",
+		Count: 48,
+	}
+
+	pkt, err := Marshal(in, With(JER))
+	if err != nil {
+		t.Fatalf("%s failed [JER encoding]: %v", t.Name(), err)
+	}
+
+	want := `{"When":"20240102030405Z","Name":"Jesse","Count":48}`
+	if got := string(pkt.Data()); got != want {
+		t.Fatalf("%s failed [JER encoding]:\n\twant: %s\n\tgot:  %s", t.Name(), want, got)
+	}
+
+	var out Adapted
+	if err = Unmarshal(pkt, &out); err != nil {
+		t.Fatalf("%s failed [JER decoding]: %v", t.Name(), err)
+	} else if !out.When.Equal(in.When) || out.Name != in.Name || out.Count != in.Count {
+		t.Fatalf("%s failed [JER decoding]:\n\twant: %v\n\tgot:  %v", t.Name(), in, out)
+	}
+
+	for _, doc := range []string{
+		`{"When":"20240102030405Z","Name":"bad_char!","Count":1}`,
+		`{"When":"not a time","Name":"Jesse","Count":1}`,
+	} {
+		if err = Unmarshal(JER.New([]byte(doc)...), &out); err == nil {
+			t.Fatalf("%s failed: expected error for %s, got nil", t.Name(), doc)
+		}
+	}
+}
+
+func TestJER_codecov(t *testing.T) {
+	type Required struct {
+		Name PrintableString
+	}
+
+	var dest Required
+	for _, doc := range []string{
+		`{}`,
+		`{"Name":1}`,
+		`[]`,
+		`{"Name":"bad_char!"}`,
+		`not json`,
+	} {
+		if err := Unmarshal(JER.New([]byte(doc)...), &dest); err == nil {
+			t.Fatalf("%s failed: expected error for %s, got nil", t.Name(), doc)
+		}
+	}
+
+	pkt := JER.New()
+	if pkt.Type() != JER || pkt.Type().String() != `JER` || !pkt.Type().OID().Eq(jerOID) {
+		t.Fatalf("%s failed [JER identity]", t.Name())
+	}
+	if _, err := pkt.TLV(); err == nil {
+		t.Fatalf("%s failed: expected TLV error, got nil", t.Name())
+	}
+
+	for _, s := range []string{"tab\there", "ctl\x01", `back\slash`} {
+		pkt, err := Marshal(UTF8String(s), With(JER))
+		if err != nil {
+			t.Fatalf("%s failed [JER marshal]: %v", t.Name(), err)
+		}
+		var out UTF8String
+		if err = Unmarshal(pkt, &out); err != nil || string(out) != s {
+			t.Fatalf("%s failed [JER string round-trip]: want %q, got %q (%v)", t.Name(), s, out, err)
+		}
+	}
+}
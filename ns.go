@@ -163,7 +163,7 @@ func init() {
 		return
 	}
 
-	RegisterTextAlias[NumericString](TagNumericString,
-		NumericStringConstraintPhase,
+	registerTextAlias[NumericString](TagNumericString,
+		func() int { return NumericStringConstraintPhase },
 		nil, nil, nil, NumericSpec)
 }
@@ -71,7 +71,7 @@ func (c *nullCodec[T]) write(pkt PDU, o *Options) (n int, err error) {
 func bcdNullWrite[T any](c *nullCodec[T], pkt PDU, o *Options) (off int, err error) {
 	o = deferImplicit(o)
 
-	cc := c.cg.phase(c.cphase, CodecConstraintEncoding)
+	cc := c.cg.phase(o.constraintPhase(c.cphase), CodecConstraintEncoding)
 	if err = cc(c.val); err == nil {
 		var wire []byte
 		if c.encodeHook != nil {
@@ -123,7 +123,7 @@ func bcdNullRead[T any](c *nullCodec[T], pkt PDU, tlv TLV, o *Options) error {
 			}
 
 			if err == nil {
-				cc := c.cg.phase(c.cphase, CodecConstraintDecoding)
+				cc := c.cg.phase(o.constraintPhase(c.cphase), CodecConstraintDecoding)
 				if err = cc(out); err == nil {
 					c.val = out
 					pkt.AddOffset(tlv.Length)
@@ -136,7 +136,7 @@ func init() {
 		return
 	}
 
-	RegisterTextAlias[OctetString](TagOctetString,
-		OctetStringConstraintPhase,
+	registerTextAlias[OctetString](TagOctetString,
+		func() int { return OctetStringConstraintPhase },
 		nil, nil, nil, OctetSpec)
 }
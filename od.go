@@ -21,6 +21,10 @@ for the constraining of values during codec operations.
 See the [CodecConstraintNone], [CodecConstraintEncoding],
 [CodecConstraintDecoding] and [CodecConstraintBoth] constants
 for possible settings.
+
+This includes [ObjectDescriptorSpec], which rejects characters outside of the
+graphic repertoire, such as control characters. By default, this
+check is applied only when decoding.
 */
 var ObjectDescriptorConstraintPhase = CodecConstraintDecoding
 
@@ -110,33 +114,43 @@ ASN.1 primitive.
 */
 func (_ ObjectDescriptor) IsPrimitive() bool { return true }
 
-func graphicStringDecoderVerify(b []byte) (err error) {
+/*
+graphicVerify returns an error if b contains any character outside of
+the graphic repertoire shared by [GraphicString] and [ObjectDescriptor],
+i.e.: control characters (including DEL) and other non-printables. The
+name of the offending type is used in the error text.
+*/
+func graphicVerify(name string, b []byte) (err error) {
 	runes := []rune(string(b))
 	for i := 0; i < len(runes) && err == nil; i++ {
 		ch := rune(runes[i])
 		if !unicode.IsPrint(ch) || unicode.IsControl(ch) || (ch < 128 && !(32 <= ch && ch <= 126)) {
-			err = primitiveErrorf("GraphicString: invalid character: ", string(ch))
+			err = primitiveErrorf(name, ": invalid character: U+", uc(fmtInt(int64(ch), 16)))
 		}
 	}
 	return
 }
 
+func graphicStringDecoderVerify(b []byte) error { return graphicVerify("GraphicString", b) }
+
+func objectDescriptorVerify(b []byte) error { return graphicVerify("ObjectDescriptor", b) }
+
 func init() {
 	ObjectDescriptorSpec = func(obj any) (err error) {
 		switch tv := obj.(type) {
 		case string:
-			err = graphicStringDecoderVerify([]byte(tv))
+			err = objectDescriptorVerify([]byte(tv))
 		case []byte:
-			err = graphicStringDecoderVerify(tv)
+			err = objectDescriptorVerify(tv)
 		case Primitive:
-			err = graphicStringDecoderVerify([]byte(tv.String()))
+			err = objectDescriptorVerify([]byte(tv.String()))
 		default:
 			err = errorPrimitiveAssertionFailed(ObjectDescriptor(``))
 		}
 		return
 	}
 
-	RegisterTextAlias[ObjectDescriptor](TagObjectDescriptor,
-		ObjectDescriptorConstraintPhase,
+	registerTextAlias[ObjectDescriptor](TagObjectDescriptor,
+		func() int { return ObjectDescriptorConstraintPhase },
 		nil, nil, nil, ObjectDescriptorSpec)
 }
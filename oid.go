@@ -9,8 +9,10 @@ JesseCoretta/go-objectid, but with small improvements.
 */
 
 import (
+	"crypto/x509"
 	"math/big"
 	"reflect"
+	"sync"
 	"unsafe"
 )
 
@@ -408,6 +410,63 @@ func (r ObjectIdentifier) Eq(o ObjectIdentifier) bool {
 	return ok
 }
 
+/*
+Compare returns an integer comparing the receiver and input [ObjectIdentifier]
+instances arc by arc. The result is -1 if r < o, 0 if r == o and 1 if r > o.
+If one instance is a prefix of the other, the shorter instance sorts first.
+
+This is suitable for use with [sort.Slice] and [slices.SortFunc].
+*/
+func (r ObjectIdentifier) Compare(o ObjectIdentifier) int {
+	for i := 0; i < r.Len() && i < o.Len(); i++ {
+		if c := cmpInteger(r[i], o[i]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case r.Len() < o.Len():
+		return -1
+	case r.Len() > o.Len():
+		return 1
+	}
+
+	return 0
+}
+
+/*
+HasPrefix returns a Boolean value indicative of the input [ObjectIdentifier]
+being a leading subset of the receiver instance, i.e.: each arc of p equals
+the corresponding leading arc of the receiver. Equal instances match.
+*/
+func (r ObjectIdentifier) HasPrefix(p ObjectIdentifier) bool {
+	var ok bool
+	if ok = p.Len() <= r.Len(); ok {
+		for i := 0; i < p.Len() && ok; i++ {
+			ok = r[i].Eq(p[i])
+		}
+	}
+
+	return ok
+}
+
+/*
+TrimPrefix returns the arcs of the receiver instance which follow the input
+[ObjectIdentifier] as a [RelativeOID], alongside a Boolean value indicative
+of p being a prefix of the receiver (see [ObjectIdentifier.HasPrefix]). If
+p is not a prefix, a nil [RelativeOID] is returned.
+
+This is the inverse of [RelativeOID.Absolute].
+*/
+func (r ObjectIdentifier) TrimPrefix(p ObjectIdentifier) (rel RelativeOID, ok bool) {
+	if ok = r.HasPrefix(p); ok {
+		rel = make(RelativeOID, r.Len()-p.Len())
+		copy(rel, r[p.Len():])
+	}
+
+	return
+}
+
 /*
 Tag returns the integer constant [TagOID].
 */
@@ -486,8 +545,12 @@ func NewObjectIdentifier(x ...any) (r ObjectIdentifier, err error) {
 		switch tv := x[i].(type) {
 		case *big.Int, Integer, string, int64, uint64, int:
 			nf, err = NewInteger(tv)
+		case Constraint:
+			constraints = append(constraints, tv)
+			continue
 		case func(any) error:
 			constraints = append(constraints, Constraint(tv))
+			continue
 		default:
 			err = errorBadTypeForConstructor("OBJECT IDENTIFIER", x[i])
 		}
@@ -511,6 +574,36 @@ func NewObjectIdentifier(x ...any) (r ObjectIdentifier, err error) {
 	return
 }
 
+/*
+NewObjectIdentifierFromInts returns an instance of [ObjectIdentifier]
+alongside an error following an attempt to marshal ints. As the input
+type matches the underlying type of [encoding/asn1.ObjectIdentifier],
+instances of the latter may be submitted following a simple cast:
+
+	oid, err := NewObjectIdentifierFromInts([]int(stdOID))
+
+See also [ObjectIdentifier.IntSlice].
+*/
+func NewObjectIdentifierFromInts(ints []int) (r ObjectIdentifier, err error) {
+	args := make([]any, len(ints))
+	for i, n := range ints {
+		args[i] = n
+	}
+
+	var _r ObjectIdentifier
+	if len(args) < 2 {
+		err = errorMinOIDArcs
+	} else if _r, err = NewObjectIdentifier(args...); err == nil {
+		if !_r.Valid() {
+			err = primitiveErrorf("OBJECT IDENTIFIER: invalid value")
+		} else {
+			r = _r
+		}
+	}
+
+	return
+}
+
 /*
 MustNewObjectIdentifier returns an instance of [ObjectIdentifier] and
 panics if [NewObjectIdentifier] returned an error during processing
@@ -573,7 +666,8 @@ IntSlice returns slices of integer values and an error. The integer values are b
 upon the contents of the receiver. Note that if any single arc number overflows int,
 a zero slice is returned.
 
-Successful output can be cast as an instance of [encoding/asn1.ObjectIdentifier], if desired.
+Successful output can be cast directly as an instance of [encoding/asn1.ObjectIdentifier],
+as both are of the underlying type []int. See [NewObjectIdentifierFromInts] for the reverse.
 */
 func (r ObjectIdentifier) IntSlice() (slice []int, err error) {
 	if r.IsZero() {
@@ -606,8 +700,8 @@ values are based upon the contents of the receiver.
 Note that if any single arc number overflows uint64, a zero slice is
 returned alongside an error.
 
-Successful output can be cast as an instance of [crypto/x509.OID], if
-desired.
+Successful output may be submitted to [crypto/x509.OIDFromInts], if
+desired. See also [ObjectIdentifier.X509].
 */
 func (r ObjectIdentifier) Uint64Slice() (slice []uint64, err error) {
 	if r.IsZero() {
@@ -632,6 +726,33 @@ func (r ObjectIdentifier) Uint64Slice() (slice []uint64, err error) {
 	return
 }
 
+/*
+X509 returns an instance of [crypto/x509.OID] alongside an error following
+an attempt to convert the receiver instance. Unlike [ObjectIdentifier.Uint64Slice],
+arcs of any magnitude are supported.
+
+See also [NewObjectIdentifierFromX509].
+*/
+func (r ObjectIdentifier) X509() (oid x509.OID, err error) {
+	if r.Len() < 2 {
+		err = errorMinOIDArcs
+		return
+	}
+
+	return x509.ParseOID(r.String())
+}
+
+/*
+NewObjectIdentifierFromX509 returns an instance of [ObjectIdentifier]
+alongside an error following an attempt to convert oid, such as one
+obtained from a parsed [crypto/x509.Certificate].
+
+See also [ObjectIdentifier.X509].
+*/
+func NewObjectIdentifierFromX509(oid x509.OID) (ObjectIdentifier, error) {
+	return newObjectIdentifierStr(oid.String())
+}
+
 /*
 Index returns the Nth index from the receiver, alongside a Boolean
 value indicative of success. This method supports the use of negative
@@ -649,7 +770,7 @@ func (r ObjectIdentifier) Index(idx int) (a Integer, ok bool) {
 		} else if idx < L {
 			a = r[idx]
 		}
-		ok = !a.IsZero()
+		ok = true
 	}
 
 	return
@@ -871,6 +992,73 @@ func (r RelativeOID) Absolute(base ObjectIdentifier) ObjectIdentifier {
 	return abs
 }
 
+/*
+AbsoluteNamed returns a complete [ObjectIdentifier] instance alongside an
+error following an attempt to append the receiver instance to the base
+registered under name by way of [RegisterRelativeOIDBase].
+
+Note that case-folding is not significant in the matching process.
+*/
+func (r RelativeOID) AbsoluteNamed(name string) (abs ObjectIdentifier, err error) {
+	roidMu.RLock()
+	base, found := relativeOIDBases[lc(name)]
+	roidMu.RUnlock()
+
+	if !found {
+		err = generalErrorf("RELATIVE-OID: unknown base ", name)
+		return
+	}
+
+	abs = r.Absolute(base)
+	return
+}
+
+var (
+	relativeOIDBases map[string]ObjectIdentifier = make(map[string]ObjectIdentifier)
+	roidMu           sync.RWMutex
+)
+
+/*
+RegisterRelativeOIDBase returns an error following an attempt to write
+name and base to the underlying RELATIVE-OID base registry in a thread
+safe manner, for later use by [RelativeOID.AbsoluteNamed]. This allows
+configuration sources to reference a [RelativeOID] by a symbolic root,
+much like the root names accepted by [NewObjectIdentifierValue].
+
+Note that case-folding is not significant in the registration and
+lookup processes. Existing bases are silently overwritten when a
+duplicate registration is executed.
+
+See also [UnregisterRelativeOIDBase].
+*/
+func RegisterRelativeOIDBase(name string, base ObjectIdentifier) (err error) {
+	if name = lc(trimS(name)); len(name) == 0 {
+		err = generalErrorf("RELATIVE-OID: base name is empty")
+	} else if base.Len() < 2 {
+		err = errorMinOIDArcs
+	} else {
+		roidMu.Lock()
+		relativeOIDBases[name] = append(ObjectIdentifier(nil), base...)
+		roidMu.Unlock()
+	}
+
+	return
+}
+
+/*
+UnregisterRelativeOIDBase deletes the named base from the underlying
+RELATIVE-OID base registry in a thread safe manner.
+
+Note that case-folding is not significant in the matching process.
+
+See also [RegisterRelativeOIDBase].
+*/
+func UnregisterRelativeOIDBase(name string) {
+	roidMu.Lock()
+	delete(relativeOIDBases, lc(trimS(name)))
+	roidMu.Unlock()
+}
+
 /*
 String returns the string representation of the receiver instance.
 */
@@ -921,7 +1109,7 @@ func (c *oidCodec[T]) write(pkt PDU, o *Options) (n int, err error) {
 func bcdOIDWrite[T any](c *oidCodec[T], pkt PDU, o *Options) (off int, err error) {
 	o = deferImplicit(o)
 
-	cc := c.cg.phase(c.cphase, CodecConstraintEncoding)
+	cc := c.cg.phase(o.constraintPhase(c.cphase), CodecConstraintEncoding)
 	if err = cc(c.val); err == nil {
 		var wire []byte
 		if c.encodeHook != nil {
@@ -1022,7 +1210,7 @@ func bcdOIDRead[T any](c *oidCodec[T], pkt PDU, tlv TLV, o *Options) error {
 			}
 
 			if err == nil {
-				cc := c.cg.phase(c.cphase, CodecConstraintDecoding)
+				cc := c.cg.phase(o.constraintPhase(c.cphase), CodecConstraintDecoding)
 				if err = cc(out); err == nil {
 					c.val = out
 					pkt.AddOffset(tlv.Length)
@@ -1063,16 +1251,9 @@ func objectIdentifierReadExpandFirstArcs(subs []*big.Int) (arcs []Integer) {
 		first, second = newBigInt(2), newBigInt(0).Sub(subs[0], eighty)
 	}
 
-	toInt := func(b *big.Int) Integer {
-		if b.IsInt64() {
-			return Integer{native: b.Int64()}
-		}
-		return Integer{big: true, bigInt: b}
-	}
-
-	arcs = []Integer{toInt(first), toInt(second)}
+	arcs = []Integer{bigToInteger(first), bigToInteger(second)}
 	for i := 1; i < len(subs); i++ {
-		arcs = append(arcs, toInt(subs[i]))
+		arcs = append(arcs, bigToInteger(subs[i]))
 	}
 
 	return
@@ -1220,7 +1401,7 @@ func bcdRelOIDRead[T any](c *relOIDCodec[T], pkt PDU, tlv TLV, o *Options) error
 		}
 
 		if err == nil {
-			cc := c.cg.phase(c.cphase, CodecConstraintDecoding)
+			cc := c.cg.phase(o.constraintPhase(c.cphase), CodecConstraintDecoding)
 			if err = cc(out); err == nil {
 				c.val = out
 			}
@@ -1251,14 +1432,7 @@ func relativeOIDReadArcs(data []byte) (roid RelativeOID, err error) {
 		}
 		i++
 
-		var arc Integer
-		if subidentifier.IsInt64() {
-			arc = Integer{native: subidentifier.Int64()}
-		} else {
-			arc = Integer{big: true, bigInt: newBigInt(0).Set(subidentifier)}
-		}
-
-		roid = append(roid, arc)
+		roid = append(roid, bigToInteger(newBigInt(0).Set(subidentifier)))
 		subidentifier = newBigInt(0)
 	}
 
@@ -1282,7 +1456,7 @@ func (c *relOIDCodec[T]) write(pkt PDU, o *Options) (n int, err error) {
 func bcdRelOIDWrite[T any](c *relOIDCodec[T], pkt PDU, o *Options) (off int, err error) {
 	o = deferImplicit(o)
 
-	cc := c.cg.phase(c.cphase, CodecConstraintEncoding)
+	cc := c.cg.phase(o.constraintPhase(c.cphase), CodecConstraintEncoding)
 	if err = cc(c.val); err == nil {
 		var wire []byte
 		if c.encodeHook != nil {
@@ -1294,7 +1468,7 @@ func bcdRelOIDWrite[T any](c *relOIDCodec[T], pkt PDU, o *Options) (off int, err
 			}
 
 			for _, arc := range roid {
-				if arc.Big().Sign() < 0 {
+				if arc.Sign() < 0 {
 					return 0, primitiveErrorf("RELATIVE-OID arcs may not be negative")
 				}
 				wire = append(wire, vlqEncodeBig(arc.Big())...)
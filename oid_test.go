@@ -2,8 +2,12 @@ package asn1plus
 
 import (
 	"bytes"
+	"crypto/x509"
+	stdasn1 "encoding/asn1"
 	"fmt"
+	"math"
 	"math/big"
+	"slices"
 	"testing"
 )
 
@@ -496,6 +500,74 @@ func (_ relOID) Tag() int          { return TagRelativeOID }
 func (_ relOID) String() string    { return `` }
 func (_ relOID) IsPrimitive() bool { return true }
 
+func TestObjectIdentifier_Compare(t *testing.T) {
+	for idx, tc := range []struct {
+		a, b string
+		want int
+	}{
+		{`1.3.6.1`, `1.3.6.1`, 0},
+		{`1.3.6.1`, `1.3.6.1.1`, -1},
+		{`1.3.6.1.1`, `1.3.6.1`, 1},
+		{`1.3.6.1.4`, `1.3.6.1.2.1`, 1},
+		{`1.3.6.1.2.1`, `1.3.6.1.4`, -1},
+		{`2.5.4.3`, `2.5.4.10`, -1},
+		{`1.3.6.1.4.1.56521`, `1.3.6.1.4.1.340282366920938463463374607431768211456`, -1},
+	} {
+		a := MustNewObjectIdentifier(tc.a)
+		b := MustNewObjectIdentifier(tc.b)
+		if got := a.Compare(b); got != tc.want {
+			t.Fatalf("%s[%d] failed: %s vs %s: want %d, got %d",
+				t.Name(), idx, a, b, tc.want, got)
+		}
+	}
+
+	oids := []ObjectIdentifier{
+		MustNewObjectIdentifier(`2.5.4.10`),
+		MustNewObjectIdentifier(`1.3.6.1.1`),
+		MustNewObjectIdentifier(`2.5.4.3`),
+		MustNewObjectIdentifier(`1.3.6.1`),
+	}
+	slices.SortFunc(oids, ObjectIdentifier.Compare)
+
+	want := []string{`1.3.6.1`, `1.3.6.1.1`, `2.5.4.3`, `2.5.4.10`}
+	for i, oid := range oids {
+		if oid.String() != want[i] {
+			t.Fatalf("%s failed [sort]: want %v, got %v", t.Name(), want, oids)
+		}
+	}
+}
+
+func TestObjectIdentifier_prefix(t *testing.T) {
+	oid := MustNewObjectIdentifier(1, 3, 6, 1, 4, 1, 56521)
+
+	for idx, tc := range []struct {
+		prefix ObjectIdentifier
+		ok     bool
+		rest   string
+	}{
+		{MustNewObjectIdentifier(1, 3, 6, 1, 4, 1, 56521), true, ``},
+		{MustNewObjectIdentifier(1, 3, 6, 1), true, `4.1.56521`},
+		{MustNewObjectIdentifier(1, 3, 6, 2), false, ``},
+		{MustNewObjectIdentifier(1, 3, 6, 1, 4, 1, 56521, 1), false, ``},
+		{ObjectIdentifier{}, true, `1.3.6.1.4.1.56521`},
+	} {
+		if got := oid.HasPrefix(tc.prefix); got != tc.ok {
+			t.Fatalf("%s[%d] failed [HasPrefix]: want %t, got %t", t.Name(), idx, tc.ok, got)
+		}
+
+		rel, ok := oid.TrimPrefix(tc.prefix)
+		if ok != tc.ok || rel.String() != tc.rest {
+			t.Fatalf("%s[%d] failed [TrimPrefix]: want %q/%t, got %q/%t",
+				t.Name(), idx, tc.rest, tc.ok, rel, ok)
+		}
+
+		if ok && !rel.Absolute(tc.prefix).Eq(oid) {
+			t.Fatalf("%s[%d] failed [Absolute]: want %s, got %s",
+				t.Name(), idx, oid, rel.Absolute(tc.prefix))
+		}
+	}
+}
+
 func TestRelativeOID_codecov(_ *testing.T) {
 	r, _ := NewRelativeOID(`33.44.55`)
 	r.IsPrimitive()
@@ -695,3 +767,138 @@ func BenchmarkRelativeOIDConstructor(b *testing.B) {
 		}
 	}
 }
+
+func TestRelativeOID_AbsoluteNamed(t *testing.T) {
+	base, _ := NewObjectIdentifier(1, 3, 6, 1, 4, 1, 56521)
+	if err := RegisterRelativeOIDBase("myEnterprise", base); err != nil {
+		t.Fatalf("%s failed [register]: %v", t.Name(), err)
+	}
+	defer UnregisterRelativeOIDBase("myEnterprise")
+
+	// later changes to the caller's base must not leak in
+	base[0], _ = NewInteger(2)
+
+	rel, _ := NewRelativeOID(101, 1)
+	abs, err := rel.AbsoluteNamed("MYENTERPRISE")
+	if err != nil {
+		t.Fatalf("%s failed [resolve]: %v", t.Name(), err)
+	} else if got := abs.String(); got != "1.3.6.1.4.1.56521.101.1" {
+		t.Fatalf("%s failed [resolve]: want 1.3.6.1.4.1.56521.101.1, got %s", t.Name(), got)
+	}
+
+	if _, err = rel.AbsoluteNamed("unknown"); err == nil {
+		t.Fatalf("%s failed: expected error for unknown base, got nil", t.Name())
+	}
+
+	UnregisterRelativeOIDBase("myenterprise")
+	if _, err = rel.AbsoluteNamed("myEnterprise"); err == nil {
+		t.Fatalf("%s failed: expected error for unregistered base, got nil", t.Name())
+	}
+
+	if err = RegisterRelativeOIDBase("", base); err == nil {
+		t.Fatalf("%s failed: expected error for empty name, got nil", t.Name())
+	} else if err = RegisterRelativeOIDBase("short", ObjectIdentifier{}); err == nil {
+		t.Fatalf("%s failed: expected error for short base, got nil", t.Name())
+	}
+}
+
+func TestObjectIdentifier_X509(t *testing.T) {
+	std, err := x509.ParseOID("2.5.4.3")
+	if err != nil {
+		t.Fatalf("%s failed [x509 parse]: %v", t.Name(), err)
+	}
+
+	oid, err := NewObjectIdentifierFromX509(std)
+	if err != nil {
+		t.Fatalf("%s failed [from x509]: %v", t.Name(), err)
+	} else if got := oid.String(); got != "2.5.4.3" {
+		t.Fatalf("%s failed [from x509]: want 2.5.4.3, got %s", t.Name(), got)
+	}
+
+	back, err := oid.X509()
+	if err != nil {
+		t.Fatalf("%s failed [to x509]: %v", t.Name(), err)
+	} else if !back.Equal(std) {
+		t.Fatalf("%s failed [to x509]: want %s, got %s", t.Name(), std, back)
+	}
+
+	// arcs beyond uint64 survive in both directions
+	large, _ := NewObjectIdentifier("2.25.340282366920938463463374607431768211455")
+	if std, err = large.X509(); err != nil {
+		t.Fatalf("%s failed [big to x509]: %v", t.Name(), err)
+	} else if oid, err = NewObjectIdentifierFromX509(std); err != nil || !oid.Eq(large) {
+		t.Fatalf("%s failed [big from x509]: %v (%s)", t.Name(), err, oid)
+	}
+
+	if _, err = (ObjectIdentifier{}).X509(); err == nil {
+		t.Fatalf("%s failed: expected error for short OID, got nil", t.Name())
+	} else if _, err = NewObjectIdentifierFromX509(x509.OID{}); err == nil {
+		t.Fatalf("%s failed: expected error for zero x509.OID, got nil", t.Name())
+	}
+}
+
+func TestObjectIdentifier_encodingASN1(t *testing.T) {
+	for idx, arcs := range [][]int{
+		{0, 0},
+		{1, 2, 840, 113549, 1, 1, 11},
+		{2, 5, 4, 3},
+		{2, 39},
+		{2, 40},
+		{2, 47},
+		{2, 48},
+		{2, 999, 1},
+		{1, 3, 6, 1, 4, 1, 2147483647},
+		{1, 3, 6, 1, 4, 1, 2147483648},
+		{1, 3, 6, 1, 4, 1, 4294967296},
+		{2, 25, 9223372036854775807},
+	} {
+		std, err := stdasn1.Marshal(stdasn1.ObjectIdentifier(arcs))
+		if err != nil {
+			t.Fatalf("%s[%d] failed [encoding/asn1 marshal]: %v", t.Name(), idx, err)
+		}
+
+		var oid ObjectIdentifier
+		if err = Unmarshal(BER.New(std...), &oid); err != nil {
+			t.Fatalf("%s[%d] failed [decode]: %v", t.Name(), idx, err)
+		}
+
+		ints, err := oid.IntSlice()
+		if err != nil {
+			t.Fatalf("%s[%d] failed [IntSlice]: %v", t.Name(), idx, err)
+		} else if got := stdasn1.ObjectIdentifier(ints); !got.Equal(arcs) {
+			t.Fatalf("%s[%d] failed [decode]: want %v, got %v", t.Name(), idx, arcs, got)
+		}
+
+		fromInts, err := NewObjectIdentifierFromInts(arcs)
+		if err != nil || !fromInts.Eq(oid) {
+			t.Fatalf("%s[%d] failed [FromInts]: %v (%s)", t.Name(), idx, err, fromInts)
+		}
+
+		for _, rule := range encodingRules {
+			pkt, err := Marshal(fromInts, With(rule))
+			if err != nil {
+				t.Fatalf("%s[%d] failed [%s encode]: %v", t.Name(), idx, rule, err)
+			} else if !btseq(pkt.Data(), std) {
+				t.Fatalf("%s[%d] failed [%s encode]:\n\twant: %X\n\tgot:  %X",
+					t.Name(), idx, rule, std, pkt.Data())
+			}
+
+			// encoding/asn1 cannot decode arcs wider than 31 bits
+			if slices.Max(arcs) > math.MaxInt32 {
+				continue
+			}
+
+			var back stdasn1.ObjectIdentifier
+			if _, err = stdasn1.Unmarshal(pkt.Data(), &back); err != nil || !back.Equal(arcs) {
+				t.Fatalf("%s[%d] failed [%s encoding/asn1 unmarshal]: %v (%v)",
+					t.Name(), idx, rule, err, back)
+			}
+		}
+	}
+
+	for idx, bogus := range [][]int{nil, {1}, {3, 1}, {1, -1}} {
+		if _, err := NewObjectIdentifierFromInts(bogus); err == nil {
+			t.Fatalf("%s[%d] failed: expected error for %v, got nil", t.Name(), idx, bogus)
+		}
+	}
+}
@@ -9,6 +9,7 @@ tags OR manual delivery of an Options instance.
 
 import (
 	"reflect"
+	"slices"
 	"sync"
 )
 
@@ -28,6 +29,22 @@ type Options struct {
 
 	// If true, the field is optional.
 	//
+	// When decoding a SEQUENCE, fields are visited in the
+	// order in which they are declared. An optional field
+	// is considered present only if the next TLV matches
+	// its class and tag: those of the tagging override, if
+	// set, else the UNIVERSAL tag implied by the field type.
+	// Otherwise the field is skipped and the TLV is offered
+	// to the next field. As such, an untagged optional field
+	// must not share its tag with the field(s) that follow
+	// it, per ITU-T Rec. X.680. Untagged optional CHOICE
+	// fields cannot be matched in this manner and are always
+	// treated as absent; such fields should be tagged.
+	//
+	// An optional pointer field which is nil is omitted when
+	// encoding, while a non-nil pointer encodes its pointee.
+	// When decoding, such a field remains nil if absent.
+	//
 	// Note that this can be enabled textually via the
 	// "optional" keyword during field parsing.
 	Optional bool
@@ -40,6 +57,9 @@ type Options struct {
 	Unique bool
 
 	// If true, the field must be nil AND a pointer type.
+	//
+	// This is not required merely to omit a nil pointer, as
+	// nil optional pointer fields are omitted automatically.
 	Absent bool
 
 	// If true, ignore empty values.
@@ -70,7 +90,8 @@ type Options struct {
 	Indefinite bool
 
 	// If true, automatic tagging is to be applied to a SEQUENCE,
-	// SET or CHOICE(s)
+	// SET or CHOICE(s). Per X.680 §25.3, the components of a SEQUENCE
+	// or SET are left untouched if any of them already bears a tag.
 	//
 	// Note that this can be enabled textually via the
 	// "automatic" keyword during field parsing.
@@ -91,6 +112,16 @@ type Options struct {
 	// "components-of" keyword during field parsing.
 	ComponentsOf bool
 
+	// If true, the TLV found at the field's position within a SEQUENCE
+	// is captured verbatim -- identifier, length and content octets --
+	// during decoding, and is re-emitted byte-for-byte during encoding.
+	// The associated field type MUST be [RawValue] or []byte. Fields of
+	// type [RawValue] are treated in this manner regardless.
+	//
+	// Note that this can be enabled textually via the
+	// "raw" keyword during field parsing.
+	Raw bool
+
 	// Name of key for the associated Choices of a single SEQUENCE
 	// field or other context.
 	//
@@ -120,6 +151,26 @@ type Options struct {
 	// key:value expression during field parsing.
 	WithComponents []string
 
+	// Named values of an ENUMERATED field, keyed by name. When set, a
+	// field of a string kind is encoded as the value associated with its
+	// name and decoded back into that name, while a field of an integer
+	// kind is encoded and decoded as-is. In either case, values absent
+	// from the set produce an error unless EnumExtensible is true.
+	//
+	// Note that this can be declared textually via the
+	// "enum:<name>=<value>,<name>=<value>,..." expression during field
+	// parsing, e.g.: "enum:red=0,green=1,blue=2". The trailing "..."
+	// marker, if present, sets EnumExtensible.
+	//
+	// Alternatively, the members of an enumeration registered via the
+	// RegisterEnumeration function may be declared using two (2) colons,
+	// e.g.: "enum::color".
+	EnumNames map[string]int
+
+	// If true, ENUMERATED values absent from EnumNames are tolerated.
+	// Such values are decoded into string fields in decimal form.
+	EnumExtensible bool
+
 	// Primitive identifier name. Only used when an adapter-based type
 	// (e.g.: string, []byte, etc.) is used instead of the equivalent
 	// Primitive type, and only when the adapter is used in a non-default
@@ -133,6 +184,15 @@ type Options struct {
 	// Case is not significant.
 	Identifier string
 
+	// Name is the ASN.1 identifier of the associated SEQUENCE or SET
+	// component. It is only used by character-based encoding rules,
+	// such as XER and JER, in which the component identifier appears
+	// within the encoding. If unset, the Go field name is used.
+	//
+	// Note that this can be declared textually via the "name:<identifier>"
+	// key:value expression during field parsing.
+	Name string
+
 	// Registered constraints to apply to the SEQUENCE field. Please see the
 	// RegisterTaggedConstraint and RegisterTaggedConstraintGroup functions
 	// for details on registering such elements.
@@ -152,6 +212,12 @@ type Options struct {
 	// Default value to apply to the SEQUENCE field. Please see the
 	// RegisterDefaultValue function for details on registration and
 	// the LookupDefaultValue function for looking-up such elements.
+	//
+	// A field whose value equals its DEFAULT is omitted during encoding,
+	// per X.690 clause 11.5, and the DEFAULT is assigned to the field
+	// during decoding should the field be absent. Native Go values are
+	// compared to the DEFAULT by value, e.g.: an int field equal to an
+	// [Integer] DEFAULT of the same magnitude is omitted.
 	Default any
 
 	// Children allows the nesting of *Options instances for a given struct
@@ -165,9 +231,12 @@ type Options struct {
 
 	tag, // if non-nil, indicates an alternative tag number.
 	class *int // represents the ASN.1 class: universal, application, context-specific, or private.
+	cphase         *int     // if non-nil, overrides the constraint phase of all codecs
+	size           *[2]int  // if non-nil, SIZE(min..max) of a SET OF or SEQUENCE OF; max < 0 is MAX
 	depth          int      // recursion depth
 	borrowed       bool     // options came from sync.Pool?
 	defaultKeyword string   // the discovered DEFAULT keyword for registered lookup
+	enumKeyword    string   // the registered ENUMERATED name, if any
 	unidentified   []string // for unidentified or superfluous keywords
 }
 
@@ -196,6 +265,11 @@ func addStringConfigValue(dst *[]string, cond bool, val string) {
 	}
 }
 
+/*
+stringifyDefault returns the string representation of DEFAULT value d.
+Any [Primitive] -- such as [ObjectIdentifier], [OctetString] or [Enumerated]
+-- is rendered by way of its String method, as are native integers.
+*/
 func stringifyDefault(d any) string {
 	switch v := d.(type) {
 	case nil:
@@ -206,11 +280,28 @@ func stringifyDefault(d any) string {
 		return bool2str(v)
 	case Integer:
 		return v.String()
+	case int:
+		return itoa(v)
+	case Primitive:
+		return v.String()
 	default:
 		return "unstringable-value"
 	}
 }
 
+/*
+isLiteralDefault returns a Boolean value indicative of whether DEFAULT
+value d may be declared literally via "default:<value>", as opposed to
+via the default registry (i.e.: "default::<name>").
+*/
+func isLiteralDefault(d any) (is bool) {
+	switch d.(type) {
+	case string, bool, Integer:
+		is = true
+	}
+	return
+}
+
 func (r *Options) copyDepth(o *Options) {
 	if r != nil && o != nil {
 		r.depth = o.depth
@@ -223,6 +314,18 @@ func (r *Options) incDepth() {
 	}
 }
 
+/*
+checkDepth returns an error if the recursion depth of the receiver
+exceeds [MaxDecodeDepth].
+*/
+func (r *Options) checkDepth() (err error) {
+	if r != nil && MaxDecodeDepth > 0 && r.depth > MaxDecodeDepth {
+		err = errorMaxDecodeDepth
+	}
+
+	return
+}
+
 /*
 Header returns the class/tag header byte. This method is exported
 solely for debugging or troubleshooting convenience and generally
@@ -263,6 +366,7 @@ func (r Options) String() string {
 	addStringConfigValue(&parts, r.Automatic, "automatic")
 	addStringConfigValue(&parts, r.Set, "set")
 	addStringConfigValue(&parts, r.Sequence, "sequence")
+	addStringConfigValue(&parts, r.HasSize(), r.sizeString())
 
 	for _, c := range r.Constraints {
 		parts = append(parts, "constrained-by:"+c)
@@ -282,14 +386,119 @@ func (r Options) String() string {
 
 	addStringConfigValue(&parts, r.Extension, "...")
 	addStringConfigValue(&parts, r.ComponentsOf, "components-of")
+	addStringConfigValue(&parts, r.Raw, "raw")
 
-	addStringConfigValue(&parts, r.Identifier != "", lc(r.Identifier))
+	addStringConfigValue(&parts, len(r.EnumNames) > 0, r.enumString())
+	addStringConfigValue(&parts, r.Identifier != "" && len(r.EnumNames) == 0, lc(r.Identifier))
 	addStringConfigValue(&parts, r.Choices != "", "choices:"+lc(r.Choices))
+	addStringConfigValue(&parts, r.Name != "", "name:"+r.Name)
 
 	return join(parts, ",")
 }
 
-func (r Options) defaultEquals(x any) bool { return deepEq(r.Default, x) }
+/*
+TagString returns the struct tag representation of the receiver instance,
+e.g.: "tag:3,explicit,optional". Unlike [Options.String], the return value
+is guaranteed to be accepted by [NewOptions], which shall reproduce the
+receiver instance. This is useful for tooling which rewrites struct tags.
+
+Note that values which cannot be expressed within a struct tag, such as
+Children, values containing commas and DEFAULT values other than those of
+a registered keyword, [Integer], bool or string, are not represented.
+*/
+func (r Options) TagString() string {
+	var parts []string
+
+	// tag:N implies CONTEXT SPECIFIC, so any other
+	// class must follow it in order to take effect.
+	addStringConfigValue(&parts, r.HasTag(), "tag:"+itoa(r.Tag()))
+	if r.HasTag() {
+		addStringConfigValue(&parts, r.Class() != ClassContextSpecific, lc(ClassNames[r.Class()]))
+	} else {
+		addStringConfigValue(&parts, r.Class() != ClassUniversal, lc(ClassNames[r.Class()]))
+	}
+
+	addStringConfigValue(&parts, r.Explicit, "explicit")
+	addStringConfigValue(&parts, r.Optional, "optional")
+	addStringConfigValue(&parts, r.Absent, "absent")
+	addStringConfigValue(&parts, r.Automatic, "automatic")
+	addStringConfigValue(&parts, r.Set, "set")
+	addStringConfigValue(&parts, r.Sequence, "sequence")
+	addStringConfigValue(&parts, r.HasSize(), r.sizeString())
+	addStringConfigValue(&parts, r.Indefinite, "indefinite")
+	addStringConfigValue(&parts, r.OmitEmpty, "omitempty")
+	addStringConfigValue(&parts, r.Extension, "...")
+	addStringConfigValue(&parts, r.ComponentsOf, "components-of")
+	addStringConfigValue(&parts, r.Raw, "raw")
+
+	for _, c := range r.Constraints {
+		parts = append(parts, "constrained-by:"+c)
+	}
+	for _, wc := range r.WithComponents {
+		parts = append(parts, "with-components:"+wc)
+	}
+
+	if r.defaultKeyword != "" {
+		parts = append(parts, "default::"+r.defaultKeyword)
+	} else if def := stringifyDefault(r.Default); def != "" && isLiteralDefault(r.Default) {
+		parts = append(parts, "default:"+def)
+	}
+
+	addStringConfigValue(&parts, r.Identifier != "" && len(r.EnumNames) == 0, lc(r.Identifier))
+	addStringConfigValue(&parts, r.Choices != "", "choices:"+r.Choices)
+	addStringConfigValue(&parts, r.Name != "", "name:"+r.Name)
+
+	// ENUMERATED members consume all subsequent
+	// "name=value" tokens, so they must come last.
+	addStringConfigValue(&parts, len(r.EnumNames) > 0, r.enumString())
+
+	return join(parts, ",")
+}
+
+/*
+defaultEquals returns a Boolean value indicative of whether x, which is
+the value of a SEQUENCE or SET field, equals the DEFAULT value of the
+receiver instance. Such values are omitted from the encoding, as is
+mandated by [CER] and [DER] and permitted by [BER].
+*/
+func (r Options) defaultEquals(x any) (eq bool) {
+	if eq = deepEq(r.Default, x); eq || r.Default == nil || x == nil {
+		return
+	}
+
+	xv := refValueOf(x)
+	if xv.Kind() == reflect.Ptr {
+		if xv.IsNil() {
+			return
+		}
+		xv = xv.Elem()
+		x = xv.Interface()
+	}
+
+	// The DEFAULT may be represented differently from the field
+	// value, e.g.: an Integer default (via "default:0") for an int
+	// field, or a bool default for a Boolean field.
+	switch def := r.Default.(type) {
+	case Integer:
+		switch xv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			eq = def.cmpInt64(xv.Int()) == 0
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			eq = def.cmpUint64(xv.Uint()) == 0
+		default:
+			if i, ok := x.(Integer); ok {
+				eq = cmpInteger(def, i) == 0
+			}
+		}
+	default:
+		dv := refValueOf(def)
+		if dv.Kind() == xv.Kind() && xv.Type().ConvertibleTo(dv.Type()) {
+			eq = deepEq(def, xv.Convert(dv.Type()).Interface())
+		}
+	}
+
+	return
+}
 
 /*
 NewOptions returns a new instance of [Options] alongside an error
@@ -300,6 +509,11 @@ The syntax of tag is the same as [encoding/asn1], e.g.:
 	asn1:"application"
 	asn1:"tag:4,explicit"
 
+The class may also be declared numerically using "class:N", where N is
+one of [ClassUniversal] (0) through [ClassPrivate] (3), e.g.:
+
+	asn1:"class:3,tag:7"
+
 This function exists solely for diagnostic or templating purposes,
 and generally need not be leveraged by the end user.
 
@@ -352,10 +566,34 @@ func parseOptions(tagStr string) (opts Options, err error) {
 	tagStr = trim(tagStr, `"`)
 	tokens := split(tagStr, ",")
 
+	var enumList bool
 	for _, raw := range tokens {
 		token := trimS(raw)
 
+		// ENUMERATED members continue until the first
+		// token which is neither "name=value" nor "...".
+		enumList = enumList && (token == "..." || cntns(token, "="))
+
 		switch {
+		case enumList:
+			if err = po.setEnumMember(token); err != nil {
+				goto Done
+			}
+
+		case hasPfx(token, "enum::"):
+			enumList = true
+			po.Identifier = "enum"
+			if err = po.setEnumRegistration(trimPfx(token, "enum::")); err != nil {
+				goto Done
+			}
+
+		case hasPfx(token, "enum:"):
+			enumList = true
+			po.Identifier = "enum"
+			if err = po.setEnumMember(trimPfx(token, "enum:")); err != nil {
+				goto Done
+			}
+
 		case hasPfx(token, "tag:"):
 			numStr := trimPfx(token, "tag:")
 			n, convErr := atoi(numStr)
@@ -365,6 +603,20 @@ func parseOptions(tagStr string) (opts Options, err error) {
 			}
 			po.SetTag(n)
 
+		case hasPfx(token, "size:"):
+			if err = po.parseOptionSize(trimPfx(token, "size:")); err != nil {
+				goto Done
+			}
+
+		case hasPfx(token, "class:"):
+			numStr := trimPfx(token, "class:")
+			n, convErr := atoi(numStr)
+			if convErr != nil || !(ClassUniversal <= n && n <= ClassPrivate) {
+				err = optionsErrorf("invalid class number ", numStr)
+				goto Done
+			}
+			po.SetClass(n)
+
 		case isBoolKeyword(token):
 			po.setBool(token)
 
@@ -378,6 +630,9 @@ func parseOptions(tagStr string) (opts Options, err error) {
 		case hasPfx(token, "choices:"):
 			po.Choices = trimPfx(token, "choices:")
 
+		case hasPfx(token, "name:"):
+			po.Name = trimPfx(token, "name:")
+
 		case hasPfx(token, "default:"):
 			po.parseOptionDefault(token)
 
@@ -400,6 +655,116 @@ Done:
 	return out, err
 }
 
+/*
+setEnumMember parses a single "name=value" ENUMERATED member, or the
+"..." extensibility marker, into the receiver instance.
+*/
+func (r *Options) setEnumMember(token string) (err error) {
+	if token == "..." {
+		r.EnumExtensible = true
+		return
+	}
+
+	idx := stridxb(token, '=')
+	if idx <= 0 {
+		err = optionsErrorf("invalid ENUMERATED member ", token)
+		return
+	}
+
+	name := trimS(token[:idx])
+	var n int
+	if n, err = atoi(trimS(token[idx+1:])); err != nil {
+		err = optionsErrorf("invalid ENUMERATED value for ", name)
+		return
+	}
+
+	return r.addEnumMember(name, n)
+}
+
+/*
+addEnumMember adds the ENUMERATED member name, bearing value n, to the
+receiver instance. An error is returned if name, or n, is already in use
+by another member, as either would render the mapping ambiguous.
+*/
+func (r *Options) addEnumMember(name string, n int) (err error) {
+	if _, dup := r.EnumNames[name]; dup {
+		err = optionsErrorf("duplicate ENUMERATED member ", name)
+		return
+	} else if other, dup := inlineEnumName(r.EnumNames, n); dup {
+		err = optionsErrorf("duplicate ENUMERATED value ", n, " for ", other, " and ", name)
+		return
+	}
+
+	if r.EnumNames == nil {
+		r.EnumNames = make(map[string]int)
+	}
+	r.EnumNames[name] = n
+
+	return
+}
+
+/*
+setEnumRegistration copies the members of the named enumeration, as
+registered via [RegisterEnumeration] or [RegisterExtensibleEnumeration],
+into the receiver instance.
+*/
+func (r *Options) setEnumRegistration(name string) (err error) {
+	var en enumeration
+	if en, err = lookupEnumeration(name); err != nil {
+		return
+	}
+
+	if r.EnumNames == nil {
+		r.EnumNames = make(map[string]int, len(en.members))
+	}
+	for member, n := range en.members {
+		if err = r.addEnumMember(member, n); err != nil {
+			return
+		}
+	}
+	r.EnumExtensible = r.EnumExtensible || en.extensible
+	r.enumKeyword = lc(name)
+
+	return
+}
+
+/*
+enumString returns the "enum:..." string representation of the ENUMERATED
+members within the receiver instance, ordered by value.
+*/
+func (r Options) enumString() string {
+	if r.enumKeyword != "" {
+		ext := ""
+		if r.EnumExtensible {
+			ext = ",..."
+		}
+		return "enum::" + r.enumKeyword + ext
+	}
+
+	names := make([]string, 0, len(r.EnumNames))
+	for name := range r.EnumNames {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) (c int) {
+		switch x, y := r.EnumNames[a], r.EnumNames[b]; {
+		case x < y || (x == y && a < b):
+			c = -1
+		case x > y || (x == y && a > b):
+			c = 1
+		}
+		return
+	})
+
+	for i, name := range names {
+		names[i] = name + "=" + itoa(r.EnumNames[name])
+	}
+	if r.EnumExtensible {
+		names = append(names, "...")
+	}
+
+	return "enum:" + join(names, ",")
+}
+
 func isWithComponents(token string) bool {
 	return hasPfx(token, `with-component`)
 }
@@ -433,6 +798,8 @@ func (r *Options) setBool(name string) {
 		r.Extension = true
 	case name == "components-of":
 		r.ComponentsOf = true
+	case name == "raw":
+		r.Raw = true
 	case name == "set":
 		r.Set = true
 		r.Sequence = false
@@ -528,7 +895,90 @@ func swapAlias(alias string) (token string) {
 	return
 }
 
-func extractOptions(field reflect.StructField, fieldNum int, automatic bool) (opts *Options, err error) {
+/*
+extractOptions returns an *[Options] instance parsed from the "asn1" struct
+tag of field, alongside an error.
+
+If auto is autoTagsOn, a field lacking a tag is assigned fieldNum as its
+context-specific tag, as with the AUTOMATIC TAGS module default defined
+in ITU-T Rec. X.680. Interface fields, such as CHOICEs, are exempt when
+untagged, as their alternatives bear their own tags. Unless auto is
+autoTagsOff, the AUTOMATIC state is propagated to fields of a SEQUENCE
+or SET (struct) type, or a SEQUENCE OF or SET OF such types, such that
+their own components are tagged likewise, at any depth. See also
+[automaticTagging].
+
+Successfully parsed options are cached within fieldOptionsCache, such
+that each distinct field is parsed but once per registry change. The caller receives its own
+copy, which it may modify freely, save for any maps or slices within, as
+these are shared with the cached instance and must be treated as
+read-only.
+*/
+func extractOptions(field reflect.StructField, fieldNum int, auto autoTagging) (opts *Options, err error) {
+	key := fieldOptionsKey{typ: field.Type, tag: field.Tag, num: fieldNum, auto: auto}
+	if cached, found := fieldOptionsCache.Load(key); found {
+		o := *cached.(*Options)
+		opts = &o
+		return
+	}
+
+	if opts, err = parseFieldOptions(field, fieldNum, auto); err == nil {
+		// Never cache a pooled instance; store a detached copy.
+		o := *opts
+		o.borrowed = false
+		fieldOptionsCache.Store(key, &o)
+	}
+
+	return
+}
+
+/*
+autoTagging describes the manner in which the AUTOMATIC TAGS module default
+applies to the components of a SEQUENCE or SET.
+*/
+type autoTagging uint8
+
+const (
+	autoTagsOff      autoTagging = iota // no automatic tagging
+	autoTagsOn                          // untagged components are tagged automatically
+	autoTagsInherent                    // components are tagged as written; nested types are still tagged automatically
+)
+
+/*
+automaticTagging returns the [autoTagging] state which applies to the
+components described by fields, given the parent opts.
+
+Per ITU-T Rec. X.680 §25.3, automatic tagging is not applied to the
+components of a SEQUENCE or SET if any component within its extension
+root already bears a tag. In such a case, autoTagsInherent is returned,
+as the module default still applies to any nested SEQUENCE or SET type.
+*/
+func automaticTagging(opts *Options, fields []reflect.StructField) (auto autoTagging) {
+	if !optsIsAutoTag(opts) {
+		return
+	}
+
+	auto = autoTagsOn
+	for i := 0; i < len(fields); i++ {
+		if sf := fields[i]; sf.PkgPath == "" && sf.Type != rawContentType {
+			if fOpts, err := extractOptions(sf, i, autoTagsOff); err != nil || fOpts.Extension {
+				break
+			} else if fOpts.HasTag() {
+				auto = autoTagsInherent
+				break
+			}
+		}
+	}
+
+	return
+}
+
+/*
+parseFieldOptions implements the uncached parsing performed on behalf
+of [extractOptions].
+*/
+func parseFieldOptions(field reflect.StructField, fieldNum int, auto autoTagging) (opts *Options, err error) {
+	automatic := auto == autoTagsOn
 	if tagStr, ok := field.Tag.Lookup("asn1"); ok {
 		var parsedOpts Options
 		if parsedOpts, err = parseOptions(tagStr); err != nil {
@@ -548,6 +998,19 @@ func extractOptions(field reflect.StructField, fieldNum int, automatic bool) (op
 		}
 	} else {
 		opts = implicitOptions()
+		if automatic && (field.Type == nil || field.Type.Kind() != reflect.Interface) {
+			opts.SetTag(fieldNum)
+		}
+	}
+
+	if auto != autoTagsOff && field.Type != nil {
+		t := derefTypePtr(field.Type)
+		if t.Kind() == reflect.Slice {
+			t = derefTypePtr(t.Elem()) // SEQUENCE OF, SET OF
+		}
+		if t.Kind() == reflect.Struct {
+			opts.Automatic = true
+		}
 	}
 
 	return
@@ -604,6 +1067,142 @@ func (r *Options) SetClass(n int) *Options {
 	return r
 }
 
+/*
+SetSize assigns an element count constraint to the receiver instance,
+thereby restricting the SET OF or SEQUENCE OF with which the receiver
+is associated to no fewer than minimum and no more than maximum elements,
+e.g.: "SET SIZE(1..4) OF OCTET STRING". A negative maximum is interpreted
+as MAX, i.e.: no upper bound. The constraint is enforced by both [Marshal]
+and [Unmarshal].
+
+Note that this can be declared textually via the "size:<min>..<max>"
+key:value expression during field parsing, e.g.: "size:1..4", "size:1..MAX"
+or, for a fixed count, "size:3".
+
+This is a fluent method.
+*/
+func (r *Options) SetSize(minimum, maximum int) *Options {
+	if maximum < 0 {
+		maximum = -1
+	}
+	if minimum >= 0 && (maximum < 0 || minimum <= maximum) {
+		r.size = &[2]int{minimum, maximum}
+	}
+	return r
+}
+
+/*
+HasSize returns a Boolean value indicative of an element count
+constraint being set within the receiver instance.
+*/
+func (r Options) HasSize() bool { return r.size != nil }
+
+/*
+Size returns the minimum and maximum element counts residing within
+the receiver instance. A maximum of -1 indicates MAX. If unset, zero
+(0) and -1 are returned.
+*/
+func (r Options) Size() (minimum, maximum int) {
+	minimum, maximum = 0, -1
+	if r.size != nil {
+		minimum, maximum = r.size[0], r.size[1]
+	}
+	return
+}
+
+/*
+parseOptionSize returns an error following an attempt to parse the
+"<min>..<max>" or "<n>" expression of a "size:" token.
+*/
+func (r *Options) parseOptionSize(expr string) (err error) {
+	lo, hi, ranged := cut(expr, "..")
+	if !ranged {
+		hi = lo
+	}
+
+	minimum, lerr := atoi(lo)
+	maximum, herr := -1, error(nil)
+	if hi != "MAX" {
+		maximum, herr = atoi(hi)
+	}
+
+	if lerr != nil || herr != nil || minimum < 0 ||
+		(hi != "MAX" && (maximum < 0 || minimum > maximum)) {
+		err = errorBadSize(expr)
+	} else {
+		r.SetSize(minimum, maximum)
+	}
+
+	return
+}
+
+/*
+sizeRange returns the "<min>..<max>" representation of the element
+count constraint residing within the receiver instance.
+*/
+func (r Options) sizeRange() string {
+	minimum, maximum := r.Size()
+	hi := "MAX"
+	if maximum >= 0 {
+		hi = itoa(maximum)
+	}
+	return itoa(minimum) + ".." + hi
+}
+
+func (r Options) sizeString() string { return "size:" + r.sizeRange() }
+
+/*
+checkSize returns an error if n, the number of elements within a SET OF
+or SEQUENCE OF, violates the element count constraint of the receiver.
+*/
+func (r *Options) checkSize(n int) (err error) {
+	if r != nil && r.size != nil {
+		if minimum, maximum := r.Size(); n < minimum || (maximum >= 0 && n > maximum) {
+			err = constraintViolationf("SIZE(", r.sizeRange(),
+				") violated by element count ", n)
+		}
+	}
+	return
+}
+
+/*
+SetConstraintPhase assigns phase to the receiver instance, thereby
+overriding the constraint phase of every codec engaged during the
+[Marshal] or [Unmarshal] operation to which the receiver instance is
+submitted, e.g.: [CodecConstraintNone] to skip constraints entirely
+when decoding input from a trusted source.
+
+Unlike the package-level variables, such as [OctetStringConstraintPhase],
+this affects only the operation in question and is thus safe for use in
+concurrent code. phase MUST be within the bounds of [CodecConstraintNone]
+and [CodecConstraintBoth], else it is ignored.
+
+This is a fluent method.
+*/
+func (r *Options) SetConstraintPhase(phase int) *Options {
+	if CodecConstraintNone <= phase && phase <= CodecConstraintBoth {
+		r.cphase = &phase
+	}
+	return r
+}
+
+/*
+constraintPhase returns the constraint phase override residing within
+the receiver instance, else dflt if unset.
+*/
+func (r *Options) constraintPhase(dflt int) int {
+	if r != nil && r.cphase != nil {
+		dflt = *r.cphase
+	}
+	return dflt
+}
+
+func (r *Options) copyPhase(o *Options) {
+	if r != nil && o != nil && o.cphase != nil {
+		r.cphase = o.cphase
+	}
+}
+
 /*
 HasClass returns a Boolean value indicative of a class being
 set within the receiver instance.
@@ -642,6 +1241,7 @@ func clearChildOpts(o *Options) (c *Options) {
 		// remove per-field overrides
 		c.tag = nil
 		c.class = nil
+		c.size = nil
 		c.Explicit = false
 	}
 
@@ -663,6 +1263,35 @@ func optsIsOmit(o *Options) bool     { return o != nil && o.OmitEmpty }
 
 var optPool = sync.Pool{New: func() any { return &Options{} }}
 
+/*
+fieldOptionsKey identifies a struct field for the purpose of caching the
+[Options] parsed from its "asn1" struct tag. The field name is omitted, as
+it does not influence the result. Struct types are immutable, thus cached
+entries are never invalidated.
+*/
+type fieldOptionsKey struct {
+	typ  reflect.Type
+	tag  reflect.StructTag
+	num  int
+	auto autoTagging
+}
+
+/*
+fieldOptionsCache maps instances of fieldOptionsKey to the *[Options]
+produced by [extractOptions], and is safe for concurrent use.
+
+As parsed options embed the results of registry lookups (e.g.: of the
+"enum::" and "default::" forms), the cache is cleared by way of
+forgetFieldOptions whenever such a registry changes.
+*/
+var fieldOptionsCache sync.Map
+
+/*
+forgetFieldOptions clears fieldOptionsCache, such that struct tags are
+parsed anew against the current state of all registries.
+*/
+func forgetFieldOptions() { fieldOptionsCache.Clear() }
+
 func borrowOptions() (o *Options) {
 	o = optPool.Get().(*Options)
 	o.borrowed = true
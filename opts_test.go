@@ -3,6 +3,7 @@ package asn1plus
 import (
 	"fmt"
 	"reflect"
+	"sync"
 	"testing"
 )
 
@@ -82,7 +83,7 @@ func TestOptions_codecov(_ *testing.T) {
 	_ = opts.String()
 	field := reflect.StructField{Tag: "blarg"}
 	_, _ = NewOptions("asn1:")
-	_, _ = extractOptions(field, 0, false)
+	_, _ = extractOptions(field, 0, autoTagsOff)
 	_ = defaultOptions()
 
 	opts.Constraints = []string{`fakeConstraint`}
@@ -92,5 +93,185 @@ func TestOptions_codecov(_ *testing.T) {
 	_ = opts.String()
 	opts.parseOptionDefault("")
 	field = reflect.StructField{Name: "field", Tag: `asn1:"automatic,explicit"`}
-	extractOptions(field, 0, true)
+	extractOptions(field, 0, autoTagsOn)
+}
+
+func TestOptions_TagString(t *testing.T) {
+	RegisterDefaultValue("tagStringDefault", Boolean(true))
+	defer UnregisterDefaultValue("tagStringDefault")
+
+	tagStrs := []string{
+		`tag:3,explicit,optional`,
+		`tag:4,universal`,
+		`tag:7,application,explicit`,
+		`private`,
+		`application,set,omitempty`,
+		`tag:0,private,sequence,indefinite`,
+		`optional,absent,automatic,...`,
+		`components-of`,
+		`tag:2,raw,optional`,
+		`set,size:1..4`,
+		`sequence,size:2..MAX`,
+		`constrained-by:^upperOnly,constrained-by:$lowerOnly,constrained-by:both`,
+		`with-components:rule1,with-components:rule2`,
+		`choices:MyChoices,tag:2,explicit`,
+		`default:5`,
+		`default:-12,optional`,
+		`default:true`,
+		`default:plainText`,
+		`default::tagStringDefault`,
+		`name:commonName`,
+		`enum:red=0,green=1,blue=2,...`,
+		`tag:1,optional,enum:low=-1,high=1`,
+	}
+	// adapter keywords are absent under asn1_no_adapter_pf, while
+	// deprecated ones are also absent under asn1_no_dprc
+	if isAdapterKeyword("printable") {
+		tagStrs = append(tagStrs, `printable,name:commonName`)
+	}
+	if isAdapterKeyword("teletex") {
+		tagStrs = append(tagStrs, `teletex`)
+	}
+
+	for idx, raw := range tagStrs {
+		want, err := parseOptions(raw)
+		if err != nil {
+			t.Fatalf("%s[%d] failed [parse %q]: %v", t.Name(), idx, raw, err)
+		}
+
+		tagStr := want.TagString()
+		got, err := parseOptions(tagStr)
+		if err != nil {
+			t.Fatalf("%s[%d] failed [reparse %q]: %v", t.Name(), idx, tagStr, err)
+		} else if !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s[%d] failed [%q -> %q]:\n\twant: %#v\n\tgot:  %#v",
+				t.Name(), idx, raw, tagStr, want, got)
+		}
+	}
+
+	opts := Options{Explicit: true}
+	opts.SetClass(ClassApplication).SetTag(11)
+	if got := opts.TagString(); got != `tag:11,application,explicit` {
+		t.Fatalf("%s failed: want %q, got %q", t.Name(), `tag:11,application,explicit`, got)
+	}
+}
+
+func TestOptions_classNumber(t *testing.T) {
+	type Private struct {
+		Num Integer `asn1:"class:3,tag:7"`
+	}
+
+	num, _ := NewInteger(5)
+	for _, rule := range encodingRules {
+		pkt, err := Marshal(Private{Num: num}, With(rule))
+		if err != nil {
+			t.Fatalf("%s failed [%s marshal]: %v", t.Name(), rule, err)
+		}
+
+		want := []byte{0x30, 0x03, 0xC7, 0x01, 0x05}
+		if got := pkt.Data(); !btseq(got, want) {
+			t.Fatalf("%s failed [%s encoding]:\n\twant: %X\n\tgot:  %X", t.Name(), rule, want, got)
+		}
+
+		var out Private
+		if err = Unmarshal(pkt, &out); err != nil {
+			t.Fatalf("%s failed [%s unmarshal]: %v", t.Name(), rule, err)
+		} else if !out.Num.Eq(num) {
+			t.Fatalf("%s failed [%s unmarshal]: want %s, got %s", t.Name(), rule, num, out.Num)
+		}
+	}
+
+	byNum, _ := NewOptions(`asn1:"class:1,tag:5"`)
+	byKW, _ := NewOptions(`asn1:"application,tag:5"`)
+	if !reflect.DeepEqual(byNum, byKW) {
+		t.Fatalf("%s failed: want %#v, got %#v", t.Name(), byKW, byNum)
+	}
+
+	for _, bogus := range []string{`class:4`, `class:-1`, `class:x,tag:1`} {
+		if _, err := NewOptions(bogus); err == nil {
+			t.Fatalf("%s failed: expected error for %q, got nil", t.Name(), bogus)
+		}
+	}
+}
+
+func TestExtractOptions_cache(t *testing.T) {
+	type Cached struct {
+		Name  PrintableString `asn1:"tag:1,optional"`
+		Plain Integer
+	}
+
+	typ := reflect.TypeOf(Cached{})
+	fields := structFields(typ)
+	if again := structFields(typ); &again[0] != &fields[0] {
+		t.Fatalf("%s failed: struct fields were not cached", t.Name())
+	}
+
+	for i := 0; i < len(fields); i++ {
+		want, err := parseFieldOptions(fields[i], i, autoTagsOff)
+		if err != nil {
+			t.Fatalf("%s[%d] failed: %v", t.Name(), i, err)
+		}
+
+		// first call populates the cache, the second reads from it
+		first, _ := extractOptions(fields[i], i, autoTagsOff)
+		first.SetTag(9).Explicit = true
+		first.incDepth()
+
+		second, err := extractOptions(fields[i], i, autoTagsOff)
+		if err != nil {
+			t.Fatalf("%s[%d] failed: %v", t.Name(), i, err)
+		} else if second == first {
+			t.Fatalf("%s[%d] failed: cached instance returned as-is", t.Name(), i)
+		} else if second.borrowed {
+			t.Fatalf("%s[%d] failed: pooled instance cached", t.Name(), i)
+		} else if second.Tag() != want.Tag() || second.Class() != want.Class() ||
+			second.Explicit || second.Optional != want.Optional || second.depth != 0 {
+			t.Fatalf("%s[%d] failed: cached options altered\n\twant: %s\n\tgot:  %s",
+				t.Name(), i, want, second)
+		}
+	}
+
+	// concurrent readers receive equivalent, independent copies
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				o, err := extractOptions(fields[0], 0, autoTagsOn)
+				if err != nil || o.Tag() != 1 || !o.Optional {
+					t.Errorf("%s failed [concurrent]: %v %v", t.Name(), o, err)
+					return
+				}
+				o.incDepth()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkExtractOptions(b *testing.B) {
+	type Record struct {
+		Name  PrintableString `asn1:"tag:1,optional,constrained-by:x"`
+		Value Integer         `asn1:"application,tag:5,default:100"`
+	}
+	field := reflect.TypeOf(Record{}).Field(1)
+
+	b.Run("uncached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := parseFieldOptions(field, 1, autoTagsOff); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := extractOptions(field, 1, autoTagsOff); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
 }
@@ -6,6 +6,7 @@ abstraction interface known as Packet.
 */
 
 import (
+	"encoding/json"
 	"io"
 	"sync"
 )
@@ -69,8 +70,8 @@ type PDU interface {
 	// instance into the io.Writer.
 	//
 	// The variadic integer value defines the maximum number of characters
-	// displayed per line before the value is wrapped. The default is 24,
-	// and can be configured no less than 16.
+	// displayed per line before the value is wrapped. The default width
+	// is DefaultDumpWidth, and may be no less than MinDumpWidth.
 	Dump(io.Writer, ...int) error
 
 	// Offset returns the integer "cursor" position currently set
@@ -143,6 +144,56 @@ func (_ invalidPacket) PeekTLV() (TLV, error)            { return TLV{}, errorIn
 func (_ invalidPacket) WriteTLV(_ TLV) error             { return errorInvalidPacket }
 func (_ invalidPacket) TLV() (TLV, error)                { return TLV{}, errorInvalidPacket }
 
+/*
+ReadOnly returns a read-only view of pkt, suitable for submission to code
+which should not be trusted to modify the underlying buffer, such as a
+plugin.
+
+The view is backed by a private copy of the buffer, thus changes made to
+pkt after the fact are not reflected, and vice versa. Read methods, such
+as Bytes, TLV, Hex and Dump, work normally, as does [Walk]. The view
+maintains its own offset, thus calls of SetOffset, AddOffset and TLV
+affect only the view. Append and Free are no-ops, and WriteTLV returns
+an error. Data, Bytes and FullBytes return fresh copies upon each call.
+
+If pkt is nil, invalid or already read-only, it is returned as-is.
+*/
+func ReadOnly(pkt PDU) PDU {
+	switch pkt.(type) {
+	case nil, invalidPacket, readOnlyPacket:
+		return pkt
+	}
+
+	cp := pkt.Type().New(pkt.Data()...)
+	cp.SetOffset(pkt.Offset())
+	if tn, ok := pkt.(tagNamer); ok {
+		if cn, ok := cp.(tagNamer); ok {
+			cn.setTagNames(tn.tagNames())
+		}
+	}
+
+	return readOnlyPacket{cp}
+}
+
+/*
+readOnlyPacket wraps a private copy of a [PDU], disabling all operations
+which would otherwise modify the underlying buffer. See [ReadOnly].
+*/
+type readOnlyPacket struct {
+	PDU
+}
+
+func (r readOnlyPacket) Data() []byte               { return append([]byte(nil), r.PDU.Data()...) }
+func (r readOnlyPacket) Append(_ ...byte)           {}
+func (r readOnlyPacket) WriteTLV(_ TLV) error       { return errorReadOnlyPDU }
+func (r readOnlyPacket) Free()                      {}
+func (r readOnlyPacket) Bytes() ([]byte, error)     { return readOnlyCopy(r.PDU.Bytes()) }
+func (r readOnlyPacket) FullBytes() ([]byte, error) { return readOnlyCopy(r.PDU.FullBytes()) }
+
+func readOnlyCopy(b []byte, err error) ([]byte, error) {
+	return append([]byte(nil), b...), err
+}
+
 func setPacketOffset(pkt PDU, offset ...int) (off int) {
 	if len(offset) > 0 {
 		if offset[0] == -1 && pkt.Len() > 1 {
@@ -157,6 +208,19 @@ func setPacketOffset(pkt PDU, offset ...int) (off int) {
 	return
 }
 
+/*
+Remaining returns the integer number of bytes within pkt which follow its
+current offset position, e.g.: any trailing bytes left over following the
+decoding of a top-level value. Zero (0) is returned if pkt is nil.
+*/
+func Remaining(pkt PDU) (n int) {
+	if pkt != nil {
+		n = max(pkt.Len()-pkt.Offset(), 0)
+	}
+
+	return
+}
+
 func incPacketOffset(pkt PDU, n int) (off int) {
 	var _off int
 	switch {
@@ -176,6 +240,88 @@ func incPacketOffset(pkt PDU, n int) (off int) {
 	return
 }
 
+/*
+outputLimiter is implemented by [PDU] qualifiers which support the
+enforcement of a maximum encoded size, as set by [MaxOutputSize].
+*/
+type outputLimiter interface {
+	outputLimit() int
+	setOutputLimit(int)
+}
+
+/*
+tagNamer is implemented by [PDU] qualifiers which support custom tag
+names within Dump output, as set by [DumpTagNames].
+*/
+type tagNamer interface {
+	tagNames() map[[2]int]string
+	setTagNames(map[[2]int]string)
+}
+
+/*
+pduResetter is implemented by [PDU] qualifiers whose content can be
+discarded while retaining the underlying buffer, allowing an [Encoder]
+to reuse a single instance across many operations.
+*/
+type pduResetter interface {
+	reset()
+}
+
+/*
+lenPacket implements a length-only [PDU] for use by [EncodedLen]. Bytes
+appended to the top level of an encoding are counted and discarded, rather
+than retained. Nested content is still assembled within pooled sub-packets,
+as its length must be known before its enclosing header may be written.
+
+All other behavior, such as the encoding rule and any output limit, is that
+of the embedded [PDU].
+*/
+type lenPacket struct {
+	PDU
+	n int
+}
+
+func (r *lenPacket) Append(data ...byte)    { r.n += len(data) }
+func (r *lenPacket) Len() int               { return r.n }
+func (r *lenPacket) Data() []byte           { return nil }
+func (r *lenPacket) Offset() int            { return r.n }
+func (r *lenPacket) SetOffset(_ ...int)     {}
+func (r *lenPacket) AddOffset(_ int)        {}
+func (r *lenPacket) HasMoreData() bool      { return false }
+func (r *lenPacket) WriteTLV(tlv TLV) error { return writeTLV(r, tlv, nil) }
+
+/*
+newSubPacket returns a new [PDU] bearing the same encoding rule as pkt,
+for use in the assembly of nested content. Any output limit set within
+pkt is carried over, less the number of bytes already written to pkt.
+*/
+func newSubPacket(pkt PDU) (sub PDU) {
+	sub = pkt.Type().New()
+	debugInheritAnnotations(pkt, sub)
+	if src, ok := pkt.(outputLimiter); ok && src.outputLimit() > 0 {
+		if dst, ok := sub.(outputLimiter); ok {
+			dst.setOutputLimit(max(src.outputLimit()-pkt.Len(), 1))
+		}
+	}
+
+	return
+}
+
+/*
+checkOutputLimit returns an error if the content of pkt, plus pending
+bytes not yet written to pkt, exceeds the output limit of pkt, if set.
+*/
+func checkOutputLimit(pkt PDU, pending int) (err error) {
+	if ol, ok := pkt.(outputLimiter); ok && ol.outputLimit() > 0 {
+		if n := pkt.Len() + pending; n > ol.outputLimit() {
+			err = codecErrorf("maximum output size exceeded: ", n,
+				" bytes written against a budget of ", ol.outputLimit())
+		}
+	}
+
+	return
+}
+
 func formatHex(input any) string {
 	var data []byte
 
@@ -291,6 +437,9 @@ func parseTagIdentifier(b []byte) (tag int, idLen int, err error) {
 // • For DER:  indefinite-length encodings are **rejected** per X.690 §10.1.
 // • For BER:  -1 length means “scan until matching EOC (00 00)”.
 func parseBody(b []byte, off int, typ EncodingRule) ([]byte, error) {
+	if off < 0 || off > len(b) {
+		return nil, errorOutOfBounds
+	}
 	sub := b[off:]
 
 	_, idLen, err := parseTagIdentifier(sub)
@@ -325,6 +474,9 @@ func parseBody(b []byte, off int, typ EncodingRule) ([]byte, error) {
 }
 
 func parseFullBytes(data []byte, off int, typ EncodingRule) ([]byte, error) {
+	if off < 0 || off > len(data) {
+		return nil, errorOutOfBounds
+	}
 	sub := data[off:]
 	if len(sub) == 0 {
 		sub = data
@@ -400,6 +552,19 @@ func findEOC(b []byte) (int, error) {
 	return 0, errorTruncatedContent
 }
 
+/*
+MaxElementLength declares the maximum number of content octets which
+any single element may declare within its length octets. Elements which
+declare a larger length are rejected with an error during decoding, prior
+to any allocation being made on their behalf.
+
+This is useful for servers decoding untrusted input, as a bogus length
+(e.g.: 2GB) is otherwise only caught once the content is found lacking.
+
+A value of zero (0) or less disables the limit, which is the default.
+*/
+var MaxElementLength int
+
 // parseLength parses the length octet(s) that follow an identifier.
 // It returns
 //   - length  –  the content-octet count;
@@ -407,6 +572,7 @@ func findEOC(b []byte) (int, error) {
 //   - lenLen  –  number of bytes that expressed the length.
 //   - err     –  non-nil on malformed encodings.
 //
+// Definite lengths exceeding [MaxElementLength], if set, are rejected.
 // The routine itself applies *no* DER-specific legality checks; callers
 // decide what to do with –1 (indefinite) or with non-minimal long forms.
 // This keeps it reusable for both BER and DER.
@@ -422,7 +588,9 @@ func parseLength(b []byte) (length int, lenLen int, err error) {
 
 	// Short-form  (bit 8 = 0)
 	if first&indefByte == 0 {
-		length = int(first)
+		if length = int(first); MaxElementLength > 0 && length > MaxElementLength {
+			return 0, 0, errorElementTooLarge
+		}
 		return
 	}
 
@@ -447,6 +615,9 @@ func parseLength(b []byte) (length int, lenLen int, err error) {
 	for i := 1; i <= n; i++ {
 		length = (length << 8) | int(b[i])
 	}
+	if MaxElementLength > 0 && length > MaxElementLength {
+		return 0, 0, errorElementTooLarge
+	}
 	lenLen += n
 	return
 }
@@ -484,8 +655,31 @@ var bufPool = sync.Pool{
 	New: func() any { return new([]byte) },
 }
 
-func getBuf() *[]byte  { return bufPool.Get().(*[]byte) }
-func putBuf(p *[]byte) { *p = (*p)[:0]; bufPool.Put(p) }
+/*
+ZeroOnFree controls whether buffers are zeroed in full prior to being
+returned to the package's internal buffer pool, such as when a [PDU] is
+freed. By default, released buffers are merely truncated, meaning their
+former contents linger in memory until overwritten by a later operation.
+
+Enabling this is recommended when processing security-sensitive payloads,
+such as private keys, so that no residual plaintext remains in reusable
+memory. The tradeoff is an additional pass over the full capacity of each
+released buffer, the cost of which grows with the size of the encodings
+being processed.
+
+Note that this does not extend to any copies of the encoded bytes which
+were made by the caller prior to freeing the [PDU].
+*/
+var ZeroOnFree bool
+
+func getBuf() *[]byte { return bufPool.Get().(*[]byte) }
+func putBuf(p *[]byte) {
+	if ZeroOnFree {
+		clear((*p)[:cap(*p)])
+	}
+	*p = (*p)[:0]
+	bufPool.Put(p)
+}
 
 // This is mainly for maintainer convenience in the midst
 // of implementing new encoding rules.
@@ -498,19 +692,40 @@ func panicOnMissingEncodingRuleConstructor(table map[EncodingRule]func(...byte)
 	}
 }
 
+/*
+DefaultDumpWidth and MinDumpWidth define the default and the minimum number
+of content octets displayed per line by the Dump method of a [PDU] before the
+value is wrapped.
+*/
+const (
+	DefaultDumpWidth = 24
+	MinDumpWidth     = 8
+)
+
 func dumpPacket(pkt PDU, w io.Writer, wrapAt ...int) error {
 	pkt.SetOffset(0)
-	width := 24
-
-	if len(wrapAt) > 0 && wrapAt[0] > 15 {
+	width := DefaultDumpWidth
+	if len(wrapAt) > 0 {
+		if wrapAt[0] < MinDumpWidth {
+			return errorBadDumpWidth(wrapAt[0])
+		}
 		width = wrapAt[0]
 	}
 
-	return dumpLevel(w, pkt.Type(), pkt.Data(), 0, width)
+	var names map[[2]int]string
+	if tn, ok := pkt.(tagNamer); ok {
+		names = tn.tagNames()
+	}
+
+	labels := debugFieldLabels(pkt)
+	return dumpLevel(w, pkt.Type(), pkt.Data(), 0, 0, width, labels, names)
 }
 
-func dumpLevel(w io.Writer, rule EncodingRule, data []byte, depth, width int) error {
+func dumpLevel(w io.Writer, rule EncodingRule, data []byte, base, depth, width int, labels map[int]string, names map[[2]int]string) error {
 	resolveTagName := func(class, tag int) string {
+		if name, ok := names[[2]int{class, tag}]; ok {
+			return name
+		}
 		cName := ClassNames[class]
 		if class == 0 {
 			if name, ok := TagNames[tag]; ok {
@@ -520,25 +735,11 @@ func dumpLevel(w io.Writer, rule EncodingRule, data []byte, depth, width int) er
 		return "[" + cName + " " + itoa(tag) + "]"
 	}
 
-	indent := strrpt("  ", depth)
-	offset := 0
-
-	for offset < len(data) {
-		class, _ := parseClassIdentifier(data[offset:])
-		compound, _ := parseCompoundIdentifier(data[offset:])
-		tag, idLen, err := parseTagIdentifier(data[offset:])
-		if err != nil {
-			return err
-		}
-
-		length, lenLen, err := parseLength(data[offset+idLen:])
-		if err != nil {
-			return codecErrorf(errorBadLength, ": ", err)
-		}
+	return walkLevel(rule, data, base, depth, func(depth, offset int, tlv TLV) error {
+		tag, length := tlv.Tag, tlv.Length
 
-		name := resolveTagName(class, tag)
 		line := newStrBuilder()
-		line.WriteString(indent)
+		line.WriteString(strrpt("  ", depth))
 
 		line.WriteByte(hexDigits[tag>>4])
 		line.WriteByte(hexDigits[tag&0xF])
@@ -552,15 +753,269 @@ func dumpLevel(w io.Writer, rule EncodingRule, data []byte, depth, width int) er
 		}
 
 		line.WriteString("    # ")
-		line.WriteString(name)
+		line.WriteString(resolveTagName(tlv.Class, tag))
 		line.WriteString(", len=")
 		line.WriteString(itoa(length))
+		if field, ok := labels[offset]; ok {
+			line.WriteString(", field=")
+			line.WriteString(field)
+		}
 		line.WriteByte('\n')
 
 		if _, err := w.Write([]byte(line.String())); err != nil {
 			return err
 		}
 
+		if !tlv.Compound {
+			dumpHexLines(w, tlv.Value, depth, width)
+		}
+
+		return nil
+	})
+}
+
+/*
+checkTLVPacket returns an error if pkt cannot be traversed as a series
+of TLVs, such as when pkt is nil or is of a character-based encoding rule.
+*/
+func checkTLVPacket(pkt PDU) (err error) {
+	if pkt == nil {
+		err = errorNilInput
+	} else if typ := pkt.Type(); typ == invalidEncodingRule {
+		err = errorInvalidPacket
+	} else if typ.textual() {
+		err = errorTextualPDU
+	}
+
+	return
+}
+
+/*
+dumpNode is the JSON representation of a single TLV written by
+[DumpJSON].
+*/
+type dumpNode struct {
+	Tag         int         `json:"tag"`
+	Class       string      `json:"class"`
+	Constructed bool        `json:"constructed"`
+	Length      int         `json:"length"`
+	Value       string      `json:"value"`
+	Children    []*dumpNode `json:"children,omitempty"`
+}
+
+/*
+DumpJSON returns an error following an attempt to write pkt into w as a
+JSON array of nodes, one per top-level TLV. Each node is an object bearing
+the keys "tag", "class", "constructed", "length" and "value", the latter
+being the hexadecimal content octets. Nodes of constructed TLVs also bear
+a "children" array of nested nodes.
+
+The offset of pkt is not altered. Only binary encoding rules, such as [BER]
+and its descendants, are supported.
+*/
+func DumpJSON(pkt PDU, w io.Writer) (err error) {
+	if err = checkTLVPacket(pkt); err != nil {
+		return
+	} else if w == nil {
+		err = errorNilInput
+		return
+	}
+
+	nodes := []*dumpNode{}
+	var parents []*dumpNode // most recent node per depth
+
+	err = walkLevel(pkt.Type(), pkt.Data(), 0, 0, func(depth, _ int, tlv TLV) error {
+		node := &dumpNode{Tag: tlv.Tag, Class: ClassNames[tlv.Class],
+			Constructed: tlv.Compound, Length: tlv.Length, Value: uc(hexstr(tlv.Value))}
+		if depth == 0 {
+			nodes = append(nodes, node)
+		} else {
+			p := parents[depth-1]
+			p.Children = append(p.Children, node)
+		}
+		parents = append(parents[:depth], node)
+		return nil
+	})
+
+	var out []byte
+	if err == nil {
+		if out, err = json.MarshalIndent(nodes, "", "  "); err == nil {
+			_, err = w.Write(append(out, '\n'))
+		}
+	}
+
+	return
+}
+
+/*
+StopWalk may be returned by the function supplied to [Walk] to stop the
+walk early. In such a case, Walk returns nil.
+*/
+var StopWalk error = mkerr("stop walk")
+
+/*
+Walk returns an error following an attempt to visit each TLV within pkt,
+descending recursively into any compound TLV. The input function is called
+once per TLV with its nesting depth, beginning at zero (0). See [StopWalk].
+
+The offset of pkt is not altered. Only binary encoding rules, such as [BER]
+and its descendants, are supported.
+*/
+func Walk(pkt PDU, fn func(int, TLV) error) (err error) {
+	if err = checkTLVPacket(pkt); err != nil {
+		return
+	} else if fn == nil {
+		err = errorNilInput
+		return
+	}
+
+	err = walkLevel(pkt.Type(), pkt.Data(), 0, 0, func(depth, _ int, tlv TLV) error {
+		return fn(depth, tlv)
+	})
+	if err == StopWalk {
+		err = nil
+	}
+
+	return
+}
+
+/*
+Tagging describes the manner in which a tagged element of an encoding
+appears to have been tagged. See [InspectTagging].
+*/
+type Tagging uint8
+
+/*
+Tagging constants describe the apparent tagging of an element.
+*/
+const (
+	TaggingNone      Tagging = iota // UNIVERSAL class, i.e.: untagged
+	TaggingExplicit                 // constructed, wrapping a single UNIVERSAL element
+	TaggingImplicit                 // primitive, or constructed with several elements
+	TaggingAmbiguous                // constructed, wrapping no element or a single tagged element
+)
+
+/*
+String returns the string representation of the receiver instance.
+*/
+func (r Tagging) String() (s string) {
+	switch r {
+	case TaggingNone:
+		s = "NONE"
+	case TaggingExplicit:
+		s = "EXPLICIT"
+	case TaggingImplicit:
+		s = "IMPLICIT"
+	default:
+		s = "AMBIGUOUS"
+	}
+
+	return
+}
+
+/*
+TagInfo describes a single element reported by [InspectTagging].
+
+Depth is the nesting depth of the element, beginning at zero (0), and
+Offset is the position of its first identifier octet within the [PDU].
+*/
+type TagInfo struct {
+	Depth    int
+	Offset   int
+	Class    int
+	Tag      int
+	Compound bool
+	Tagging  Tagging
+}
+
+/*
+InspectTagging returns a slice of [TagInfo] instances, one per element found
+within pkt -- in the order encountered, including nested elements -- alongside
+an error. Each element bearing a non-UNIVERSAL class is assessed as follows:
+
+  - a primitive element is likely IMPLICIT, as its content octets are those of the underlying type
+  - a constructed element wrapping exactly one UNIVERSAL element is likely EXPLICIT
+  - a constructed element wrapping several elements is likely an IMPLICIT SET or SEQUENCE
+  - any other constructed element is AMBIGUOUS
+
+Note these are heuristics: an IMPLICIT SEQUENCE bearing a single UNIVERSAL
+component cannot be distinguished from an EXPLICIT tag by its encoding alone.
+This function is intended to aid in the diagnosis of mis-tagging, such as when
+reverse-engineering the schema of a peer.
+
+The offset of pkt is not altered. Only binary encoding rules, such as [BER]
+and its descendants, are supported.
+*/
+func InspectTagging(pkt PDU) (infos []TagInfo, err error) {
+	if pkt == nil {
+		err = errorNilInput
+		return
+	} else if pkt.Type().textual() {
+		err = errorTextualPDU
+		return
+	}
+
+	var (
+		parents  []int // index of the most recent element per depth
+		children []int // number of child elements per element
+		inner    []int // class of the first child element per element
+	)
+
+	err = walkLevel(pkt.Type(), pkt.Data(), 0, 0, func(depth, offset int, tlv TLV) error {
+		if depth > 0 {
+			p := parents[depth-1]
+			if children[p]++; children[p] == 1 {
+				inner[p] = tlv.Class
+			}
+		}
+
+		parents = append(parents[:depth], len(infos))
+		children = append(children, 0)
+		inner = append(inner, -1)
+		infos = append(infos, TagInfo{Depth: depth, Offset: offset,
+			Class: tlv.Class, Tag: tlv.Tag, Compound: tlv.Compound})
+
+		return nil
+	})
+
+	for i := range infos {
+		switch {
+		case infos[i].Class == ClassUniversal:
+			infos[i].Tagging = TaggingNone
+		case !infos[i].Compound, children[i] > 1:
+			infos[i].Tagging = TaggingImplicit
+		case children[i] == 1 && inner[i] == ClassUniversal:
+			infos[i].Tagging = TaggingExplicit
+		default:
+			infos[i].Tagging = TaggingAmbiguous
+		}
+	}
+
+	return
+}
+
+/*
+walkLevel calls fn for each TLV found within data, which begins at
+offset base within the enclosing buffer, and descends into compound
+TLVs at depth+1. The offset supplied to fn is that of the TLV's first
+identifier octet relative to the enclosing buffer.
+*/
+func walkLevel(rule EncodingRule, data []byte, base, depth int, fn func(int, int, TLV) error) error {
+	offset := 0
+
+	for offset < len(data) {
+		class, _ := parseClassIdentifier(data[offset:])
+		compound, _ := parseCompoundIdentifier(data[offset:])
+		tag, idLen, err := parseTagIdentifier(data[offset:])
+		if err != nil {
+			return err
+		}
+
+		length, lenLen, err := parseLength(data[offset+idLen:])
+		if err != nil {
+			return codecErrorf(errorBadLength, ": ", err)
+		}
+
 		start := offset + idLen + lenLen
 		var end int
 		if length >= 0 {
@@ -576,12 +1031,16 @@ func dumpLevel(w io.Writer, rule EncodingRule, data []byte, depth, width int) er
 			end = start + idx
 		}
 
+		tlv := TLV{typ: rule, Class: class, Tag: tag, Length: length,
+			Compound: compound, Value: append([]byte{}, data[start:end]...)}
+		if err = fn(depth, base+offset, tlv); err != nil {
+			return err
+		}
+
 		if compound {
-			if err := dumpLevel(w, rule, data[start:end], depth+1, width); err != nil {
+			if err = walkLevel(rule, data[start:end], base+start, depth+1, fn); err != nil {
 				return err
 			}
-		} else {
-			dumpHexLines(w, data[start:end], depth, width)
 		}
 
 		offset = end
@@ -2,6 +2,7 @@ package asn1plus
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"sync"
@@ -56,8 +57,8 @@ func (r *testPacket) Append(data ...byte) {
 		newBuf := append((*bufPtr)[:0], r.data...)
 
 		if cap(r.data) != 0 {
-			old := r.data[:0]
-			bufPool.Put(&old)
+			old := r.data
+			putBuf(&old)
 		}
 		r.data = newBuf
 	}
@@ -67,8 +68,8 @@ func (r *testPacket) Append(data ...byte) {
 
 func (r *testPacket) Free() {
 	if cap(r.data) != 0 {
-		buf := r.data[:0]
-		bufPool.Put(&buf)
+		buf := r.data
+		putBuf(&buf)
 	}
 	*r = testPacket{}
 	testPktPool.Put(r)
@@ -130,6 +131,41 @@ func ExamplePDU_manualCreation() {
 	// Output: this is a UTF-8 string
 }
 
+func TestUnmarshalBytes(t *testing.T) {
+	// same vector as ExamplePDU_manualCreation
+	berBytes := []byte{
+		0x0c, 0x16, 0x74, 0x68, 0x69, 0x73, 0x20, 0x69,
+		0x73, 0x20, 0x61, 0x20, 0x55, 0x54, 0x46, 0x2d,
+		0x38, 0x20, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	}
+	orig := append([]byte(nil), berBytes...)
+
+	var u8 UTF8String
+	if err := UnmarshalBytes(berBytes, BER, &u8); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	} else if want := "this is a UTF-8 string"; u8.String() != want {
+		t.Fatalf("%s failed: want %q, got %q", t.Name(), want, u8)
+	} else if !bytes.Equal(berBytes, orig) {
+		t.Fatalf("%s failed: input was modified", t.Name())
+	}
+
+	// options are honored
+	observed := invalidEncodingRule
+	if err := UnmarshalBytes(berBytes, BER, &u8, ObservedRule(&observed)); err != nil {
+		t.Fatalf("%s failed [observed]: %v", t.Name(), err)
+	} else if observed != BER {
+		t.Fatalf("%s failed [observed]: want %s, got %s", t.Name(), BER, observed)
+	}
+
+	if err := UnmarshalBytes(berBytes, invalidEncodingRule, &u8); err != errorRuleNotImplemented {
+		t.Fatalf("%s failed: want %v, got %v", t.Name(), errorRuleNotImplemented, err)
+	} else if err = UnmarshalBytes(berBytes[:4], BER, &u8); err == nil {
+		t.Fatalf("%s failed: expected truncation error, got nil", t.Name())
+	} else if err = UnmarshalBytes(berBytes, BER, u8); err == nil {
+		t.Fatalf("%s failed: expected non-pointer error, got nil", t.Name())
+	}
+}
+
 func ExamplePDU_Dump_primitive() {
 	var oct OctetString = OctetString("Testing 123")
 	pkt, err := Marshal(oct)
@@ -283,6 +319,343 @@ func ExamplePDU_Dump_sequence() {
 	//         44 65 65 70 20 76 61 6C 75 65
 }
 
+func TestPDU_DumpTagNames(t *testing.T) {
+	type Filter struct {
+		And   []OctetString `asn1:"tag:0"`
+		Plain OctetString   `asn1:"tag:1"`
+	}
+
+	names := map[[2]int]string{
+		{ClassContextSpecific, 0}:     "[filter and]",
+		{ClassUniversal, TagSequence}: "Filter",
+	}
+
+	filter := Filter{And: []OctetString{OctetString("x")}, Plain: OctetString("y")}
+	pkt, err := Marshal(filter, With(BER, DumpTagNames(names)))
+	if err != nil {
+		t.Fatalf("%s failed [BER marshal]: %v", t.Name(), err)
+	}
+
+	var w bytes.Buffer
+	if err = pkt.Dump(&w); err != nil {
+		t.Fatalf("%s failed [dump]: %v", t.Name(), err)
+	}
+
+	for _, want := range []string{
+		"# Filter, len=",
+		"# [filter and], len=",
+		"# [CONTEXT SPECIFIC 1], len=",
+		"# OCTET STRING, len=",
+	} {
+		if !bytes.Contains(w.Bytes(), []byte(want)) {
+			t.Fatalf("%s failed: %q not found in dump:\n%s", t.Name(), want, w.String())
+		}
+	}
+}
+
+func TestPDU_DumpCustomNames(t *testing.T) {
+	type SubSequence struct {
+		Name OctetString
+	}
+	type Outer struct {
+		Sub SubSequence `asn1:"application,tag:0"`
+	}
+
+	names := map[[2]int]string{
+		{ClassUniversal, TagSequence}: "Outer",
+		{ClassApplication, 0}:         "SubSequence",
+	}
+	pkt, err := Marshal(Outer{SubSequence{OctetString("x")}}, With(BER, DumpTagNames(names)))
+	if err != nil {
+		t.Fatalf("%s failed [BER marshal]: %v", t.Name(), err)
+	}
+
+	var w bytes.Buffer
+	if err = pkt.Dump(&w, 32); err != nil {
+		t.Fatalf("%s failed [dump]: %v", t.Name(), err)
+	}
+
+	for _, want := range []string{"# SubSequence, len=", "# Outer, len="} {
+		if !bytes.Contains(w.Bytes(), []byte(want)) {
+			t.Fatalf("%s failed: %q not found in dump:\n%s", t.Name(), want, w.String())
+		}
+	}
+	if bytes.Contains(w.Bytes(), []byte("[APPLICATION 0]")) {
+		t.Fatalf("%s failed: default name not overridden:\n%s", t.Name(), w.String())
+	}
+
+	// a PDU not produced by Marshal bears no custom names
+	w.Reset()
+	if err = BER.New(pkt.Data()...).Dump(&w); err != nil {
+		t.Fatalf("%s failed [received dump]: %v", t.Name(), err)
+	}
+	if !bytes.Contains(w.Bytes(), []byte("[APPLICATION 0]")) {
+		t.Fatalf("%s failed: unexpected custom name:\n%s", t.Name(), w.String())
+	}
+}
+
+func TestPDU_DumpJSON(t *testing.T) {
+	type DeepSequence struct {
+		Field2 OctetString
+	}
+	type SubSequence struct {
+		Values []OctetString
+		Deep   DeepSequence `asn1:"tag:2"`
+	}
+	type MySequence struct {
+		Field0 PrintableString
+		Field2 SubSequence `asn1:"application,tag:0"`
+	}
+
+	my := MySequence{
+		Field0: PrintableString("Print me"),
+		Field2: SubSequence{
+			Values: []OctetString{OctetString("Zero"), OctetString("One")},
+			Deep:   DeepSequence{Field2: OctetString("Deep value")},
+		},
+	}
+
+	type node struct {
+		Tag         int    `json:"tag"`
+		Class       string `json:"class"`
+		Constructed bool   `json:"constructed"`
+		Length      int    `json:"length"`
+		Value       string `json:"value"`
+		Children    []node `json:"children"`
+	}
+
+	for _, rule := range encodingRules {
+		pkt, err := Marshal(my, With(rule))
+		if err != nil {
+			t.Fatalf("%s failed [%s marshal]: %v", t.Name(), rule, err)
+		}
+
+		var w bytes.Buffer
+		if err = DumpJSON(pkt, &w); err != nil {
+			t.Fatalf("%s failed [%s dump]: %v", t.Name(), rule, err)
+		}
+
+		var nodes []node
+		if err = json.Unmarshal(w.Bytes(), &nodes); err != nil {
+			t.Fatalf("%s failed [%s invalid JSON]: %v\n%s", t.Name(), rule, err, w.String())
+		} else if len(nodes) != 1 || len(nodes[0].Children) != 2 {
+			t.Fatalf("%s failed [%s structure]:\n%s", t.Name(), rule, w.String())
+		}
+
+		top := nodes[0]
+		ps := top.Children[0]
+		sub := top.Children[1]
+		if top.Tag != TagSequence || !top.Constructed || top.Class != "UNIVERSAL" {
+			t.Fatalf("%s failed [%s top]: %#v", t.Name(), rule, top)
+		} else if ps.Tag != TagPrintableString || ps.Constructed ||
+			ps.Length != 8 || ps.Value != "5072696E74206D65" {
+			t.Fatalf("%s failed [%s PrintableString]: %#v", t.Name(), rule, ps)
+		} else if sub.Class != "APPLICATION" || sub.Tag != 0 || len(sub.Children) != 2 ||
+			len(sub.Children[0].Children) != 2 || len(sub.Children[1].Children) != 1 {
+			t.Fatalf("%s failed [%s SubSequence]:\n%s", t.Name(), rule, w.String())
+		} else if deep := sub.Children[1].Children[0]; deep.Value != uc(hexstr([]byte("Deep value"))) {
+			t.Fatalf("%s failed [%s DeepSequence]: %#v", t.Name(), rule, deep)
+		}
+	}
+
+	if JER.Enabled() {
+		if err := DumpJSON(JER.New('{', '}'), io.Discard); err != errorTextualPDU {
+			t.Fatalf("%s failed: want %v, got %v", t.Name(), errorTextualPDU, err)
+		}
+	}
+	if err := DumpJSON(BER.New(0x04, 0x05, 0x01), io.Discard); err == nil {
+		t.Fatalf("%s failed: expected truncation error, got nil", t.Name())
+	}
+	if err := DumpJSON(nil, io.Discard); err != errorNilInput {
+		t.Fatalf("%s failed: want %v, got %v", t.Name(), errorNilInput, err)
+	} else if err = DumpJSON(invalidPacket{}, io.Discard); err != errorInvalidPacket {
+		t.Fatalf("%s failed: want %v, got %v", t.Name(), errorInvalidPacket, err)
+	} else if err = DumpJSON(BER.New(0x05, 0x00), nil); err != errorNilInput {
+		t.Fatalf("%s failed: want %v, got %v", t.Name(), errorNilInput, err)
+	}
+}
+
+func TestPDU_DumpWidth(t *testing.T) {
+	oct := OctetString("ABCDEFGHIJKLMNOPQRST")
+	for _, rule := range encodingRules {
+		pkt, err := Marshal(oct, With(rule))
+		if err != nil {
+			t.Fatalf("%s failed [%s marshal]: %v", t.Name(), rule, err)
+		}
+
+		var w bytes.Buffer
+		if err = pkt.Dump(&w, MinDumpWidth); err != nil {
+			t.Fatalf("%s failed [%s dump]: %v", t.Name(), rule, err)
+		}
+
+		want := "04 14    # OCTET STRING, len=20\n" +
+			"  41 42 43 44 45 46 47 48\n" +
+			"  49 4A 4B 4C 4D 4E 4F 50\n" +
+			"  51 52 53 54\n"
+		if got := w.String(); got != want {
+			t.Fatalf("%s failed [%s output]:\n\twant: %q\n\tgot:  %q", t.Name(), rule, want, got)
+		}
+
+		if err = pkt.Dump(io.Discard, MinDumpWidth-1); err == nil {
+			t.Fatalf("%s failed [%s]: expected error for narrow width, got nil", t.Name(), rule)
+		}
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	type Entry struct {
+		Name PrintableString
+		Code Enumerated
+	}
+
+	for _, rule := range encodingRules {
+		pkt, err := Marshal(Entry{PrintableString("alpha"), 1}, With(rule))
+		if err != nil {
+			t.Fatalf("%s failed [%s marshal]: %v", t.Name(), rule, err)
+		}
+		orig := append([]byte(nil), pkt.Data()...)
+
+		ro := ReadOnly(pkt)
+		if ReadOnly(ro) != ro {
+			t.Fatalf("%s failed [%s]: expected idempotent wrapper", t.Name(), rule)
+		}
+
+		ro.Append(0xFF, 0xFF)
+		ro.Data()[0] = 0xFF
+		if b, _ := ro.Bytes(); len(b) > 0 {
+			b[0] = 0xFF
+		}
+		if b, _ := ro.FullBytes(); len(b) > 0 {
+			b[0] = 0xFF
+		}
+		if err = ro.WriteTLV(rule.newTLV(ClassUniversal, TagNull, 0, false)); err != errorReadOnlyPDU {
+			t.Fatalf("%s failed [%s]: want %v, got %v", t.Name(), rule, errorReadOnlyPDU, err)
+		}
+		ro.SetOffset(3)
+		ro.Free()
+
+		if !btseq(pkt.Data(), orig) || !btseq(ro.Data(), orig) {
+			t.Fatalf("%s failed [%s]: buffer modified:\n\twant: %X\n\tgot:  %X / %X",
+				t.Name(), rule, orig, pkt.Data(), ro.Data())
+		} else if pkt.Offset() != 0 {
+			t.Fatalf("%s failed [%s]: underlying offset modified: %d", t.Name(), rule, pkt.Offset())
+		}
+
+		ro.SetOffset(0)
+		if tlv, err := ro.TLV(); err != nil || tlv.Tag != TagSequence {
+			t.Fatalf("%s failed [%s TLV]: %v (%#v)", t.Name(), rule, err, tlv)
+		}
+
+		var nodes int
+		if err = Walk(ro, func(int, TLV) error { nodes++; return nil }); err != nil || nodes != 3 {
+			t.Fatalf("%s failed [%s walk]: want 3 nodes, got %d (%v)", t.Name(), rule, nodes, err)
+		} else if ro.Hex() != pkt.Hex() {
+			t.Fatalf("%s failed [%s hex]: want %s, got %s", t.Name(), rule, pkt.Hex(), ro.Hex())
+		}
+
+		var out Entry
+		ro.SetOffset(0)
+		if err = Unmarshal(ro, &out); err != nil || out.Name != "alpha" {
+			t.Fatalf("%s failed [%s unmarshal]: %v", t.Name(), rule, err)
+		}
+	}
+
+	if ReadOnly(nil) != nil || ReadOnly(invalidPacket{}) != (invalidPacket{}) {
+		t.Fatalf("%s failed: expected nil and invalid packets as-is", t.Name())
+	}
+}
+
+func TestPDU_Walk(t *testing.T) {
+	// Same structure as ExamplePDU_Dump_sequence.
+	type DeepSequence struct {
+		Field2 OctetString
+	}
+
+	type SubSequence struct {
+		Values []OctetString
+		Deep   DeepSequence `asn1:"tag:2"`
+	}
+
+	type MySequence struct {
+		Field0 PrintableString
+		Field1 OctetString `asn1:"optional"`
+		Field2 SubSequence `asn1:"application,tag:0"`
+	}
+
+	my := MySequence{
+		Field0: PrintableString("Print me"),
+		Field2: SubSequence{
+			Values: []OctetString{
+				OctetString("Zero"),
+				OctetString("One"),
+				OctetString("Two"),
+				OctetString("Three"),
+			},
+			Deep: DeepSequence{
+				Field2: OctetString("Deep value"),
+			},
+		},
+	}
+
+	opts := Options{}
+	opts.SetClass(1)
+	opts.SetTag(7)
+
+	for _, rule := range encodingRules {
+		pkt, err := Marshal(my, With(rule, opts))
+		if err != nil {
+			t.Fatalf("%s failed [%s marshal]: %v", t.Name(), rule, err)
+		}
+
+		var nodes, maxDepth int
+		if err = Walk(pkt, func(depth int, tlv TLV) error {
+			nodes++
+			maxDepth = max(maxDepth, depth)
+			return nil
+		}); err != nil {
+			t.Fatalf("%s failed [%s walk]: %v", t.Name(), rule, err)
+		}
+
+		// MySequence, Field0, Field1, SubSequence, SET OF (+4),
+		// DeepSequence, Field2
+		if nodes != 11 || maxDepth != 3 {
+			t.Fatalf("%s failed [%s walk]: want 11 nodes at depth 3, got %d at depth %d",
+				t.Name(), rule, nodes, maxDepth)
+		}
+
+		var last TLV
+		nodes = 0
+		if err = Walk(pkt, func(depth int, tlv TLV) error {
+			nodes++
+			if last = tlv; tlv.Class == ClassUniversal && tlv.Tag == TagSet {
+				return StopWalk
+			}
+			return nil
+		}); err != nil {
+			t.Fatalf("%s failed [%s stop]: %v", t.Name(), rule, err)
+		} else if nodes != 5 || !last.Compound {
+			t.Fatalf("%s failed [%s stop]: want stop at node 5, got %d (%s)",
+				t.Name(), rule, nodes, last)
+		}
+	}
+
+	pkt, _ := Marshal(OctetString("x"), With(BER))
+	if err := Walk(pkt, nil); err == nil {
+		t.Fatalf("%s failed: expected error for nil function, got nil", t.Name())
+	}
+
+	pkt = BER.New(0x30, 0x80, 0x04, 0x01, 'x')
+	if err := Walk(pkt, func(int, TLV) error { return nil }); err == nil {
+		t.Fatalf("%s failed: expected missing EOC error, got nil", t.Name())
+	}
+
+	if err := Walk(nil, func(int, TLV) error { return nil }); err != errorNilInput {
+		t.Fatalf("%s failed: want %v, got %v", t.Name(), errorNilInput, err)
+	} else if err = Walk(invalidPacket{}, func(int, TLV) error { return nil }); err != errorInvalidPacket {
+		t.Fatalf("%s failed: want %v, got %v", t.Name(), errorInvalidPacket, err)
+	}
+}
+
 func TestPDU_invalidPacket(_ *testing.T) {
 	var invp invalidPacket
 	invp.Type()
@@ -457,6 +830,43 @@ func TestParseLengthCornerCases(t *testing.T) {
 	}
 }
 
+func TestMaxElementLength(t *testing.T) {
+	defer func(n int) { MaxElementLength = n }(MaxElementLength)
+	MaxElementLength = 1024
+
+	// OCTET STRING declaring ~2GB of content
+	huge := []byte{0x04, 0x84, 0x7F, 0xFF, 0xFF, 0xFF, 0x00}
+
+	if _, _, err := parseLength(huge[1:]); err != errorElementTooLarge {
+		t.Fatalf("%s failed [parseLength]: want %v, got %v", t.Name(), errorElementTooLarge, err)
+	}
+	if _, err := parseBody(huge, 0, BER); err != errorElementTooLarge {
+		t.Fatalf("%s failed [parseBody]: want %v, got %v", t.Name(), errorElementTooLarge, err)
+	}
+	if _, err := parseFullBytes(huge, 0, BER); err != errorElementTooLarge {
+		t.Fatalf("%s failed [parseFullBytes]: want %v, got %v", t.Name(), errorElementTooLarge, err)
+	}
+
+	var oct OctetString
+	if err := Unmarshal(BER.New(huge...), &oct); err == nil {
+		t.Fatalf("%s failed [Unmarshal]: expected error, got nil", t.Name())
+	}
+	if _, err := UnmarshalN(BER.New(huge...), &oct); err != errorElementTooLarge {
+		t.Fatalf("%s failed [UnmarshalN]: want %v, got %v", t.Name(), errorElementTooLarge, err)
+	}
+	if err := NewStreamDecoder(bytes.NewReader(huge), BER).Decode(&oct); err != errorElementTooLarge {
+		t.Fatalf("%s failed [StreamDecoder]: want %v, got %v", t.Name(), errorElementTooLarge, err)
+	}
+
+	// lengths at or below the limit are unaffected
+	ok := append([]byte{0x04, 0x82, 0x04, 0x00}, make([]byte, 1024)...)
+	if err := Unmarshal(BER.New(ok...), &oct); err != nil {
+		t.Fatalf("%s failed [within limit]: %v", t.Name(), err)
+	} else if len(oct) != 1024 {
+		t.Fatalf("%s failed [within limit]: want 1024 octets, got %d", t.Name(), len(oct))
+	}
+}
+
 func TestParseTagIdentifierCornerCases(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -854,6 +1264,78 @@ func TestSequence_PrimitiveFieldsImplicit(t *testing.T) {
 	}
 }
 
+func TestInspectTagging(t *testing.T) {
+	type explicitSequence struct {
+		Field0 OctetString `asn1:"explicit,tag:0"`
+		Field1 OctetString `asn1:"explicit,tag:1"`
+	}
+	type implicitSequence struct {
+		Field0 OctetString `asn1:"tag:0"`
+		Field1 OctetString `asn1:"tag:1"`
+	}
+
+	for idx, tc := range []struct {
+		value any
+		want  []Tagging
+	}{
+		{explicitSequence{OctetString("Hello"), OctetString("World")},
+			[]Tagging{TaggingNone, TaggingExplicit, TaggingNone, TaggingExplicit, TaggingNone}},
+		{implicitSequence{OctetString("Hello"), OctetString("World")},
+			[]Tagging{TaggingNone, TaggingImplicit, TaggingImplicit}},
+	} {
+		for _, rule := range encodingRules {
+			pkt, err := Marshal(tc.value, With(rule))
+			if err != nil {
+				t.Fatalf("%s[%d] failed [%s encoding]: %v", t.Name(), idx, rule, err)
+			}
+
+			infos, err := InspectTagging(pkt)
+			if err != nil {
+				t.Fatalf("%s[%d] failed [%s inspect]: %v", t.Name(), idx, rule, err)
+			} else if len(infos) != len(tc.want) {
+				t.Fatalf("%s[%d] failed [%s]: want %d elements, got %d", t.Name(), idx, rule, len(tc.want), len(infos))
+			}
+
+			for i, info := range infos {
+				if info.Tagging != tc.want[i] {
+					t.Fatalf("%s[%d] failed [%s element #%d]: want %s, got %s",
+						t.Name(), idx, rule, i, tc.want[i], info.Tagging)
+				}
+			}
+		}
+	}
+
+	// IMPLICIT SEQUENCE of several elements, and a tag wrapping a tagged element
+	infos, err := InspectTagging(BER.New(0xA0, 0x06, 0x80, 0x01, 0x01, 0x81, 0x01, 0x02,
+		0xA1, 0x03, 0x80, 0x01, 0x03, 0xA2, 0x00))
+	if err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+	for i, want := range []Tagging{TaggingImplicit, TaggingImplicit, TaggingImplicit,
+		TaggingAmbiguous, TaggingImplicit, TaggingAmbiguous} {
+		if infos[i].Tagging != want {
+			t.Fatalf("%s failed [element #%d]: want %s, got %s", t.Name(), i, want, infos[i].Tagging)
+		}
+	}
+	if infos[4].Depth != 1 || infos[4].Offset != 10 {
+		t.Fatalf("%s failed: want depth 1 at offset 10, got depth %d at offset %d",
+			t.Name(), infos[4].Depth, infos[4].Offset)
+	}
+
+	if _, err = InspectTagging(nil); err == nil {
+		t.Fatalf("%s failed: expected error for nil PDU, got nil", t.Name())
+	}
+	if _, err = InspectTagging(BER.New(0x04, 0x05, 0x01)); err == nil {
+		t.Fatalf("%s failed: expected truncation error, got nil", t.Name())
+	}
+	if JER.Enabled() {
+		if _, err = InspectTagging(JER.New('{', '}')); err != errorTextualPDU {
+			t.Fatalf("%s failed: want %v, got %v", t.Name(), errorTextualPDU, err)
+		}
+	}
+	_ = TaggingAmbiguous.String() + TaggingNone.String()
+}
+
 func TestConstructorMap_ShouldPanic(t *testing.T) {
 	defer func() {
 		if r := recover(); r == nil {
@@ -903,15 +1385,67 @@ func BenchmarkPDU_SequenceBER(b *testing.B) {
 		Raw:  OctetString(`fjkewjlkjlkwjlkr324j589234torhj23trioh324t8294ht24ih243hui4h4hih3i`),
 	}
 
-	for i := 0; i < b.N; i++ {
-		pkt, err := Marshal(mine, With(BER))
-		if err != nil {
-			b.Fatal(err)
+	// "uncached" clears the struct field and field options caches
+	// prior to each round trip, approximating the cost incurred
+	// before those caches were introduced.
+	for _, cached := range []bool{true, false} {
+		name := "cached"
+		if !cached {
+			name = "uncached"
 		}
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if !cached {
+					forgetFieldOptions()
+					structFieldsCache.Clear()
+				}
+
+				pkt, err := Marshal(mine, With(BER))
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				var mine2 MySequence
+				if err = Unmarshal(pkt, &mine2); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
 
-		var mine2 MySequence
-		if err = Unmarshal(pkt, &mine2); err != nil {
-			b.Fatal(err)
+func TestZeroOnFree(t *testing.T) {
+	defer func(z bool) { ZeroOnFree = z }(ZeroOnFree)
+
+	secret := []byte{0x04, 0x06, 's', 'e', 'c', 'r', 'e', 't'}
+	for _, zero := range []bool{false, true} {
+		ZeroOnFree = zero
+		for _, rule := range encodingRules {
+			pkt := rule.New(secret...)
+			data := pkt.Data()
+			pkt.Free()
+
+			if leaked := btseq(data, secret); leaked == zero {
+				t.Fatalf("%s failed [%s, ZeroOnFree=%t]: residual data %X", t.Name(), rule, zero, data)
+			}
+			if zero {
+				for i, b := range data[:cap(data)] {
+					if b != 0 {
+						t.Fatalf("%s failed [%s]: octet %d not zeroed (0x%02X)", t.Name(), rule, i, b)
+					}
+				}
+			}
 		}
 	}
+
+	// growth within Append also releases the former buffer
+	ZeroOnFree = true
+	pkt := BER.New(secret...)
+	old := pkt.Data()
+	pkt.Append(make([]byte, cap(old)+1)...)
+	if btseq(old, secret) {
+		t.Fatalf("%s failed [Append growth]: residual data %X", t.Name(), old)
+	}
+	pkt.Free()
 }
@@ -137,7 +137,7 @@ func primitiveCheckRead(tag int, pkt PDU, tlv TLV, opts *Options) (data []byte,
 		// WITH a length of 0x80.
 		//
 		// TODO: revisit this approach.
-		if typ.allowsIndefinite() && pkt.Data()[1] == indefByte {
+		if typ.allowsIndefinite() && pkt.Data()[1] == indefByte && len(data) >= 2 {
 			if data[len(data)-1] == zeroByte &&
 				data[len(data)-2] == zeroByte {
 				data = data[:len(data)-2]
@@ -180,7 +180,7 @@ func init() {
 		return
 	}
 
-	RegisterTextAlias[PrintableString](TagPrintableString,
-		PrintableStringConstraintPhase,
+	registerTextAlias[PrintableString](TagPrintableString,
+		func() int { return PrintableStringConstraintPhase },
 		nil, nil, nil, PrintableSpec)
 }
@@ -203,3 +203,33 @@ func BenchmarkPrintableStringConstructor(b *testing.B) {
 		}
 	}
 }
+
+func TestPrintableString_encodingPhase(t *testing.T) {
+	defer func(phase int) { PrintableStringConstraintPhase = phase }(PrintableStringConstraintPhase)
+
+	bogus := PrintableString("user@example.com") // '@' is not printable
+
+	// the default (decoding) phase permits the bogus value to be encoded
+	PrintableStringConstraintPhase = CodecConstraintDecoding
+	if _, err := Marshal(bogus, With(BER)); err != nil {
+		t.Fatalf("%s failed [decoding phase]: %v", t.Name(), err)
+	}
+
+	PrintableStringConstraintPhase = CodecConstraintBoth
+	for _, rule := range encodingRules {
+		pkt, err := Marshal(bogus, With(rule))
+		if err == nil {
+			t.Fatalf("%s failed [%s both phases]: expected error, got %s", t.Name(), rule, pkt.Hex())
+		} else if pkt != nil && pkt.Len() > 0 {
+			t.Fatalf("%s failed [%s both phases]: bytes produced: %s", t.Name(), rule, pkt.Hex())
+		}
+	}
+
+	// SEQUENCE components are subject to the same phase
+	type Seq struct {
+		Name PrintableString
+	}
+	if _, err := Marshal(Seq{Name: bogus}, With(BER)); err == nil {
+		t.Fatalf("%s failed [SEQUENCE both phases]: expected error, got nil", t.Name())
+	}
+}
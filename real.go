@@ -165,6 +165,85 @@ func (r Real) Big() *big.Float {
 	return result
 }
 
+/*
+Rat returns the exact *[big.Rat] representation of the receiver instance,
+alongside an error should the receiver describe an infinity or bear an
+unsupported base.
+
+Unlike [Real.Float] and [Real.Big], no rounding takes place. This makes
+Rat suitable for decimal quantities, such as currency, which cannot be
+represented exactly in binary floating-point, e.g.: 0.1.
+*/
+func (r Real) Rat() (rat *big.Rat, err error) {
+	if r.Special != RealNormal {
+		err = primitiveErrorf("REAL: ", r.Special.String(), " has no rational value")
+		return
+	} else if !validRealBase(r.Base) {
+		err = primitiveErrorf("REAL: unsupported base ", r.Base)
+		return
+	}
+
+	absExp := r.Exponent
+	if absExp < 0 {
+		absExp = -absExp
+	}
+	factor := newBigInt(0).Exp(newBigInt(int64(r.Base)), newBigInt(int64(absExp)), nil)
+
+	rat = new(big.Rat).SetInt(r.Mantissa.Big())
+	if r.Exponent < 0 {
+		rat.Quo(rat, new(big.Rat).SetInt(factor))
+	} else {
+		rat.Mul(rat, new(big.Rat).SetInt(factor))
+	}
+
+	return
+}
+
+/*
+ratToRealParts returns the base-10 mantissa and exponent which represent
+rat exactly, alongside an error should no such representation exist,
+e.g.: 1/3.
+*/
+func ratToRealParts(rat *big.Rat, _ int) (mant any, exp int, err error) {
+	if rat == nil {
+		err = errorNilInput
+		return
+	}
+
+	num := new(big.Int).Set(rat.Num())
+	den := new(big.Int).Set(rat.Denom())
+	ten := newBigInt(10)
+
+	// Scale by ten until the denominator is eliminated. This only
+	// terminates if the denominator is of the form 2^a × 5^b, thus
+	// the number of iterations is bounded by its bit length.
+	rem := new(big.Int)
+	for limit := den.BitLen(); den.Cmp(newBigInt(1)) != 0; limit-- {
+		if limit < 0 {
+			err = primitiveErrorf("REAL: ", rat.RatString(), " has no exact base-10 representation")
+			return
+		}
+		num.Mul(num, ten)
+		exp--
+		g := new(big.Int).GCD(nil, nil, num, den)
+		num.Quo(num, g)
+		den.Quo(den, g)
+	}
+
+	// strip superfluous trailing zeros from the mantissa
+	for num.Sign() != 0 {
+		q, m := new(big.Int).QuoRem(num, ten, rem)
+		if m.Sign() != 0 {
+			break
+		}
+		num = q
+		exp++
+	}
+
+	mant = num
+	return
+}
+
 /*
 Float64 returns the numeric value of r as a float64. If r encodes ±∞,
 the corresponding math.Inf value is returned. If the magnitude cannot
@@ -461,7 +540,7 @@ func (c *realCodec[T]) write(pkt PDU, o *Options) (n int, err error) {
 func bcdRealWrite[T any](c *realCodec[T], pkt PDU, o *Options) (off int, err error) {
 	o = deferImplicit(o)
 
-	cc := c.cg.phase(c.cphase, CodecConstraintEncoding)
+	cc := c.cg.phase(o.constraintPhase(c.cphase), CodecConstraintEncoding)
 	if err := cc(c.val); err == nil {
 		r := toReal(c.val)
 		var wire []byte
@@ -470,13 +549,13 @@ func bcdRealWrite[T any](c *realCodec[T], pkt PDU, o *Options) (off int, err err
 		} else {
 			var ok bool
 			if wire, ok = infinityToByte(r.Special); !ok {
-				if r.Mantissa.Big().Sign() == 0 {
+				if r.Mantissa.IsZero() {
 					// zero: empty content
 					wire = nil
 				} else {
 					// normal number
 					signFlag := byte(0)
-					if r.Mantissa.Big().Sign() < 0 {
+					if r.Mantissa.Sign() < 0 {
 						signFlag = 0x20
 					}
 
@@ -486,7 +565,7 @@ func bcdRealWrite[T any](c *realCodec[T], pkt PDU, o *Options) (off int, err err
 						return 0, primitiveErrorf("REAL: exponent too long")
 					}
 					header := 0x80 | baseIndicator | signFlag | byte(len(expBytes))
-					mantissaBytes := encodeMantissa(r.Mantissa.Big().Abs(r.Mantissa.Big()))
+					mantissaBytes := encodeMantissa(r.Mantissa.Abs().Big())
 
 					wire = append([]byte{header}, expBytes...)
 					wire = append(wire, mantissaBytes...)
@@ -546,6 +625,14 @@ func bcdRealRead[T any](c *realCodec[T], pkt PDU, tlv TLV, o *Options) (err erro
 					}
 				default:
 					header := wire[0]
+					if header&0xC0 == 0 {
+						// decimal (ISO 6093) form, per X.690 clause 8.5.8
+						if r, err = decodeRealDecimal(wire[1:]); err != nil {
+							return
+						}
+						break
+					}
+
 					expLen := int(header & 0x0F)
 					if 1+expLen >= len(wire) {
 						return primitiveErrorf("REAL: insufficient data for exponent")
@@ -570,7 +657,7 @@ func bcdRealRead[T any](c *realCodec[T], pkt PDU, tlv TLV, o *Options) (err erro
 			}
 
 			if err == nil {
-				cc := c.cg.phase(c.cphase, CodecConstraintDecoding)
+				cc := c.cg.phase(o.constraintPhase(c.cphase), CodecConstraintDecoding)
 				if err = cc(out); err == nil {
 					c.val = out
 					pkt.AddOffset(tlv.Length)
@@ -582,6 +669,64 @@ func bcdRealRead[T any](c *realCodec[T], pkt PDU, tlv TLV, o *Options) (err erro
 	return err
 }
 
+/*
+decodeRealDecimal returns an instance of [Real] alongside an error following
+an attempt to parse the ISO 6093 numerical representation (NR1, NR2 or NR3)
+found within b, e.g.: "314", "3.14" or "314.E-2". The result is exact, and
+bears a base of ten (10).
+*/
+func decodeRealDecimal(b []byte) (r Real, err error) {
+	s := trimL(string(b), " ")
+	bad := func() (Real, error) {
+		return Real{}, primitiveErrorf("REAL: invalid decimal representation ", s)
+	}
+
+	var digits []byte
+	var exp, i int
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		digits = append(digits, s[0])
+		i++
+	}
+
+	var nd, frac int
+	for seenMark := false; i < len(s); i++ {
+		switch c := s[i]; {
+		case '0' <= c && c <= '9':
+			digits = append(digits, c)
+			nd++
+			if seenMark {
+				frac++
+			}
+			continue
+		case (c == '.' || c == ',') && !seenMark:
+			seenMark = true
+			continue
+		}
+		break
+	}
+
+	if nd == 0 {
+		return bad()
+	}
+
+	if i < len(s) {
+		if s[i] != 'E' && s[i] != 'e' {
+			return bad()
+		}
+		if exp, err = atoi(trimPfx(s[i+1:], "+")); err != nil {
+			return bad()
+		}
+	}
+
+	mant, _ := new(big.Int).SetString(string(digits), 10)
+	var m Integer
+	if m, err = NewInteger(mant); err == nil {
+		r = Real{Mantissa: m, Base: 10, Exponent: exp - frac}
+	}
+
+	return
+}
+
 func byteToInfinity(b byte) (r Real, err error) {
 	switch b {
 	case plusIByte:
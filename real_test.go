@@ -93,6 +93,71 @@ func TestReal_encodingRules(t *testing.T) {
 	}
 }
 
+func TestReal_Rat(t *testing.T) {
+	pi := MustNewReal(314, 10, -2)
+	for _, rule := range encodingRules {
+		pkt, err := Marshal(pi, With(rule))
+		if err != nil {
+			t.Fatalf("%s failed [%s encode]: %v", t.Name(), rule, err)
+		}
+
+		var out Real
+		if err = Unmarshal(pkt, &out); err != nil {
+			t.Fatalf("%s failed [%s decode]: %v", t.Name(), rule, err)
+		}
+
+		if rat, err := out.Rat(); err != nil {
+			t.Fatalf("%s failed [%s Rat]: %v", t.Name(), rule, err)
+		} else if rat.Cmp(big.NewRat(314, 100)) != 0 {
+			t.Fatalf("%s failed [%s Rat]: want 157/50, got %s", t.Name(), rule, rat)
+		}
+	}
+
+	// 0.1 cannot be represented exactly as a float64
+	tenth := MustNewReal(1, 10, -1)
+	if rat, _ := tenth.Rat(); rat.Cmp(big.NewRat(1, 10)) != 0 {
+		t.Fatalf("%s failed: want 1/10, got %s", t.Name(), rat)
+	} else if lossy := new(big.Rat).SetFloat64(tenth.Float()); lossy.Cmp(rat) == 0 {
+		t.Fatalf("%s failed: expected float64 rounding of 0.1", t.Name())
+	}
+
+	if _, err := NewRealPlusInfinity().Rat(); err == nil {
+		t.Fatalf("%s failed: expected error for infinity, got nil", t.Name())
+	}
+}
+
+func TestReal_decimalForm(t *testing.T) {
+	for idx, tc := range []struct {
+		nr   string
+		want *big.Rat
+	}{
+		{"\x01314", big.NewRat(314, 1)},
+		{"\x023,14", big.NewRat(314, 100)},
+		{"\x02 -0.5", big.NewRat(-1, 2)},
+		{"\x03314.E-2", big.NewRat(314, 100)},
+		{"\x031.5E+3", big.NewRat(1500, 1)},
+		{"\x01abc", nil},
+		{"\x033.14X", nil},
+	} {
+		data := append([]byte{byte(TagReal), byte(len(tc.nr))}, tc.nr...)
+
+		var r Real
+		err := UnmarshalBytes(data, BER, &r)
+		if tc.want == nil {
+			if err == nil {
+				t.Fatalf("%s[%d] failed: expected error, got %s", t.Name(), idx, r)
+			}
+			continue
+		} else if err != nil {
+			t.Fatalf("%s[%d] failed: %v", t.Name(), idx, err)
+		}
+
+		if rat, err := r.Rat(); err != nil || rat.Cmp(tc.want) != 0 {
+			t.Fatalf("%s[%d] failed: want %s, got %v (%v)", t.Name(), idx, tc.want, rat, err)
+		}
+	}
+}
+
 func TestRealSpecial_encodingRules(t *testing.T) {
 	posOrNeg := func(i int) string {
 		if i == 0 {
@@ -20,22 +20,53 @@ one or more of:
 If an [EncodingRule] is not specified, the value of [DefaultEncoding] is used,
 which is [BER] by default.
 
-See also [MustMarshal], [MustUnmarshal], [Unmarshal] and [With].
+See also [MustMarshal], [MarshalBytes], [MustUnmarshal], [Unmarshal], [With]
+and [Encoder].
 */
 func Marshal(x any, with ...EncodingOption) (pkt PDU, err error) {
 	cfg := &encodingConfig{rule: DefaultEncoding}
 	for _, o := range with {
 		o(cfg)
 	}
+	cfg.resolve()
 
 	debugEnter(x, cfg.rule, cfg.opts)
 	defer func() { debugExit(pkt, newLItem(err)) }()
 
 	if err = marshalCheckBadOptions(cfg.rule, cfg.opts); err == nil {
 		pkt = cfg.rule.New()
+		err = marshalConfigured(x, pkt, cfg)
+	}
+
+	return
+}
+
+/*
+marshalConfigured returns an error following an attempt to encode x into
+pkt, which must be empty, per the fully resolved configuration cfg.
+*/
+func marshalConfigured(x any, pkt PDU, cfg *encodingConfig) (err error) {
+	if ol, ok := pkt.(outputLimiter); ok && cfg.maxOutput > 0 {
+		ol.setOutputLimit(cfg.maxOutput)
+	}
+	if tn, ok := pkt.(tagNamer); ok && cfg.tagNames != nil {
+		tn.setTagNames(cfg.tagNames)
+	}
+	if cfg.annotate {
+		debugEnableAnnotations(pkt)
+	}
+
+	if cfg.rule.textual() {
+		err = marshalText(refValueOf(x), pkt, cfg.opts)
+	} else {
 		err = marshalValue(refValueOf(x), pkt, cfg.opts)
 	}
 
+	if err == nil && cfg.maxOutput > 0 && pkt.Len() > cfg.maxOutput {
+		err = codecErrorf("maximum output size exceeded: ", pkt.Len(),
+			" bytes written against a budget of ", cfg.maxOutput)
+	}
+
 	return
 }
 
@@ -51,6 +82,65 @@ func MustMarshal(x any, with ...EncodingOption) PDU {
 	return pkt
 }
 
+/*
+MarshalBytes returns the encoding of x as a detached []byte instance
+alongside an error following an attempt to [Marshal] x using the input
+[EncodingOption] instances, which are honored as they are by [Marshal].
+
+The underlying [PDU] is freed prior to return, thus the caller need not
+(and cannot) call its Free method. The return value is a copy, and may be
+retained or modified freely.
+*/
+func MarshalBytes(x any, with ...EncodingOption) (b []byte, err error) {
+	var pkt PDU
+	if pkt, err = Marshal(x, with...); err == nil {
+		b = append([]byte(nil), pkt.Data()...)
+	}
+	if pkt != nil {
+		pkt.Free()
+	}
+
+	return
+}
+
+/*
+EncodedLen returns the total number of bytes which comprise the encoding of
+x per rule alongside an error. The input [EncodingOption] instances are honored
+as they are by [Marshal], save for any [EncodingRule] declared within, as rule
+always prevails.
+
+This is useful for the pre-sizing of buffers, or when a length determinant
+must be emitted ahead of the encoding itself, such as with length-prefixed
+framing. The top-level output is never assembled: its bytes are counted and
+discarded as they are written. Nested content (e.g.: the components of a
+SEQUENCE) is still produced within the package's pooled buffers, as the
+length of each component must be known before its enclosing header can be
+written.
+*/
+func EncodedLen(x any, rule EncodingRule, with ...EncodingOption) (n int, err error) {
+	if !rule.Enabled() {
+		err = errorRuleNotImplemented
+		return
+	}
+
+	cfg := &encodingConfig{rule: DefaultEncoding}
+	for _, o := range with {
+		o(cfg)
+	}
+	cfg.rule = rule // rule always prevails
+	cfg.resolve()
+
+	if err = marshalCheckBadOptions(cfg.rule, cfg.opts); err == nil {
+		pkt := &lenPacket{PDU: rule.New()}
+		if err = marshalConfigured(x, pkt, cfg); err == nil {
+			n = pkt.Len()
+		}
+		pkt.PDU.Free()
+	}
+
+	return
+}
+
 /*
 marshalCheckBadOptions returns an error following a scan for illegal or
 unsupported options statements just prior to the marshaling process.
@@ -68,6 +158,51 @@ func marshalCheckBadOptions(rule EncodingRule, o *Options) (err error) {
 	return
 }
 
+/*
+textMarshalers and textUnmarshalers contain the encoding and decoding
+handlers for character-based encoding rules (e.g.: [XER]), which do not
+follow the tag/length/value model. Each rule registers its own handlers
+during initialization.
+*/
+var (
+	textMarshalers   = make(map[EncodingRule]func(reflect.Value, PDU, *Options) error)
+	textUnmarshalers = make(map[EncodingRule]func(PDU, reflect.Value, *Options) error)
+)
+
+/*
+marshalText returns an error following an attempt to marshal v into pkt
+using the character-based encoding rule implemented by pkt.
+*/
+func marshalText(v reflect.Value, pkt PDU, opts *Options) (err error) {
+	debugEnter(v, pkt, opts)
+	defer func() { debugExit(newLItem(err)) }()
+
+	if enc, ok := textMarshalers[pkt.Type()]; !ok {
+		err = errorRuleNotImplemented
+	} else {
+		err = enc(v, pkt, opts)
+	}
+
+	return
+}
+
+/*
+unmarshalText returns an error following an attempt to unmarshal pkt into
+v using the character-based encoding rule implemented by pkt.
+*/
+func unmarshalText(pkt PDU, v reflect.Value, opts *Options) (err error) {
+	debugEnter(v, pkt, opts)
+	defer func() { debugExit(newLItem(err)) }()
+
+	if dec, ok := textUnmarshalers[pkt.Type()]; !ok {
+		err = errorRuleNotImplemented
+	} else {
+		err = dec(pkt, v, opts)
+	}
+
+	return
+}
+
 func marshalValue(v reflect.Value, pkt PDU, opts *Options) (err error) {
 	debugEnter(v, pkt, opts)
 	defer func() { debugExit(newLItem(err)) }()
@@ -91,7 +226,7 @@ func marshalValue(v reflect.Value, pkt PDU, opts *Options) (err error) {
 	opts = deferOverrideOptions(v, opts)
 
 	// Handle pointers first
-	if k == reflect.Ptr {
+	if k == reflect.Ptr && !isAdaptedPointer(v, opts) {
 		err = marshalValue(v.Elem(), pkt, opts)
 		return
 	}
@@ -140,7 +275,9 @@ func marshalBasic(
 	case ptrIsNil(v):
 		err = codecErrorf("Marshal: input must be non-nil")
 	default:
-		v = derefValuePtr(v)
+		if k != reflect.Ptr || !isAdaptedPointer(v, opts) {
+			v = derefValuePtr(v)
+		}
 
 		var handled bool
 		for _, handler := range marshalHandlers {
@@ -174,7 +311,12 @@ func marshalComposite(v reflect.Value, pkt PDU, opts *Options) (err error) {
 	k := v.Kind()
 	switch k {
 	case reflect.Slice:
-		if opts.Sequence {
+		if err = opts.checkSize(v.Len()); err != nil {
+			return
+		}
+		if opts.Explicit && opts.HasTag() {
+			err = marshalExplicitCollection(v, pkt, opts)
+		} else if opts.Sequence {
 			err = marshalSequenceOfSlice(v, pkt, opts)
 		} else {
 			err = marshalSet(v, pkt, opts)
@@ -192,6 +334,33 @@ func marshalComposite(v reflect.Value, pkt PDU, opts *Options) (err error) {
 	return
 }
 
+/*
+marshalExplicitCollection returns an error following an attempt to marshal
+slice v as a SET OF or SEQUENCE OF, which is then wrapped within the EXPLICIT
+tag declared within opts, e.g.: "[1] EXPLICIT SET OF OCTET STRING".
+*/
+func marshalExplicitCollection(v reflect.Value, pkt PDU, opts *Options) (err error) {
+	debugEnter(v, pkt, opts)
+	defer func() { debugExit(newLItem(err)) }()
+
+	tmp := newSubPacket(pkt)
+	inner := clearChildOpts(opts)
+	if inner.Sequence {
+		err = marshalSequenceOfSlice(v, tmp, inner)
+	} else {
+		err = marshalSet(v, tmp, inner)
+	}
+
+	if err == nil {
+		content := tmp.Data()
+		tlv := pkt.Type().newTLV(opts.Class(), opts.Tag(), len(content), true, content...)
+		pkt.Append(encodeTLV(tlv, nil)...)
+	}
+	tmp.Free()
+
+	return
+}
+
 func marshalInterfaceChoice(v reflect.Value, pkt PDU, opts *Options) (handled bool, err error) {
 	if optsHasChoices(opts) {
 		typ := pkt.Type()
@@ -204,7 +373,7 @@ func marshalInterfaceChoice(v reflect.Value, pkt PDU, opts *Options) (handled bo
 			cls := desc.class[tag]
 			exp := desc.explicit[tag]
 
-			tmp := typ.New()
+			tmp := newSubPacket(pkt)
 			tmp.SetOffset(0)
 
 			k := v.Kind()
@@ -303,7 +472,7 @@ func wrapMarshalExplicit(pkt PDU, prim codecRW, opts *Options) (err error) {
 	defer func() { debugExit(newLItem(err)) }()
 
 	typ := pkt.Type()
-	tmp := typ.New()
+	tmp := newSubPacket(pkt)
 	innerOpts := clearChildOpts(opts)
 
 	if _, err = prim.write(tmp, innerOpts); err == nil {
@@ -322,6 +491,16 @@ func wrapMarshalExplicit(pkt PDU, prim codecRW, opts *Options) (err error) {
 	return
 }
 
+/*
+MaxDecodeDepth declares the maximum number of nested constructed values
+(e.g.: SEQUENCE, SET OF or CHOICE) which [Unmarshal] shall descend into
+before aborting with an error. This guards against maliciously deep
+encodings, such as those crafted to exhaust the stack.
+
+A value of zero (0) or less disables the limit. The default is 256.
+*/
+var MaxDecodeDepth int = 256
+
 /*
 Unmarshal returns an error following an attempt to decode the input [PDU] instance
 into x. x MUST be a pointer.
@@ -333,6 +512,12 @@ It is not necessary to declare a particular [EncodingRule] using the [With] pack
 function, as the input instance of [PDU] already has this information. Providing an
 [EncodingRule] to Unmarshal -- whether valid or not -- will produce no perceptible effect.
 
+Should a component of a SEQUENCE or SET fail to decode, the error is prefixed with
+the path of field names leading to it, e.g.: "MySequence.Field2.Deep.Field2: ...".
+
+Any bytes which follow the top-level value are ignored, unless [WithStrictTrailing]
+is supplied. Their number may be obtained by way of [TrailingBytes].
+
 See also [Marshal], [MustMarshal], [MustUnmarshal] and [With].
 */
 func Unmarshal(pkt PDU, x any, with ...EncodingOption) error {
@@ -349,17 +534,82 @@ func Unmarshal(pkt PDU, x any, with ...EncodingOption) error {
 		return err
 	}
 
-	pkt.SetOffset(0)
-
 	cfg := &encodingConfig{rule: pkt.Type()}
 	for _, o := range with {
 		o(cfg)
 	}
+	cfg.resolve()
+
+	rule := pkt.Type()
+	if err = unmarshalPacket(pkt, rv.Elem(), cfg.opts); err != nil {
+		for _, fb := range cfg.fallback {
+			if fb == pkt.Type() || !fb.Enabled() {
+				continue
+			}
+
+			// discard any partial result of the prior attempt
+			rv.Elem().Set(reflect.Zero(rv.Elem().Type()))
+			alt := fb.New(pkt.Data()...)
+			ferr := unmarshalPacket(alt, rv.Elem(), cfg.opts)
+			alt.Free()
+			if ferr == nil {
+				err, rule = nil, fb
+				break
+			}
+		}
+	}
+
+	if err == nil {
+		err = checkTrailing(pkt, rule, cfg)
+	}
+
+	if err == nil && cfg.observed != nil {
+		*cfg.observed = rule
+	} else if _, ok := err.(fieldPathErr); ok {
+		// root the field path at the name of the target type
+		err = withFieldPath(rv.Elem().Type().Name(), err)
+	}
 
-	err = unmarshalValue(pkt, rv.Elem(), cfg.opts)
 	return err
 }
 
+/*
+checkTrailing returns an error if any bytes follow the top-level value
+within pkt, decoded per rule, and cfg forbids them. The number of such
+bytes is reported to cfg, if requested.
+*/
+func checkTrailing(pkt PDU, rule EncodingRule, cfg *encodingConfig) (err error) {
+	if rule.textual() || (!cfg.strict && cfg.trailing == nil) {
+		return
+	}
+
+	var full []byte
+	if full, err = parseFullBytes(pkt.Data(), 0, rule); err == nil {
+		n := pkt.Len() - len(full)
+		if cfg.strict && n > 0 {
+			err = errorTrailingData(n)
+		} else if cfg.trailing != nil {
+			*cfg.trailing = n
+		}
+	}
+
+	return
+}
+
+/*
+unmarshalPacket returns an error following an attempt to decode the
+contents of pkt, from the beginning, into v per the rule of pkt.
+*/
+func unmarshalPacket(pkt PDU, v reflect.Value, opts *Options) (err error) {
+	pkt.SetOffset(0)
+	if pkt.Type().textual() {
+		err = unmarshalText(pkt, v, opts)
+	} else {
+		err = unmarshalValue(pkt, v, opts)
+	}
+	return
+}
+
 /*
 MustUnmarshal panics if [Unmarshal] returned an error during processing.
 */
@@ -369,6 +619,63 @@ func MustUnmarshal(pkt PDU, x any, with ...EncodingOption) {
 	}
 }
 
+/*
+UnmarshalBytes returns an error following an attempt to decode data, which
+must be encoded per rule, into x by way of [Unmarshal]. The input [EncodingOption]
+instances are honored as they are by [Unmarshal].
+
+This spares the caller the manual construction of a [PDU] via [EncodingRule.New],
+and the underlying [PDU] is freed prior to return. The data is copied, and is
+never modified.
+*/
+func UnmarshalBytes(data []byte, rule EncodingRule, x any, with ...EncodingOption) (err error) {
+	if !rule.Enabled() {
+		err = errorRuleNotImplemented
+	} else {
+		err = Unmarshal(rule.New(data...), x, with...)
+	}
+
+	return
+}
+
+/*
+UnmarshalN returns the number of bytes consumed alongside an error following
+an attempt to decode the first top-level TLV within the input [PDU] instance
+into x. x MUST be a pointer. Any bytes which follow the first TLV are ignored.
+
+This is useful when processing a buffer which contains several concatenated
+encodings, as the returned count may be used to locate the next encoding.
+
+As with [Unmarshal], the input [PDU] instance is freed upon return. Note that
+only binary encoding rules, such as [BER], [CER] and [DER], are supported.
+
+See also [Unmarshal] and [StreamDecoder].
+*/
+func UnmarshalN(pkt PDU, x any, with ...EncodingOption) (n int, err error) {
+	debugEnter(x, with, pkt)
+	defer func() { debugExit(newLItem(n), newLItem(err)) }()
+
+	if pkt.Type().textual() {
+		pkt.Free()
+		err = errorTextualPDU
+		return
+	}
+
+	var full []byte
+	if full, err = parseFullBytes(pkt.Data(), 0, pkt.Type()); err != nil {
+		pkt.Free()
+		return
+	}
+
+	sub := pkt.Type().New(full...)
+	pkt.Free()
+	if err = Unmarshal(sub, x, with...); err == nil {
+		n = len(full)
+	}
+
+	return
+}
+
 /*
 unmarshalValue returns an error following an attempt to decode v into pkt, possibly
 aided by [Options] directives.
@@ -386,9 +693,22 @@ func unmarshalValue(pkt PDU, v reflect.Value, opts *Options) (err error) {
 	}
 	k := v.Kind()
 
-	opts = deferOverrideOptions(v, opts)
+	if ovr := deferOverrideOptions(v, opts); ovr != opts && opts != nil {
+		// preserve the recursion depth and constraint phase
+		// without altering the (shared) registered override.
+		o := *ovr
+		o.copyDepth(opts)
+		o.copyPhase(opts)
+		opts = &o
+	} else {
+		opts = ovr
+	}
+
+	if err = opts.checkDepth(); err != nil {
+		return
+	}
 
-	if k == reflect.Ptr {
+	if k == reflect.Ptr && !isAdaptedPointer(v, opts) {
 		err = unmarshalPointer(v, pkt, opts)
 		return
 	}
@@ -409,6 +729,11 @@ func unmarshalValue(pkt PDU, v reflect.Value, opts *Options) (err error) {
 	opts = deferImplicit(opts)
 	kw := opts.Identifier
 
+	if len(opts.EnumNames) > 0 {
+		err = unmarshalInlineEnum(v, pkt, opts)
+		return
+	}
+
 	if ad, ok := adapterForValue(v, kw); ok {
 		codec := ad.newCodec()
 		var tlv TLV
@@ -425,9 +750,16 @@ func unmarshalValue(pkt PDU, v reflect.Value, opts *Options) (err error) {
 		if err = codec.(codecRW).read(pkt, tlv, opts); err != nil {
 			return
 		}
-		pkt.SetOffset(start + outerLen)
+		if outerLen >= 0 {
+			// see unmarshalPrimitive
+			pkt.SetOffset(start + outerLen)
+		}
 
-		goVal := refValueOf(ad.toGo(codec))
+		var a any
+		if a, err = ad.toGo(codec); err != nil {
+			return
+		}
+		goVal := refValueOf(a)
 		if !goVal.Type().AssignableTo(v.Type()) {
 			err = codecErrorf("type mismatch decoding ", kw)
 		} else {
@@ -443,11 +775,16 @@ func unmarshalValue(pkt PDU, v reflect.Value, opts *Options) (err error) {
 
 	switch k {
 	case reflect.Slice:
-		if opts.Sequence {
+		if opts.Explicit && opts.HasTag() {
+			err = unmarshalExplicitCollection(v, pkt, opts)
+		} else if opts.Sequence {
 			err = unmarshalSequenceBranch(v, pkt, opts)
 		} else {
 			err = unmarshalSetBranch(v, pkt, opts)
 		}
+		if err == nil {
+			err = opts.checkSize(v.Len())
+		}
 	case reflect.Struct:
 		err = unmarshalSequence(v, pkt, opts)
 	default:
@@ -456,6 +793,42 @@ func unmarshalValue(pkt PDU, v reflect.Value, opts *Options) (err error) {
 	return
 }
 
+/*
+unmarshalExplicitCollection returns an error following an attempt to peel
+the EXPLICIT tag declared within opts from the next TLV within pkt, and to
+decode the enclosed SET OF or SEQUENCE OF into slice v.
+*/
+func unmarshalExplicitCollection(v reflect.Value, pkt PDU, opts *Options) (err error) {
+	debugEnter(v, pkt, opts)
+	defer func() { debugExit(newLItem(err)) }()
+
+	var outer TLV
+	if outer, err = pkt.TLV(); err != nil {
+		return
+	} else if !outer.matchClassAndTag(opts.Class(), opts.Tag()) {
+		err = compositeErrorf("expected EXPLICIT [", ClassNames[opts.Class()], " ",
+			opts.Tag(), "]; got class ", outer.Class, " / tag ", outer.Tag)
+		return
+	}
+
+	start := pkt.Offset()
+	sub := pkt.Type().New(outer.Value...)
+	sub.SetOffset(0)
+
+	inner := clearChildOpts(opts)
+	if inner.Sequence {
+		err = unmarshalSequenceBranch(v, sub, inner)
+	} else {
+		err = unmarshalSetBranch(v, sub, inner)
+	}
+
+	if err == nil {
+		pkt.SetOffset(start + len(outer.Value))
+	}
+
+	return
+}
+
 func unmarshalPointer(v reflect.Value, pkt PDU, opts *Options) (err error) {
 	if v.IsNil() {
 		err = refSetValue(v, refNew(v.Type().Elem()))
@@ -489,12 +862,20 @@ func unmarshalChoice(v reflect.Value, pkt PDU, opts *Options) (err error) {
 		return
 	}
 
-	// decode into the concrete Go value
+	// decode into the concrete Go value; a nested
+	// CHOICE is resolved using its own registry.
+	if nested, ok := cd.nested[tag]; ok {
+		chopts.Choices = nested
+	}
 	inner := refNew(cd.tagToType[tag]).Elem()
-	if err = unmarshalValue(sub, inner, chopts); err != nil {
+	if err = unmarshalValue(sub, inner, chopts); err == errorMaxDecodeDepth {
+		return
+	} else if err != nil {
 		err = codecErrorf("decodeCtxChoice[",
 			cd.tagToType[tag].String(), "]: ", err)
 		return
+	} else if err = cd.constrain(tag, inner, chopts); err != nil {
+		return
 	}
 
 	// ALWAYS wrap back into the Choice interface
@@ -517,33 +898,44 @@ func unmarshalSequenceBranch(v reflect.Value, pkt PDU, opts *Options) (err error
 		err = compositeErrorf("unmarshalSequenceBranch: no SEQUENCE header: ", err)
 		return
 	}
-	if !tlv.matchClassAndTag(ClassUniversal, TagSequence) {
-		err = compositeErrorf("expected UNIVERSAL SEQUENCE (16); got class ",
-			tlv.Class, " / tag ", tlv.Tag)
+	if tag, class := effectiveHeader(TagSequence, ClassUniversal, opts); !tlv.matchClassAndTag(class, tag) {
+		err = compositeErrorf("expected SEQUENCE OF [", ClassNames[class], " ", tag,
+			"]; got class ", tlv.Class, " / tag ", tlv.Tag)
 		return
 	}
 	start := pkt.Offset()
-	end := start + tlv.Length
-	if end > pkt.Len() {
+	end, next := start+tlv.Length, start+tlv.Length
+	if tlv.Length < 0 {
+		// indefinite length: content ends at the matching EOC
+		var idx int
+		if idx, err = findEOC(pkt.Data()[start:]); err != nil {
+			err = compositeErrorf("unmarshalSequenceBranch: ", err)
+			return
+		}
+		end, next = start+idx, start+idx+len(indefEoC)
+	} else if end > pkt.Len() {
 		err = compositeErrorf("unmarshalSequenceBranch: truncated content")
 		return
 	}
 
 	data := pkt.Data()[start:end]
-	pkt.SetOffset(end)
+	pkt.SetOffset(next)
 
 	sub := pkt.Type().New(data...)
 	sub.SetOffset(0)
 
-	elemOpts := *opts
+	elemOpts := *clearChildOpts(opts)
 	elemOpts.Sequence = false
+	elemOpts.incDepth()
 
 	elemType := v.Type().Elem()
 	for sub.Offset() < len(data) {
 		// create a zero‐value element
 		elem := refNew(elemType).Elem()
 		if err = unmarshalValue(sub, elem, &elemOpts); err != nil {
-			err = compositeErrorf("unmarshalSequenceBranch: element decode failed: ", err)
+			if err != errorMaxDecodeDepth {
+				err = compositeErrorf("unmarshalSequenceBranch: element decode failed: ", err)
+			}
 			return
 		}
 		if err = refSetValue(v, refAppend(v, elem)); err != nil {
@@ -606,6 +998,8 @@ func unmarshalSetBranch(v reflect.Value, pkt PDU, opts *Options) (err error) {
 			innerVal := refNew(childType).Elem()
 			if err = unmarshalValue(childPK, innerVal, childOpts); err != nil {
 				return
+			} else if err = cd.constrain(tag, innerVal, childOpts); err != nil {
+				return
 			}
 
 			// convert and append
@@ -655,7 +1049,9 @@ func unmarshalPrimitive(pkt PDU, v reflect.Value, opts *Options) (err error) {
 		}
 	}
 
-	if err == nil {
+	if err == nil && tlv.Length >= 0 {
+		// For indefinite lengths, the codec is responsible
+		// for advancing beyond the end-of-contents octets.
 		pkt.SetOffset(start + tlv.Length)
 	}
 	return
@@ -670,6 +1066,7 @@ func unmarshalHandleTag(kw string, pkt PDU, tlv *TLV, opts *Options) (err error)
 			err = codecErrorf("identifier mismatch decoding ", kw)
 		} else if opts.Explicit {
 			inner := pkt.Type().New(tlv.Value...)
+			inner.SetOffset(0)
 			var innerTLV TLV
 			if innerTLV, err = inner.TLV(); err == nil {
 				*tlv = innerTLV
@@ -1,9 +1,457 @@
 package asn1plus
 
-import "testing"
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
 
 func TestMustMarshalRoundtrip(t *testing.T) {
 	// Simply for code coverage
 	var dest PrintableString
 	MustUnmarshal(MustMarshal(MustNewPrintableString("testing123")), &dest)
 }
+
+func TestMarshalBytes(t *testing.T) {
+	type Entry struct {
+		Name PrintableString
+		Code Integer `asn1:"tag:0,explicit"`
+	}
+
+	code, _ := NewInteger(1234)
+	entry := Entry{Name: PrintableString("alpha"), Code: code}
+
+	for _, rule := range encodingRules {
+		b, err := MarshalBytes(entry, With(rule))
+		if err != nil {
+			t.Fatalf("%s failed [%s MarshalBytes]: %v", t.Name(), rule, err)
+		}
+
+		pkt, err := Marshal(entry, With(rule))
+		if err != nil {
+			t.Fatalf("%s failed [%s Marshal]: %v", t.Name(), rule, err)
+		} else if want, got := pkt.Hex(), formatHex(b); want != got {
+			t.Fatalf("%s failed [%s]:\n\twant: %s\n\tgot:  %s", t.Name(), rule, want, got)
+		}
+		pkt.Free()
+
+		// the result is detached from the (freed) buffer
+		again, _ := MarshalBytes(Entry{Name: PrintableString("bravo"), Code: code}, With(rule))
+		if bytes.Equal(b, again) || !bytes.Contains(b, []byte("alpha")) {
+			t.Fatalf("%s failed [%s]: output was not detached: %X", t.Name(), rule, b)
+		}
+
+		// options are honored
+		if _, err = MarshalBytes(entry, With(rule, MaxOutputSize(4))); err == nil {
+			t.Fatalf("%s failed [%s]: expected output size error, got nil", t.Name(), rule)
+		}
+	}
+}
+
+func TestEncodedLen(t *testing.T) {
+	type Entry struct {
+		Name  PrintableString
+		Code  Integer `asn1:"tag:0,explicit"`
+		Items []OctetString
+		Large OctetString
+	}
+
+	code, _ := NewInteger(1234)
+	entry := Entry{Name: PrintableString("alpha"), Code: code,
+		Items: []OctetString{OctetString("one"), OctetString("two")},
+		Large: OctetString(bytes.Repeat([]byte{'x'}, 1500))}
+
+	type Outer struct {
+		Inner Entry
+		When  GeneralizedTime
+		Set   []Integer `asn1:"set"`
+	}
+
+	when, _ := NewGeneralizedTime("20240102030405Z")
+	outer := Outer{Inner: entry, When: when, Set: []Integer{code, {}}}
+
+	for _, x := range []any{entry, outer, code, OctetString("hello"), []OctetString{}} {
+		for _, rule := range append(encodingRules, DefaultEncoding) {
+			pkt, err := Marshal(x, With(rule))
+			if err != nil {
+				t.Fatalf("%s failed [%s Marshal]: %v", t.Name(), rule, err)
+			}
+
+			// a conflicting rule within the options does not prevail
+			n, err := EncodedLen(x, rule, With(BER, &Options{}))
+			if err != nil {
+				t.Fatalf("%s failed [%s EncodedLen]: %v", t.Name(), rule, err)
+			} else if n != len(pkt.Data()) {
+				t.Fatalf("%s failed [%s %T]: want %d, got %d", t.Name(), rule, x, len(pkt.Data()), n)
+			}
+			pkt.Free()
+		}
+	}
+
+	if _, err := EncodedLen(entry, invalidEncodingRule); err != errorRuleNotImplemented {
+		t.Fatalf("%s failed: want %v, got %v", t.Name(), errorRuleNotImplemented, err)
+	}
+	if _, err := EncodedLen(entry, BER, MaxOutputSize(4)); err == nil {
+		t.Fatalf("%s failed: expected output size error, got nil", t.Name())
+	}
+}
+
+func TestMarshal_maxOutputSize(t *testing.T) {
+	type Large struct {
+		Name  PrintableString
+		Items []OctetString `asn1:"sequence"`
+		Tags  []OctetString
+	}
+
+	large := Large{Name: PrintableString("large")}
+	for i := 0; i < 1000; i++ {
+		large.Items = append(large.Items, OctetString(fmt.Sprintf("item-%011d", i)))
+		large.Tags = append(large.Tags, OctetString(fmt.Sprintf("tag-%012d", i)))
+	}
+
+	for _, rule := range encodingRules {
+		full, err := Marshal(large, With(rule))
+		if err != nil {
+			t.Fatalf("%s failed [%s marshal]: %v", t.Name(), rule, err)
+		}
+
+		// within budget
+		if _, err = Marshal(large, With(rule, MaxOutputSize(full.Len()))); err != nil {
+			t.Fatalf("%s failed [%s within budget]: %v", t.Name(), rule, err)
+		}
+
+		// over budget: ensure the abort happened early, long before
+		// the full encoding was assembled.
+		_, err = Marshal(large, With(rule, MaxOutputSize(64)))
+		if err == nil {
+			t.Fatalf("%s failed [%s over budget]: expected error, got nil", t.Name(), rule)
+		}
+
+		const pfx = "maximum output size exceeded: "
+		var written int
+		if idx := strings.Index(err.Error(), pfx); idx < 0 {
+			t.Fatalf("%s failed [%s over budget]: unexpected error: %v", t.Name(), rule, err)
+		} else if fmt.Sscanf(err.Error()[idx+len(pfx):], "%d", &written); written > 128 {
+			t.Fatalf("%s failed [%s early abort]: %d bytes written of %d", t.Name(), rule, written, full.Len())
+		}
+
+		// SET OF budget
+		if _, err = Marshal(large.Tags, With(rule, MaxOutputSize(64))); err == nil {
+			t.Fatalf("%s failed [%s SET OF over budget]: expected error, got nil", t.Name(), rule)
+		}
+	}
+
+	// character-based rules are checked upon completion
+	if _, err := Marshal(large, With(GSER, MaxOutputSize(64))); err == nil {
+		t.Fatalf("%s failed [GSER over budget]: expected error, got nil", t.Name())
+	}
+}
+
+func TestUnmarshal_ObservedRule(t *testing.T) {
+	type Entry struct {
+		Name PrintableString
+		Code Enumerated
+	}
+
+	// indefinite-length BER encoding
+	data := []byte{0x30, 0x80,
+		0x13, 0x05, 'd', 'e', 'l', 't', 'a',
+		0x0A, 0x01, 0x04,
+		0x00, 0x00}
+	want := Entry{PrintableString("delta"), 4}
+
+	var observed EncodingRule
+	var out Entry
+	if err := Unmarshal(BER.New(data...), &out, With(ObservedRule(&observed))); err != nil {
+		t.Fatalf("%s failed [BER]: %v", t.Name(), err)
+	} else if observed != BER || out != want {
+		t.Fatalf("%s failed [BER]: want %s %#v, got %s %#v", t.Name(), BER, want, observed, out)
+	}
+
+	if !DER.Enabled() {
+		return
+	}
+
+	observed, out = invalidEncodingRule, Entry{}
+	if err := Unmarshal(DER.New(data...), &out, With(ObservedRule(&observed))); err == nil {
+		t.Fatalf("%s failed [DER]: expected error, got nil", t.Name())
+	} else if observed != invalidEncodingRule {
+		t.Fatalf("%s failed [DER]: observed rule written upon failure: %s", t.Name(), observed)
+	}
+
+	out = Entry{}
+	if err := Unmarshal(DER.New(data...), &out,
+		With(FallbackRules(DER, BER), ObservedRule(&observed))); err != nil {
+		t.Fatalf("%s failed [DER→BER]: %v", t.Name(), err)
+	} else if observed != BER || out != want {
+		t.Fatalf("%s failed [DER→BER]: want %s %#v, got %s %#v", t.Name(), BER, want, observed, out)
+	}
+}
+
+func TestUnmarshalN(t *testing.T) {
+	type Entry struct {
+		Name PrintableString
+		Code Enumerated
+	}
+
+	first := Entry{PrintableString("alpha"), 1}
+	second := Entry{PrintableString("bravo"), 2}
+
+	for _, rule := range encodingRules {
+		pkt1, _ := Marshal(first, With(rule))
+		pkt2, _ := Marshal(second, With(rule))
+		buf := append(append([]byte{}, pkt1.Data()...), pkt2.Data()...)
+
+		var got Entry
+		n, err := UnmarshalN(rule.New(buf...), &got)
+		if err != nil {
+			t.Fatalf("%s failed [%s first]: %v", t.Name(), rule, err)
+		} else if n != pkt1.Len() || got != first {
+			t.Fatalf("%s failed [%s first]: want %d/%v, got %d/%v",
+				t.Name(), rule, pkt1.Len(), first, n, got)
+		}
+
+		if n, err = UnmarshalN(rule.New(buf[n:]...), &got); err != nil {
+			t.Fatalf("%s failed [%s second]: %v", t.Name(), rule, err)
+		} else if n != pkt2.Len() || got != second {
+			t.Fatalf("%s failed [%s second]: want %d/%v, got %d/%v",
+				t.Name(), rule, pkt2.Len(), second, n, got)
+		}
+	}
+
+	var dest Entry
+	if _, err := UnmarshalN(BER.New(0x30, 0x05, 0x13), &dest); err == nil {
+		t.Fatalf("%s failed: expected truncation error, got nil", t.Name())
+	}
+	if !JER.Enabled() {
+		return
+	}
+	if _, err := UnmarshalN(JER.New([]byte(`{}`)...), &dest); err != errorTextualPDU {
+		t.Fatalf("%s failed: want %v, got %v", t.Name(), errorTextualPDU, err)
+	}
+}
+
+func TestUnmarshal_trailing(t *testing.T) {
+	first, second := Integer{native: 5}, OctetString("trailer")
+	if n := Remaining(nil); n != 0 {
+		t.Fatalf("%s failed [nil Remaining]: want 0, got %d", t.Name(), n)
+	}
+
+	for _, rule := range encodingRules {
+		pkt1, _ := Marshal(first, With(rule))
+		pkt2, _ := Marshal(second, With(rule))
+		buf := append(append([]byte{}, pkt1.Data()...), pkt2.Data()...)
+
+		pkt := rule.New(buf...)
+		pkt.SetOffset(0)
+		if tlv, err := pkt.TLV(); err != nil {
+			t.Fatalf("%s failed [%s TLV]: %v", t.Name(), rule, err)
+		} else if pkt.AddOffset(tlv.Length); Remaining(pkt) != pkt2.Len() {
+			t.Fatalf("%s failed [%s Remaining]: want %d, got %d", t.Name(), rule, pkt2.Len(), Remaining(pkt))
+		}
+		pkt.Free()
+
+		var got Integer
+		trailing := -1
+		if err := Unmarshal(rule.New(buf...), &got, TrailingBytes(&trailing)); err != nil {
+			t.Fatalf("%s failed [%s lenient]: %v", t.Name(), rule, err)
+		} else if trailing != pkt2.Len() || got.Big().Int64() != 5 {
+			t.Fatalf("%s failed [%s lenient]: want %d trailing bytes, got %d", t.Name(), rule, pkt2.Len(), trailing)
+		}
+
+		if err := Unmarshal(rule.New(buf...), &got, WithStrictTrailing()); err == nil {
+			t.Fatalf("%s failed [%s strict]: expected trailing data error, got nil", t.Name(), rule)
+		}
+		if err := Unmarshal(rule.New(pkt1.Data()...), &got,
+			WithStrictTrailing(), TrailingBytes(&trailing)); err != nil {
+			t.Fatalf("%s failed [%s strict]: %v", t.Name(), rule, err)
+		} else if trailing != 0 {
+			t.Fatalf("%s failed [%s strict]: want 0 trailing bytes, got %d", t.Name(), rule, trailing)
+		}
+	}
+}
+
+type decodeDepthNode struct {
+	Next *decodeDepthNode `asn1:"optional"`
+}
+
+func TestUnmarshal_MaxDecodeDepth(t *testing.T) {
+	nested := func(n int) []byte {
+		var b []byte
+		for i := 0; i < n; i++ {
+			b = append(b, 0x30, indefByte)
+		}
+		for i := 0; i < n; i++ {
+			b = append(b, indefEoC...)
+		}
+		return b
+	}
+
+	var shallow decodeDepthNode
+	if err := Unmarshal(BER.New(nested(10)...), &shallow); err != nil {
+		t.Fatalf("%s failed [shallow]: %v", t.Name(), err)
+	}
+	if shallow.Next == nil || shallow.Next.Next == nil {
+		t.Fatalf("%s failed [shallow]: nested levels not decoded", t.Name())
+	}
+
+	deep := nested(MaxDecodeDepth + 2)
+
+	var node decodeDepthNode
+	if err := Unmarshal(BER.New(deep...), &node); err != errorMaxDecodeDepth {
+		t.Fatalf("%s failed [deep]: want %v, got %v", t.Name(), errorMaxDecodeDepth, err)
+	}
+
+	var nodes []decodeDepthNode
+	if err := Unmarshal(BER.New(deep...), &nodes, With(&Options{Sequence: true})); err != errorMaxDecodeDepth {
+		t.Fatalf("%s failed [deep SEQUENCE OF]: want %v, got %v", t.Name(), errorMaxDecodeDepth, err)
+	}
+
+	dec := NewStreamDecoder(bytes.NewReader(deep), BER)
+	if err := dec.Decode(&node); err != errorMaxDecodeDepth {
+		t.Fatalf("%s failed [stream]: want %v, got %v", t.Name(), errorMaxDecodeDepth, err)
+	}
+
+	defer func(d int) { MaxDecodeDepth = d }(MaxDecodeDepth)
+	MaxDecodeDepth = 0
+	node = decodeDepthNode{}
+	if err := Unmarshal(BER.New(deep...), &node); err != nil {
+		t.Fatalf("%s failed [unlimited]: %v", t.Name(), err)
+	}
+}
+
+type phaseString PrintableString
+
+func (_ phaseString) Tag() int          { return TagPrintableString }
+func (r phaseString) String() string    { return string(r) }
+func (_ phaseString) IsPrimitive() bool { return true }
+
+func TestOptions_SetConstraintPhase(t *testing.T) {
+	RegisterTextAlias[phaseString](TagPrintableString,
+		CodecConstraintDecoding, nil, nil, nil, PrintableSpec,
+		func(x any) (err error) {
+			if s, _ := x.(phaseString); s == "forbidden" {
+				err = fmt.Errorf("value %q is forbidden", s)
+			}
+			return
+		})
+	defer unregisterType(refTypeOf(phaseString("")))
+
+	type Wrapper struct {
+		Name phaseString
+	}
+
+	pkt, err := Marshal(Wrapper{Name: "forbidden"})
+	if err != nil {
+		t.Fatalf("%s failed [marshal]: %v", t.Name(), err)
+	}
+	data := pkt.Data()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 64)
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func(skip bool) {
+			defer wg.Done()
+			var with []EncodingOption
+			if skip {
+				with = append(with, With((&Options{}).SetConstraintPhase(CodecConstraintNone)))
+			}
+
+			var w Wrapper
+			err := Unmarshal(BER.New(data...), &w, with...)
+			if skip && err != nil {
+				errs <- fmt.Errorf("override: unexpected error: %v", err)
+			} else if !skip && err == nil {
+				errs <- fmt.Errorf("no override: expected constraint violation, got nil")
+			}
+		}(i%2 == 0)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	if got := (&Options{}).SetConstraintPhase(42).constraintPhase(CodecConstraintBoth); got != CodecConstraintBoth {
+		t.Fatalf("%s failed: invalid phase should be ignored, got %d", t.Name(), got)
+	}
+}
+
+func TestWithoutConstraints(t *testing.T) {
+	RegisterTaggedConstraint("withoutConstraintsNoDigits", func(x any) (err error) {
+		if o, _ := x.(OctetString); bytes.ContainsAny(o, "0123456789") {
+			err = fmt.Errorf("digits are prohibited")
+		}
+		return
+	})
+
+	type Badge struct {
+		Serial OctetString `asn1:"constrained-by:withoutConstraintsNoDigits"`
+	}
+
+	badge := Badge{Serial: OctetString("A113")}
+	if _, err := Marshal(badge); err == nil {
+		t.Fatalf("%s failed: expected constraint violation, got nil", t.Name())
+	}
+
+	for _, with := range [][]EncodingOption{
+		{WithoutConstraints()},
+		{WithoutConstraints(), With(BER, &Options{})},
+		{With(BER, &Options{}), WithoutConstraints()},
+	} {
+		pkt, err := Marshal(badge, with...)
+		if err != nil {
+			t.Fatalf("%s failed [marshal]: %v", t.Name(), err)
+		}
+
+		var out Badge
+		if err = Unmarshal(pkt, &out, with...); err != nil {
+			t.Fatalf("%s failed [unmarshal]: %v", t.Name(), err)
+		} else if !btseq(out.Serial, badge.Serial) {
+			t.Fatalf("%s failed: want %s, got %s", t.Name(), badge.Serial, out.Serial)
+		}
+	}
+
+	// the caller's Options instance must not be altered
+	opts := &Options{}
+	if _, err := Marshal(badge, With(opts), WithoutConstraints()); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	} else if opts.cphase != nil {
+		t.Fatalf("%s failed: caller Options instance was modified", t.Name())
+	}
+}
+
+func benchmarkConstrainedDecode(b *testing.B, with ...EncodingOption) {
+	type Record struct {
+		ID   Integer
+		Data OctetString
+	}
+
+	id, _ := NewInteger(1234567)
+	pkt, err := Marshal(Record{ID: id, Data: OctetString("0123456789ABCDEF")})
+	if err != nil {
+		b.Fatal(err)
+	}
+	data := pkt.Data()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var rec Record
+		if err = Unmarshal(BER.New(data...), &rec, with...); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshal_constraints(b *testing.B) {
+	benchmarkConstrainedDecode(b)
+}
+
+func BenchmarkUnmarshal_withoutConstraints(b *testing.B) {
+	benchmarkConstrainedDecode(b, WithoutConstraints())
+}
@@ -6,7 +6,10 @@ ASN.1 SEQUENCE composite type which, in Go, manifests
 as a struct.
 */
 
-import "reflect"
+import (
+	"reflect"
+	"sync"
+)
 
 /*
 RawContent implements a []byte slice in the same context
@@ -14,6 +17,20 @@ as the [encoding/asn1.RawContent] type.
 */
 type RawContent []byte
 
+/*
+RawValue implements a []byte slice which holds the complete encoding
+of a single TLV -- identifier, length and content octets -- verbatim.
+
+When used as a SEQUENCE field, the TLV found at the field's position is
+captured as-is during [Unmarshal] without being decoded, and is emitted
+byte-for-byte during [Marshal]. This is useful for pass-through proxying
+of components which need not (or cannot) be interpreted.
+
+A field of type []byte may be treated in the same manner by way of the
+"raw" struct tag keyword. See [Options.Raw] for details.
+*/
+type RawValue []byte
+
 /*
 SequenceComponentsConstraintPhase declares the appropriate phase for
 the enforcement of PRESENT/ABSENT field values during codec operations.
@@ -24,6 +41,19 @@ for possible settings.
 */
 var SequenceComponentsConstraintPhase = CodecConstraintDecoding
 
+/*
+StrictSequenceOrdering declares whether a SEQUENCE is rejected during
+decoding should any of its components remain once all fields have been
+processed. Such a component either belongs to a field whose tag appeared
+out of the declared order -- in which case it would otherwise have been
+skipped as an absent OPTIONAL field -- or to no field at all.
+
+The default is false, in which case such components are silently ignored.
+SEQUENCEs bearing an extension field (see [Options.Extension]) collect any
+trailing components, and are not affected by this setting.
+*/
+var StrictSequenceOrdering bool
+
 /*
 marshalSequence returns an error following an
 attempt to marshal sequence (struct) v into pkt.
@@ -56,21 +86,27 @@ func marshalSequence(v reflect.Value, pkt PDU, opts *Options) (err error) {
 		return
 	}
 
-	sub := pkt.Type().New()
-	auto := optsIsAutoTag(opts)
+	sub := newSubPacket(pkt)
+	auto := automaticTagging(opts, fields)
 
 	for i := 0; i < len(fields) && err == nil; i++ {
 		if field := fields[i]; field.PkgPath == "" && rawIdx != i {
 			var fOpts *Options
 			if fOpts, err = extractOptions(field, i, auto); err == nil {
+				fOpts.copyPhase(opts)
 				if i == extIdx {
 					err = marshalSequenceExtensionField(v.Field(i), sub, fOpts)
 				} else if fOpts.ComponentsOf {
 					err = marshalSequenceComponentsOf(field, v.Field(i), sub, fOpts, auto)
+				} else if isRawField(field, fOpts) {
+					err = marshalSequenceRawField(field.Name, v.Field(i), sub, fOpts)
 				} else {
 					err = marshalSequenceField(field.Name, v, v.Field(i), sub, fOpts)
 				}
 			}
+			if err == nil {
+				err = checkOutputLimit(sub, 0)
+			}
 		}
 	}
 
@@ -83,20 +119,34 @@ func marshalSequence(v reflect.Value, pkt PDU, opts *Options) (err error) {
 
 /*
 structFields returns slices of [reflect.StructField].
+
+Results are cached per struct type within structFieldsCache. The return
+value is shared, and must not be modified.
 */
 func structFields(t reflect.Type) (fields []reflect.StructField) {
 	t = derefTypePtr(t)
 	if t.Kind() == reflect.Struct {
+		if cached, found := structFieldsCache.Load(t); found {
+			return cached.([]reflect.StructField)
+		}
+
 		num := t.NumField()
 		fields = make([]reflect.StructField, 0, num)
 
 		for i := 0; i < num; i++ {
 			fields = append(fields, t.Field(i))
 		}
+		structFieldsCache.Store(t, fields)
 	}
 	return fields
 }
 
+/*
+structFieldsCache maps struct instances of [reflect.Type] to the
+[reflect.StructField] slices returned by structFields.
+*/
+var structFieldsCache sync.Map
+
 func marshalSequenceExtensionField(v reflect.Value, pkt PDU, opts *Options) (err error) {
 	debugEnter(v, opts, pkt)
 	defer func() { debugExit(newLItem(err)) }()
@@ -126,10 +176,22 @@ func marshalSequenceField(name string, v, fv reflect.Value, pkt PDU, opts *Optio
 		return
 	}
 
+	if fv.Kind() == reflect.Ptr && fv.IsNil() && (optsIsOptional(opts) || optsIsAbsent(opts)) {
+		// Nil pointer denotes an absent OPTIONAL field.
+		return
+	}
+
+	start := pkt.Len()
+	defer func() {
+		if err == nil {
+			debugAnnotate(pkt, start, name)
+		}
+	}()
+
 	// Check optional vs. missing value state
 	if err = checkSequenceFieldCriticality(name, fv, opts); err == nil {
 		// Apply any constraints (if we're supposed to)
-		if err = applyFieldConstraints(fv.Interface(), opts.Constraints, '^'); err == nil {
+		if err = applyFieldConstraints(fv.Interface(), opts, '^'); err == nil {
 			var handled bool
 			// If field is some kind of Choice, handle it.
 			handled, err = marshalSequenceFieldChoice(v, fv, pkt, opts)
@@ -144,12 +206,57 @@ func marshalSequenceField(name string, v, fv reflect.Value, pkt PDU, opts *Optio
 	return
 }
 
+/*
+isRawField returns a Boolean value indicative of whether field is to be
+captured and emitted verbatim, either because it is of type [RawValue] or
+because the "raw" keyword was declared.
+*/
+func isRawField(field reflect.StructField, opts *Options) bool {
+	return field.Type == rawValueType || (opts != nil && opts.Raw)
+}
+
+/*
+marshalSequenceRawField returns an error following an attempt to write
+the raw TLV held by fv into pkt verbatim. An empty value is omitted
+entirely, unless the field is neither OPTIONAL nor marked omitempty.
+*/
+func marshalSequenceRawField(name string, fv reflect.Value, pkt PDU, opts *Options) (err error) {
+	debugEnter(newLItem(name, "raw field"), fv, pkt, opts)
+	defer func() { debugExit(newLItem(err)) }()
+
+	if fv.Kind() != reflect.Slice || fv.Type().Elem().Kind() != reflect.Uint8 {
+		err = compositeErrorf("raw field ", name, " must be RawValue or []byte, got ", fv.Type())
+		return
+	}
+
+	raw := fv.Bytes()
+	if len(raw) == 0 {
+		if !optsIsOptional(opts) && !optsIsOmit(opts) {
+			err = compositeErrorf("raw field ", name, " is empty")
+		}
+		return
+	}
+
+	// Ensure the value is exactly one well-formed TLV, lest
+	// the enclosing SEQUENCE be rendered unparseable.
+	var full []byte
+	if full, err = parseFullBytes(raw, 0, pkt.Type()); err != nil {
+		err = compositeErrorf("raw field ", name, ": ", err)
+	} else if len(full) != len(raw) {
+		err = compositeErrorf("raw field ", name, ": trailing data after TLV")
+	} else {
+		pkt.Append(raw...)
+	}
+
+	return
+}
+
 func marshalSequenceComponentsOf(
 	field reflect.StructField,
 	v reflect.Value,
 	sub PDU,
 	opts *Options,
-	auto bool,
+	auto autoTagging,
 ) (err error) {
 	debugEnter(newLItem(field.Name, "field"),
 		newLItem(auto, "auto tag"), v, sub, opts)
@@ -160,12 +267,18 @@ func marshalSequenceComponentsOf(
 		return
 	}
 
+	v = componentsOfValue(v, false)
+	if err = checkComponentsOfKind(v); err != nil {
+		return
+	}
+
 	t := v.Type()
 	for i := 0; i < t.NumField() && err == nil; i++ {
 		if field = t.Field(i); field.PkgPath == "" {
 			var fOpts *Options
 			if fOpts, err = extractOptions(field, i, auto); err == nil {
 				fOpts.copyDepth(opts)
+				fOpts.copyPhase(opts)
 				err = marshalSequenceField(field.Name, v, v.Field(i), sub, fOpts)
 			}
 		}
@@ -207,28 +320,25 @@ func getSequenceTag(o *Options) (seqTag int) {
 	return
 }
 
-func marshalSequenceOfSlice(v reflect.Value, pkt PDU, _ *Options) (err error) {
-	debugEnter(v, pkt)
+func marshalSequenceOfSlice(v reflect.Value, pkt PDU, opts *Options) (err error) {
+	debugEnter(v, pkt, opts)
 	defer func() { debugExit(newLItem(err)) }()
 
 	typ := pkt.Type()
-	sub := typ.New()
+	sub := newSubPacket(pkt)
 	for i := 0; i < v.Len() && err == nil; i++ {
-		err = marshalValue(v.Index(i), sub, implicitOptions())
+		if err = marshalValue(v.Index(i), sub, implicitOptions()); err == nil {
+			err = checkOutputLimit(sub, 0)
+		}
 	}
 
 	if err == nil {
-		id := emitHeader(ClassUniversal, TagSequence, true)
-		debugPrim(newLItem(id, "header"))
-		pkt.Append(id)
+		// honor any IMPLICIT tag, e.g.: [2] IMPLICIT SEQUENCE OF
+		tag, class := effectiveHeader(TagSequence, ClassUniversal, opts)
+		debugPrim(newLItem([]int{class, tag}, "header class/tag"))
 		content := sub.Data()
-
-		bufPtr := getBuf()
-		encodeLengthInto(typ, bufPtr, len(content))
-		pkt.Append(*bufPtr...)
-		putBuf(bufPtr)
-
-		pkt.Append(content...)
+		tlv := typ.newTLV(class, tag, len(content), true, content...)
+		pkt.Append(encodeTLV(tlv, nil)...)
 	}
 
 	return
@@ -280,6 +390,7 @@ func marshalSequenceWrap(sub, pkt PDU, opts *Options, seqTag int) (err error) {
 
 	tlv := pkt.Type().newTLV(class, tag, len(content), true, content...)
 	pkt.Append(encodeTLV(tlv, opts)...)
+	debugMergeAnnotations(sub, pkt, pkt.Len()-len(content))
 
 	return
 }
@@ -299,20 +410,33 @@ func unmarshalSequence(v reflect.Value, pkt PDU, opts *Options) (err error) {
 
 	start := pkt.Offset()
 	end := start + tlv.Length
-	if end > pkt.Len() {
+	next := end
+	if tlv.Length < 0 {
+		// indefinite length: content ends at the matching EOC
+		var idx int
+		if !pkt.Type().allowsIndefinite() {
+			err = errorIndefiniteProhibited
+			return
+		} else if idx, err = findEOC(pkt.Data()[start:]); err != nil {
+			err = compositeErrorf("unmarshalValue: ", err)
+			return
+		}
+		end, next = start+idx, start+idx+len(indefEoC)
+	} else if end > pkt.Len() {
 		err = compositeErrorf("unmarshalValue: insufficient data for SEQUENCE content")
 		return
 	}
 
 	seqContent := pkt.Data()[start:end]
-	pkt.SetOffset(end)
+	pkt.SetOffset(next)
 	sub := pkt.Type().New(seqContent...)
 	sub.SetOffset(0)
 
 	typ := v.Type()
 	fields := structFields(typ)
 
-	if rawIdx := findRawContentIndex(typ, fields); rawIdx == 0 {
+	rawIdx := findRawContentIndex(typ, fields)
+	if rawIdx == 0 {
 		if err = refSetValue(v.Field(0), refValueOf(tlv.Value)); err != nil {
 			return
 		}
@@ -321,17 +445,22 @@ func unmarshalSequence(v reflect.Value, pkt PDU, opts *Options) (err error) {
 	var extIdx int
 	extIdx, err = findExtensibleIndex(fields, opts)
 
-	auto := optsIsAutoTag(opts)
+	auto := automaticTagging(opts, fields)
 	for i := 0; i < len(fields) && err == nil; i++ {
-		if field := fields[i]; field.PkgPath == "" {
+		if field := fields[i]; field.PkgPath == "" && rawIdx != i {
 			var fOpts *Options
 			if fOpts, err = extractOptions(field, i, auto); err == nil {
+				fOpts.copyDepth(opts)
+				fOpts.copyPhase(opts)
+				fOpts.incDepth()
 				if i == extIdx {
 					err = unmarshalSequenceExtensionField(v.Field(i), sub, fOpts)
 				} else if field.Type == rawContentType && i != 0 {
 					err = errorExtensionNotFieldZero
 				} else if fOpts.ComponentsOf {
 					err = unmarshalSequenceComponentsOf(field, v.Field(i), sub, fOpts, auto)
+				} else if isRawField(field, fOpts) {
+					err = unmarshalSequenceRawField(field.Name, v.Field(i), sub, fOpts)
 				} else {
 					err = unmarshalSequenceField(field.Name, v.Field(i), sub, fOpts)
 				}
@@ -339,6 +468,10 @@ func unmarshalSequence(v reflect.Value, pkt PDU, opts *Options) (err error) {
 		}
 	}
 
+	if err == nil && StrictSequenceOrdering && extIdx < 0 {
+		err = checkSequenceOrdering(fields, sub, auto)
+	}
+
 	// If 'WITH COMPONENTS' is specified, ensure field value
 	// states are in full compliance in terms of PRESENT/ABSENT.
 	if err == nil {
@@ -350,6 +483,34 @@ func unmarshalSequence(v reflect.Value, pkt PDU, opts *Options) (err error) {
 	return
 }
 
+/*
+checkSequenceOrdering returns an error if any component remains within sub
+following the processing of all fields. The error names the field to which
+the first such component belongs, if any, as it can only have appeared out
+of the declared order.
+*/
+func checkSequenceOrdering(fields []reflect.StructField, sub PDU, auto autoTagging) (err error) {
+	if !sub.HasMoreData() {
+		return
+	}
+
+	var tlv TLV
+	if tlv, err = sub.PeekTLV(); err != nil {
+		return
+	}
+
+	for i := 0; i < len(fields); i++ {
+		if field := fields[i]; field.PkgPath == "" {
+			if fOpts, _ := extractOptions(field, i, auto); fOpts != nil &&
+				fOpts.HasTag() && tlv.matchClassAndTag(fOpts.Class(), fOpts.Tag()) {
+				return errorSequenceOrdering(field.Name, tlv)
+			}
+		}
+	}
+
+	return errorSequenceOrdering("", tlv)
+}
+
 func unmarshalSequenceExtensionField(v reflect.Value, pkt PDU, opts *Options) (err error) {
 	debugEnter(v, opts, pkt)
 	defer func() { debugExit(newLItem(err)) }()
@@ -379,7 +540,15 @@ func unmarshalSequenceField(
 	debugEnter(newLItem(name, "field"), fv, opts, sub)
 	defer func() { debugExit(newLItem(err)) }()
 
-	if fv.Kind() == reflect.Ptr {
+	if fv.Kind() == reflect.Ptr && !isAdaptedPointer(fv, opts) {
+		// An absent OPTIONAL field bearing no DEFAULT is left nil.
+		var absent bool
+		if absent, err = unmarshalSequenceFieldOptionalEmpty(sub, fv, opts); err != nil {
+			return
+		} else if absent && !optsHasDefault(opts) && opts.defaultKeyword == "" {
+			return
+		}
+
 		if fv.IsNil() {
 			err = refSetValue(fv, refNew(fv.Type().Elem()))
 		}
@@ -390,26 +559,25 @@ func unmarshalSequenceField(
 	}
 
 	var handled bool
-	if handled, err = unmarshalSequenceFieldOptionalEmpty(sub, opts); err != nil {
+	if handled, err = unmarshalSequenceFieldOptionalEmpty(sub, fv, opts); err != nil {
 		return err
 	} else if handled {
-		return nil
+		// Absent field: assign the DEFAULT, if any.
+		_, err = setDefaultValue(fv, opts)
+		return
 	}
 
 	if err = unmarshalUnwrapInterfaceChoice(sub, fv, opts); err == nil {
-		if err = unmarshalValue(sub, fv, opts); err != nil {
+		if err = unmarshalValue(sub, fv, opts); err == errorMaxDecodeDepth {
+			// Never recoverable, even for OPTIONAL fields.
+			return
+		} else if err != nil {
 			// Error *might* be recoverable.
-			def := opts.Default
-			if def == nil {
-				def, _ = lookupDefaultValue(opts.defaultKeyword)
-			}
-			if def != nil {
-				err = refSetValue(fv, refValueOf(def))
+			if dflt, derr := setDefaultValue(fv, opts); dflt {
+				err = derr
 			} else {
-				err = compositeErrorf(
-					"unmarshalValue: failed for field ", name, ": ", err,
-				)
-				if berr := checkSequenceFieldCriticality(name, fv, opts); berr == nil {
+				err = withFieldPath(name, err)
+				if berr := checkSequenceFieldCriticality(name, fv, opts); berr == nil && optsIsOptional(opts) {
 					err = berr
 				}
 			}
@@ -417,19 +585,90 @@ func unmarshalSequenceField(
 
 		if err == nil {
 			err = applyFieldConstraints(
-				fv.Interface(), opts.Constraints, '$')
+				fv.Interface(), opts, '$')
 		}
 	}
 
 	return
 }
 
+/*
+unmarshalSequenceRawField returns an error following an attempt to copy
+the complete TLV found at the current offset of sub into fv verbatim. The
+TLV is not interpreted beyond its identifier and length octets.
+*/
+func unmarshalSequenceRawField(name string, fv reflect.Value, sub PDU, opts *Options) (err error) {
+	debugEnter(newLItem(name, "raw field"), fv, opts, sub)
+	defer func() { debugExit(newLItem(err)) }()
+
+	if fv.Kind() != reflect.Slice || fv.Type().Elem().Kind() != reflect.Uint8 {
+		err = compositeErrorf("raw field ", name, " must be RawValue or []byte, got ", fv.Type())
+		return
+	} else if !sub.HasMoreData() {
+		if !optsIsOptional(opts) {
+			err = compositeErrorf("unmarshalValue: missing raw field ", name)
+		}
+		return
+	}
+
+	var full []byte
+	if full, err = parseFullBytes(sub.Data(), sub.Offset(), sub.Type()); err != nil {
+		err = compositeErrorf("unmarshalValue: failed for raw field ", name, ": ", err)
+		return
+	}
+	sub.AddOffset(len(full))
+
+	raw := refNew(fv.Type()).Elem()
+	raw.SetBytes(append([]byte{}, full...))
+	fv.Set(raw)
+
+	return
+}
+
+/*
+setDefaultValue returns a Boolean value indicative of whether opts bears
+a DEFAULT value, alongside an error following an attempt to write said
+value into fv. An [Integer] DEFAULT is converted as needed for fields of
+a native integer kind.
+*/
+func setDefaultValue(fv reflect.Value, opts *Options) (ok bool, err error) {
+	def := opts.Default
+	if def == nil {
+		def, _ = lookupDefaultValue(opts.defaultKeyword)
+	}
+	if ok = def != nil; !ok {
+		return
+	}
+
+	src := refValueOf(def)
+	if i, isInt := def.(Integer); isInt && !i.IsBig() {
+		switch fv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if fv.OverflowInt(i.Native()) {
+				err = compositeErrorf("DEFAULT ", i, " overflows ", fv.Type())
+				return
+			}
+			src = refValueOf(i.Native())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if i.Native() < 0 || fv.OverflowUint(uint64(i.Native())) {
+				err = compositeErrorf("DEFAULT ", i, " overflows ", fv.Type())
+				return
+			}
+			src = refValueOf(uint64(i.Native()))
+		}
+	}
+
+	err = refSetValue(fv, src)
+	return
+}
+
 func unmarshalSequenceFieldOptionalEmpty(
 	sub PDU,
+	fv reflect.Value,
 	opts *Options,
 ) (handled bool, err error) {
 
-	debugEnter(opts, sub)
+	debugEnter(fv, opts, sub)
 	defer func() { debugExit(err) }()
 
 	abs := optsIsAbsent(opts)
@@ -471,13 +710,30 @@ func unmarshalSequenceFieldOptionalEmpty(
 		return
 	}
 
-	// Match options Class/Tag to TLV Class/Tag when
-	// any data remains.
-	if tlv.matchClassAndTag(opts.Class(), opts.Tag()) {
+	// Match options Class/Tag to TLV Class/Tag when any data
+	// remains. Untagged fields are matched by way of the class
+	// and tag implied by the field type, if known.
+	class, tag := opts.Class(), opts.Tag()
+	if !opts.HasTag() {
+		if c, t, ok := untaggedClassAndTag(fv, opts); ok {
+			class, tag = c, t
+		}
+	}
+
+	if tlv.matchClassAndTag(class, tag) {
 		debugEvent(mask,
 			newLItem(handled, "handled"),
 			newLItem("parse OPTIONAL: class/tag matched"))
 		return
+	} else if !opts.HasTag() && fv.Kind() == reflect.Interface && optsHasChoices(opts) {
+		// An untagged CHOICE is present if the TLV matches
+		// any of its alternatives.
+		if reg, ok := GetChoices(opts.Choices); ok && reg.hasAlternative(tlv.Class, tlv.Tag) {
+			debugEvent(mask,
+				newLItem(handled, "handled"),
+				newLItem("parse OPTIONAL: CHOICE alternative matched"))
+			return
+		}
 	}
 
 	handled = true
@@ -489,12 +745,49 @@ func unmarshalSequenceFieldOptionalEmpty(
 	return
 }
 
+/*
+untaggedClassAndTag returns the class and tag implied by the type of
+untagged field value fv, alongside a Boolean value indicative of whether
+they could be determined. Types whose encoding cannot be known ahead of
+time, such as CHOICE, return false.
+*/
+func untaggedClassAndTag(fv reflect.Value, opts *Options) (class, tag int, ok bool) {
+	t := derefTypePtr(fv.Type())
+	zero := refNew(t).Elem()
+
+	switch {
+	case t.Kind() == reflect.Interface:
+		// CHOICE or other interface: unknowable
+	case isPrimitive(zero.Interface()):
+		prim, _ := refNew(t).Interface().(Primitive)
+		class, tag, ok = ClassUniversal, prim.Tag(), true
+	default:
+		if o, _ := lookupOverrideOptions(zero.Interface()); o != nil && o.HasTag() {
+			class, tag, ok = o.Class(), o.Tag(), true
+		} else if ad, found := adapterForValue(zero, opts.Identifier); found {
+			class, tag, ok = ClassUniversal, ad.newCodec().Tag(), true
+		} else if t.Kind() == reflect.Struct {
+			class, tag, ok = ClassUniversal, TagSequence, true
+			if isSet(zero.Interface(), opts) {
+				tag = TagSet
+			}
+		} else if t.Kind() == reflect.Slice {
+			class, tag, ok = ClassUniversal, TagSet, true
+			if opts.Sequence {
+				tag = TagSequence
+			}
+		}
+	}
+
+	return
+}
+
 func unmarshalSequenceComponentsOf(
 	field reflect.StructField,
 	v reflect.Value,
 	sub PDU,
 	opts *Options,
-	auto bool,
+	auto autoTagging,
 ) (err error) {
 	debugEnter(field, v, opts, newLItem(auto, "auto tag"), sub)
 	defer func() { newLItem(err) }()
@@ -504,12 +797,18 @@ func unmarshalSequenceComponentsOf(
 		return
 	}
 
+	v = componentsOfValue(v, true)
+	if err = checkComponentsOfKind(v); err != nil {
+		return
+	}
+
 	t := v.Type()
 	for i := 0; i < t.NumField() && err == nil; i++ {
 		if field = t.Field(i); field.PkgPath == "" {
 			var fOpts *Options
 			if fOpts, err = extractOptions(field, i, auto); err == nil {
 				fOpts.copyDepth(opts)
+				fOpts.copyPhase(opts)
 				err = unmarshalSequenceField(field.Name, v.Field(i), sub, fOpts)
 			}
 		}
@@ -518,12 +817,45 @@ func unmarshalSequenceComponentsOf(
 	return
 }
 
+/*
+componentsOfValue returns the struct value to be expanded in-line for
+a field bearing the "components-of" keyword. An embedded *struct is
+expanded by way of its pointee. If nil, a new instance is allocated and
+assigned when alloc is true (i.e.: when decoding), else the zero value
+of the struct is used.
+*/
+func componentsOfValue(v reflect.Value, alloc bool) reflect.Value {
+	if v.Kind() == reflect.Ptr {
+		if !v.IsNil() {
+			v = v.Elem()
+		} else if nv := refNew(v.Type().Elem()); alloc && v.CanSet() {
+			v.Set(nv)
+			v = nv.Elem()
+		} else {
+			v = nv.Elem()
+		}
+	}
+
+	return v
+}
+
+/*
+checkComponentsOfKind returns an error if v, which is the (dereferenced)
+value of a field bearing the "components-of" keyword, is not a struct.
+*/
+func checkComponentsOfKind(v reflect.Value) (err error) {
+	if v.Kind() != reflect.Struct {
+		err = compositeErrorf("'COMPONENTS OF' requires a SEQUENCE (struct), got ", v.Type())
+	}
+	return
+}
+
 func findExtensibleIndex(fields []reflect.StructField, opts *Options) (idx int, err error) {
 	debugEnter(opts)
 	defer func() { debugExit(newLItem(err)) }()
 
 	idx = -1
-	auto := optsIsAutoTag(opts)
+	auto := automaticTagging(opts, fields)
 	for i := 0; i < len(fields); i++ {
 		if sf := fields[i]; sf.PkgPath == "" {
 			var opts *Options
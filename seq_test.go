@@ -66,6 +66,81 @@ func TestSequence_ComponentsOf(t *testing.T) {
 	}
 }
 
+func TestSequence_ComponentsOfPointerEmbedded(t *testing.T) {
+	type ComponentSequence struct {
+		Name UTF8String  `asn1:"tag:1"`
+		Note OctetString `asn1:"tag:2,optional,omitempty"`
+		Max  *Integer    `asn1:"tag:3,optional"`
+	}
+
+	type EmbeddedSequence struct {
+		Field1            PrintableString `asn1:"tag:0"`
+		ComponentSequence `asn1:"components-of"`
+		Field3            OctetString `asn1:"tag:4"`
+	}
+
+	type PointerSequence struct {
+		Field1             PrintableString `asn1:"tag:0"`
+		*ComponentSequence `asn1:"components-of"`
+		Field3             OctetString `asn1:"tag:4"`
+	}
+
+	seventeen, _ := NewInteger(17)
+	comp := ComponentSequence{Name: UTF8String("Jesse"), Max: &seventeen}
+
+	// Both forms must flatten identically, with the absent
+	// OPTIONAL "Note" component omitted entirely.
+	want := []byte{0x30, 0x15,
+		0x80, 0x04, 't', 'e', 's', 't',
+		0x81, 0x05, 'J', 'e', 's', 's', 'e',
+		0x83, 0x01, 0x11,
+		0x84, 0x03, 'o', 'c', 't'}
+
+	for _, rule := range encodingRules {
+		emb := EmbeddedSequence{Field1: "test", ComponentSequence: comp, Field3: OctetString("oct")}
+		pkt, err := Marshal(emb, With(rule))
+		if err != nil {
+			t.Fatalf("%s failed [%s embedded encoding]: %v", t.Name(), rule, err)
+		} else if rule != CER && !btseq(pkt.Data(), want) {
+			t.Fatalf("%s failed [%s embedded encoding]:\n\twant: %X\n\tgot:  %X", t.Name(), rule, want, pkt.Data())
+		}
+
+		var emb2 EmbeddedSequence
+		if err = Unmarshal(pkt, &emb2); err != nil {
+			t.Fatalf("%s failed [%s embedded decoding]: %v", t.Name(), rule, err)
+		} else if emb2.Name != comp.Name || len(emb2.Note) != 0 || emb2.Max == nil || emb2.Max.Ne(seventeen) {
+			t.Fatalf("%s failed [%s embedded cmp.]: got %#v", t.Name(), rule, emb2.ComponentSequence)
+		}
+
+		ptr := PointerSequence{Field1: "test", ComponentSequence: &comp, Field3: OctetString("oct")}
+		var ppkt PDU
+		if ppkt, err = Marshal(ptr, With(rule)); err != nil {
+			t.Fatalf("%s failed [%s pointer encoding]: %v", t.Name(), rule, err)
+		} else if !btseq(ppkt.Data(), pkt.Data()) {
+			t.Fatalf("%s failed [%s pointer encoding]:\n\twant: %X\n\tgot:  %X", t.Name(), rule, pkt.Data(), ppkt.Data())
+		}
+
+		var ptr2 PointerSequence
+		if err = Unmarshal(ppkt, &ptr2); err != nil {
+			t.Fatalf("%s failed [%s pointer decoding]: %v", t.Name(), rule, err)
+		} else if ptr2.ComponentSequence == nil || ptr2.Name != comp.Name ||
+			len(ptr2.Note) != 0 || ptr2.Max == nil || ptr2.Max.Ne(seventeen) {
+			t.Fatalf("%s failed [%s pointer cmp.]: got %#v", t.Name(), rule, ptr2.ComponentSequence)
+		} else if ptr2.Field3.String() != "oct" {
+			t.Fatalf("%s failed [%s trailing field]: got %q", t.Name(), rule, ptr2.Field3)
+		}
+	}
+
+	type BadSequence struct {
+		Field1       PrintableString `asn1:"tag:0"`
+		*OctetString `asn1:"components-of"`
+	}
+	oct := OctetString("bad")
+	if _, err := Marshal(BadSequence{Field1: "test", OctetString: &oct}); err == nil {
+		t.Fatalf("%s failed: expected error for non-struct COMPONENTS OF, got nil", t.Name())
+	}
+}
+
 func TestSequence_IntFields(t *testing.T) {
 	type MySequence struct {
 		Field0 Integer
@@ -191,6 +266,62 @@ func TestMarshal_SequenceEncodingRules(t *testing.T) {
 	}
 }
 
+func TestSequence_mixedTagging(t *testing.T) {
+	// Interleaved untagged (positional) and tagged fields.
+	type Mixed struct {
+		Name    PrintableString
+		Serial  OctetString `asn1:"tag:0,optional"`
+		Count   Enumerated
+		Version Integer     `asn1:"tag:1,explicit,optional"`
+		Note    UTF8String  `asn1:"optional"`
+		Extra   OctetString `asn1:"tag:2,explicit,optional"`
+		Flag    Boolean
+	}
+
+	ver, _ := NewInteger(3)
+	for idx, mixed := range []Mixed{
+		{Name: "all", Serial: OctetString("s"), Count: 1, Version: ver,
+			Note: "n", Extra: OctetString("x"), Flag: true},
+		{Name: "none", Count: 2},
+		{Name: "untagged", Count: 3, Note: "n", Flag: true},
+		{Name: "tagged", Serial: OctetString("s"), Count: 4, Extra: OctetString("x")},
+		{Name: "explicit", Count: 5, Version: ver, Flag: true},
+	} {
+		for _, rule := range encodingRules {
+			pkt, err := Marshal(mixed, With(rule))
+			if err != nil {
+				t.Fatalf("%s[%d] failed [%s encoding]: %v", t.Name(), idx, rule, err)
+			}
+
+			var mixed2 Mixed
+			if err = Unmarshal(pkt, &mixed2); err != nil {
+				t.Fatalf("%s[%d] failed [%s decoding]: %v", t.Name(), idx, rule, err)
+			}
+
+			if mixed2.Name != mixed.Name || mixed2.Count != mixed.Count ||
+				mixed2.Note != mixed.Note || mixed2.Flag != mixed.Flag ||
+				!btseq(mixed2.Serial, mixed.Serial) || !btseq(mixed2.Extra, mixed.Extra) ||
+				mixed2.Version.String() != mixed.Version.String() {
+				t.Fatalf("%s[%d] failed [%s round-trip]:\n\twant: %#v\n\tgot:  %#v",
+					t.Name(), idx, rule, mixed, mixed2)
+			}
+		}
+	}
+
+	// A required field which fails to decode must not be
+	// silently zeroed.
+	type Strict struct {
+		Name PrintableString
+		Flag Boolean
+	}
+
+	var strict Strict
+	pkt := BER.New(0x30, 0x06, 0x13, 0x01, 'a', 0x02, 0x01, 0x01)
+	if err := Unmarshal(pkt, &strict); err == nil {
+		t.Fatalf("%s failed: expected error for mismatched required field, got nil", t.Name())
+	}
+}
+
 func TestMarshal_SequenceNested(t *testing.T) {
 	type OtherSequence struct {
 		Field1 UTF8String
@@ -338,3 +469,423 @@ func TestSequence_AutomaticTagging(t *testing.T) {
 		}
 	}
 }
+
+func TestSequence_AutomaticTaggingNested(t *testing.T) {
+	// M DEFINITIONS AUTOMATIC TAGS ::= BEGIN
+	//   Top  ::= SEQUENCE { a INTEGER, b Mid, c BOOLEAN, d [9] Deep }
+	//   Mid  ::= SEQUENCE { x INTEGER, y Deep }
+	//   Deep ::= SEQUENCE { p INTEGER, q BOOLEAN }
+	// END
+	type Deep struct {
+		P Integer
+		Q Boolean
+	}
+	type Mid struct {
+		X Integer
+		Y Deep
+	}
+	type Top struct {
+		A Integer
+		B Mid
+		C Boolean
+		D Deep `asn1:"tag:9"`
+	}
+
+	i1, _ := NewInteger(1)
+	i2, _ := NewInteger(2)
+	i3, _ := NewInteger(3)
+	in := Top{
+		A: i1,
+		B: Mid{X: i2, Y: Deep{P: i3, Q: true}},
+		D: Deep{P: i3},
+	}
+	opts := Options{Automatic: true}
+
+	// As d bears a tag, automatic tagging does not apply to the
+	// components of Top (X.680 §25.3), but still applies to those
+	// of Mid and Deep.
+	//
+	// 30 1B                 -- SEQUENCE
+	//   02 01 01            -- a INTEGER 1
+	//   30 0B               -- b SEQUENCE
+	//     80 01 02          --   x [0] IMPLICIT INTEGER 2
+	//     A1 06             --   y [1] IMPLICIT SEQUENCE
+	//       80 01 03        --     p [0] IMPLICIT INTEGER 3
+	//       81 01 FF        --     q [1] IMPLICIT BOOLEAN TRUE
+	//   01 01 00            -- c BOOLEAN FALSE
+	//   A9 06               -- d [9] IMPLICIT SEQUENCE
+	//     80 01 03          --   p [0] IMPLICIT INTEGER 3
+	//     81 01 00          --   q [1] IMPLICIT BOOLEAN FALSE
+	want := "30 1B 020101300B800102A1068001038101FF010100A906800103810100"
+
+	for _, rule := range encodingRules {
+		pkt, err := Marshal(in, With(rule, opts))
+		if err != nil {
+			t.Fatalf("%s failed [%s encoding]: %v", t.Name(), rule, err)
+		} else if got := pkt.Hex(); got != want {
+			t.Fatalf("%s failed: unexpected %s encoding:\n\twant: '%s'\n\tgot:  '%s'",
+				t.Name(), rule, want, got)
+		}
+
+		var out Top
+		if err = Unmarshal(pkt, &out, With(opts)); err != nil {
+			t.Fatalf("%s failed [%s decoding]: %v", t.Name(), rule, err)
+		} else if out != in {
+			t.Fatalf("%s failed: %s round-trip mismatch: got %+v want %+v",
+				t.Name(), rule, out, in)
+		}
+	}
+
+	// SEQUENCE OF a SEQUENCE type is tagged likewise
+	type List struct {
+		Items []Deep
+	}
+	pkt, err := Marshal(List{Items: []Deep{{P: i1}, {P: i2, Q: true}}}, With(opts))
+	if err != nil {
+		t.Fatalf("%s failed [SEQUENCE OF encoding]: %v", t.Name(), err)
+	}
+	if want = "30 12 A010300680010181010030068001028101FF"; pkt.Hex() != want {
+		t.Fatalf("%s failed: unexpected SEQUENCE OF encoding:\n\twant: '%s'\n\tgot:  '%s'",
+			t.Name(), want, pkt.Hex())
+	}
+}
+
+func TestSequence_RawField(t *testing.T) {
+	type Inner struct {
+		Flag Boolean
+		Note UTF8String
+	}
+
+	type Full struct {
+		Name  PrintableString
+		Inner Inner `asn1:"tag:1,explicit"`
+		Count Integer
+	}
+
+	type Proxy struct {
+		Name  PrintableString
+		Inner RawValue
+		Count Integer
+	}
+
+	type ProxyBytes struct {
+		Name  PrintableString
+		Inner []byte `asn1:"raw"`
+		Count []byte `asn1:"raw"`
+	}
+
+	count, _ := NewInteger(42)
+	full := Full{
+		Name:  PrintableString("proxy"),
+		Inner: Inner{Flag: true, Note: UTF8String("opaque")},
+		Count: count,
+	}
+
+	for _, rule := range encodingRules {
+		pkt, err := Marshal(full, With(rule))
+		if err != nil {
+			t.Fatalf("%s failed [%s marshal]: %v", t.Name(), rule, err)
+		}
+		want := pkt.Data()
+
+		var proxy Proxy
+		if err = Unmarshal(rule.New(want...), &proxy); err != nil {
+			t.Fatalf("%s failed [%s unmarshal]: %v", t.Name(), rule, err)
+		} else if len(proxy.Inner) == 0 || proxy.Inner[0] != 0xA1 {
+			t.Fatalf("%s failed [%s raw capture]: got %X", t.Name(), rule, []byte(proxy.Inner))
+		}
+
+		var repkt PDU
+		if repkt, err = Marshal(proxy, With(rule)); err != nil {
+			t.Fatalf("%s failed [%s re-marshal]: %v", t.Name(), rule, err)
+		} else if got := repkt.Data(); !btseq(got, want) {
+			t.Fatalf("%s failed [%s round trip]:\n\twant: %X\n\tgot:  %X", t.Name(), rule, want, got)
+		}
+
+		var pb ProxyBytes
+		if err = Unmarshal(rule.New(want...), &pb); err != nil {
+			t.Fatalf("%s failed [%s unmarshal bytes]: %v", t.Name(), rule, err)
+		} else if repkt, err = Marshal(pb, With(rule)); err != nil {
+			t.Fatalf("%s failed [%s re-marshal bytes]: %v", t.Name(), rule, err)
+		} else if got := repkt.Data(); !btseq(got, want) {
+			t.Fatalf("%s failed [%s bytes round trip]:\n\twant: %X\n\tgot:  %X", t.Name(), rule, want, got)
+		}
+	}
+
+	// Non-minimal length octets within the raw TLV must survive.
+	data := []byte{0x30, 0x0B,
+		0x13, 0x01, 'a',
+		0x04, 0x81, 0x02, 'h', 'i',
+		0x02, 0x01, 0x05}
+	type Lenient struct {
+		Name  PrintableString
+		Blob  RawValue
+		Count Integer
+	}
+	var lenient Lenient
+	if err := Unmarshal(BER.New(data...), &lenient); err != nil {
+		t.Fatalf("%s failed [lenient unmarshal]: %v", t.Name(), err)
+	} else if pkt, err := Marshal(lenient, With(BER)); err != nil {
+		t.Fatalf("%s failed [lenient marshal]: %v", t.Name(), err)
+	} else if got := pkt.Data(); !btseq(got, data) {
+		t.Fatalf("%s failed [lenient round trip]:\n\twant: %X\n\tgot:  %X", t.Name(), data, got)
+	}
+
+	// Malformed or mistyped raw values are rejected.
+	for idx, bogus := range []any{
+		Lenient{Name: "a", Blob: RawValue{0x04, 0x05, 'h'}},
+		Lenient{Name: "a", Blob: RawValue{0x04, 0x01, 'h', 0x00}},
+		Lenient{Name: "a"},
+		struct {
+			Blob string `asn1:"raw"`
+		}{"x"},
+	} {
+		if _, err := Marshal(bogus, With(BER)); err == nil {
+			t.Fatalf("%s[%d] failed: expected error, got nil", t.Name(), idx)
+		}
+	}
+
+	type Optional struct {
+		Name PrintableString
+		Blob RawValue `asn1:"optional"`
+	}
+	var opt Optional
+	if pkt, err := Marshal(Optional{Name: "a"}, With(BER)); err != nil {
+		t.Fatalf("%s failed [optional marshal]: %v", t.Name(), err)
+	} else if err = Unmarshal(pkt, &opt); err != nil || opt.Blob != nil {
+		t.Fatalf("%s failed [optional unmarshal]: %v (%X)", t.Name(), err, []byte(opt.Blob))
+	}
+}
+
+func TestSequence_DefaultOmission(t *testing.T) {
+	type Versioned struct {
+		Version Integer `asn1:"tag:0,explicit,default:0"`
+		Name    PrintableString
+		Count   Enumerated `asn1:"tag:1,default:3"`
+	}
+
+	zero, _ := NewInteger(0)
+	two, _ := NewInteger(2)
+
+	for _, tc := range []struct {
+		value Versioned
+		want  []byte
+	}{
+		{
+			// both fields equal their DEFAULT, and are omitted
+			Versioned{Version: zero, Name: "a", Count: 3},
+			[]byte{0x30, 0x03, 0x13, 0x01, 'a'},
+		},
+		{
+			Versioned{Version: two, Name: "a", Count: 5},
+			[]byte{0x30, 0x0B,
+				0xA0, 0x03, 0x02, 0x01, 0x02,
+				0x13, 0x01, 'a',
+				0x81, 0x01, 0x05},
+		},
+	} {
+		for _, rule := range []EncodingRule{BER, DER} {
+			if !rule.Enabled() {
+				continue
+			}
+
+			pkt, err := Marshal(tc.value, With(rule))
+			if err != nil {
+				t.Fatalf("%s failed [%s encoding]: %v", t.Name(), rule, err)
+			} else if !btseq(pkt.Data(), tc.want) {
+				t.Fatalf("%s failed [%s encoding]:\n\twant: %X\n\tgot:  %X",
+					t.Name(), rule, tc.want, pkt.Data())
+			}
+
+			// absent fields must be decoded as their DEFAULT
+			out := Versioned{Version: two, Count: -1}
+			if err = Unmarshal(pkt, &out); err != nil {
+				t.Fatalf("%s failed [%s decoding]: %v", t.Name(), rule, err)
+			} else if out.Version.Ne(tc.value.Version) || out.Count != tc.value.Count || out.Name != tc.value.Name {
+				t.Fatalf("%s failed [%s decoding]:\n\twant: %v\n\tgot:  %v", t.Name(), rule, tc.value, out)
+			}
+		}
+	}
+}
+
+func TestSequence_FieldPathError(t *testing.T) {
+	type Deep struct {
+		Field1 PrintableString
+		Field2 OctetString
+	}
+	type Mid struct {
+		Deep Deep
+	}
+	type MySequence struct {
+		Field1 Integer
+		Field2 Mid
+	}
+
+	five, _ := NewInteger(5)
+	data, err := MarshalBytes(MySequence{five, Mid{Deep{"a", OctetString("hello")}}})
+	if err != nil {
+		t.Fatalf("%s failed [encoding]: %v", t.Name(), err)
+	}
+
+	// overstate the length of the innermost OCTET STRING
+	data[len(data)-6] = 0x09
+
+	var out MySequence
+	err = UnmarshalBytes(data, BER, &out)
+	if want := "MySequence.Field2.Deep.Field2: "; err == nil || !hasPfx(err.Error(), want) {
+		t.Fatalf("%s failed: want error prefix %q, got %v", t.Name(), want, err)
+	}
+}
+
+func TestStrictSequenceOrdering(t *testing.T) {
+	defer func(strict bool) { StrictSequenceOrdering = strict }(StrictSequenceOrdering)
+
+	type Implicit struct {
+		Zero OctetString `asn1:"tag:0"`
+		One  OctetString `asn1:"tag:1,optional"`
+		Two  OctetString `asn1:"tag:2,optional"`
+	}
+
+	swapped := []byte{0x30, 0x09,
+		0x80, 0x01, 'z',
+		0x82, 0x01, 'b', // [2] precedes [1]
+		0x81, 0x01, 'a'}
+	unknown := []byte{0x30, 0x09,
+		0x80, 0x01, 'z',
+		0x81, 0x01, 'a',
+		0x85, 0x01, 'x'} // [5] matches no field
+
+	for _, rule := range encodingRules {
+		StrictSequenceOrdering = false
+		var got Implicit
+		if err := Unmarshal(rule.New(swapped...), &got); err != nil {
+			t.Fatalf("%s failed [%s lenient]: %v", t.Name(), rule, err)
+		} else if got.One != nil || string(got.Two) != "b" {
+			t.Fatalf("%s failed [%s lenient]: unexpected result %#v", t.Name(), rule, got)
+		}
+
+		StrictSequenceOrdering = true
+		err := Unmarshal(rule.New(swapped...), &got)
+		if err == nil || !cntns(err.Error(), "field One appears out of order") {
+			t.Fatalf("%s failed [%s strict, swapped]: expected ordering error, got %v", t.Name(), rule, err)
+		}
+		if err = Unmarshal(rule.New(unknown...), &got); err == nil || !cntns(err.Error(), "unexpected component") {
+			t.Fatalf("%s failed [%s strict, unknown]: expected component error, got %v", t.Name(), rule, err)
+		}
+
+		// conformant ordering remains acceptable
+		pkt, _ := Marshal(Implicit{OctetString("z"), OctetString("a"), OctetString("b")}, With(rule))
+		if err = Unmarshal(pkt, &got); err != nil {
+			t.Fatalf("%s failed [%s strict, ordered]: %v", t.Name(), rule, err)
+		}
+
+		// components following a SET OF are not mistaken for trailing data
+		type SetThenField struct {
+			Items []OctetString `asn1:"set"`
+			After OctetString
+		}
+		pkt, _ = Marshal(SetThenField{[]OctetString{OctetString("a")}, OctetString("z")}, With(rule))
+		var stf SetThenField
+		if err = Unmarshal(pkt, &stf); err != nil {
+			t.Fatalf("%s failed [%s strict, SET OF]: %v", t.Name(), rule, err)
+		} else if len(stf.Items) != 1 || string(stf.After) != "z" {
+			t.Fatalf("%s failed [%s strict, SET OF]: unexpected result %#v", t.Name(), rule, stf)
+		}
+	}
+}
+
+func TestSequence_untaggedOptional(t *testing.T) {
+	type Value struct {
+		Field1 OctetString `asn1:"optional"`
+		Field2 Integer
+	}
+	type Pointer struct {
+		Field1 *OctetString `asn1:"optional"`
+		Field2 Integer
+	}
+	type Inner struct {
+		X Integer
+	}
+	type Struct struct {
+		Field1 *Inner `asn1:"optional"`
+		Field2 Integer
+	}
+
+	absent := []byte{0x30, 0x03, 0x02, 0x01, 0x05}
+	present := []byte{0x30, 0x06, 0x04, 0x01, 'a', 0x02, 0x01, 0x05}
+
+	for _, rule := range encodingRules {
+		var v Value
+		if err := Unmarshal(rule.New(absent...), &v); err != nil {
+			t.Fatalf("%s failed [%s value, absent]: %v", t.Name(), rule, err)
+		} else if v.Field1 != nil || v.Field2.Big().Int64() != 5 {
+			t.Fatalf("%s failed [%s value, absent]: unexpected result %#v", t.Name(), rule, v)
+		}
+		if err := Unmarshal(rule.New(present...), &v); err != nil {
+			t.Fatalf("%s failed [%s value, present]: %v", t.Name(), rule, err)
+		} else if string(v.Field1) != "a" || v.Field2.Big().Int64() != 5 {
+			t.Fatalf("%s failed [%s value, present]: unexpected result %#v", t.Name(), rule, v)
+		}
+
+		// absent OPTIONAL pointers remain nil
+		var p Pointer
+		if err := Unmarshal(rule.New(absent...), &p); err != nil {
+			t.Fatalf("%s failed [%s pointer, absent]: %v", t.Name(), rule, err)
+		} else if p.Field1 != nil || p.Field2.Big().Int64() != 5 {
+			t.Fatalf("%s failed [%s pointer, absent]: unexpected result %#v", t.Name(), rule, p)
+		}
+		if err := Unmarshal(rule.New(present...), &p); err != nil {
+			t.Fatalf("%s failed [%s pointer, present]: %v", t.Name(), rule, err)
+		} else if p.Field1 == nil || string(*p.Field1) != "a" {
+			t.Fatalf("%s failed [%s pointer, present]: unexpected result %#v", t.Name(), rule, p)
+		}
+
+		var s Struct
+		if err := Unmarshal(rule.New(absent...), &s); err != nil {
+			t.Fatalf("%s failed [%s struct, absent]: %v", t.Name(), rule, err)
+		} else if s.Field1 != nil || s.Field2.Big().Int64() != 5 {
+			t.Fatalf("%s failed [%s struct, absent]: unexpected result %#v", t.Name(), rule, s)
+		}
+	}
+}
+
+func TestSequence_nilPointerOptional(t *testing.T) {
+	type Entry struct {
+		Name  PrintableString
+		Alias *PrintableString `asn1:"optional"`
+		Note  *PrintableString `asn1:"tag:1,optional"`
+	}
+
+	name, _ := NewPrintableString("name")
+	alias, _ := NewPrintableString("alias")
+
+	for _, rule := range encodingRules {
+		for idx, tc := range []struct {
+			in   Entry
+			want []byte
+		}{
+			{Entry{Name: name},
+				[]byte{0x30, 0x06, 0x13, 0x04, 'n', 'a', 'm', 'e'}},
+			{Entry{Name: name, Alias: &alias},
+				[]byte{0x30, 0x0D, 0x13, 0x04, 'n', 'a', 'm', 'e', 0x13, 0x05, 'a', 'l', 'i', 'a', 's'}},
+			{Entry{Name: name, Note: &alias},
+				[]byte{0x30, 0x0D, 0x13, 0x04, 'n', 'a', 'm', 'e', 0x81, 0x05, 'a', 'l', 'i', 'a', 's'}},
+		} {
+			pkt, err := Marshal(tc.in, With(rule))
+			if err != nil {
+				t.Fatalf("%s[%d] failed [%s encoding]: %v", t.Name(), idx, rule, err)
+			} else if !btseq(pkt.Data(), tc.want) {
+				t.Fatalf("%s[%d] failed [%s encoding]:\n\twant: %s\n\tgot:  %s",
+					t.Name(), idx, rule, hexstr(tc.want), hexstr(pkt.Data()))
+			}
+
+			var out Entry
+			if err = Unmarshal(pkt, &out); err != nil {
+				t.Fatalf("%s[%d] failed [%s decoding]: %v", t.Name(), idx, rule, err)
+			} else if !deepEq(out, tc.in) {
+				t.Fatalf("%s[%d] failed [%s decoding]:\n\twant: %#v\n\tgot:  %#v",
+					t.Name(), idx, rule, tc.in, out)
+			}
+		}
+	}
+}
@@ -1,8 +1,8 @@
 package asn1plus
 
 /*
-set.go contains all private functions pertaining to the
-ASN.1 SET type.
+set.go contains all components pertaining to the ASN.1
+SET type.
 */
 
 import (
@@ -10,6 +10,19 @@ import (
 	"slices"
 )
 
+/*
+StrictDERSetOfOrdering declares whether a SET OF decoded under [DER] is
+rejected should its elements not appear in the canonical order mandated
+by ITU-T Rec. X.690 clause 11.6, i.e.: ascending order of their encodings
+when compared as octet strings. This is useful when verifying signatures
+computed over the DER encoding, in which a reordered SET OF would yield a
+different digest.
+
+The default is false, in which case elements are accepted in any order.
+[BER] does not mandate any ordering, and is not affected by this setting.
+*/
+var StrictDERSetOfOrdering bool
+
 func isSet(target any, opts *Options) (set bool) {
 	t := derefTypePtr(refTypeOf(target))
 	o := deferImplicit(opts)
@@ -71,12 +84,15 @@ func marshalSet(v reflect.Value, pkt PDU, opts *Options) (err error) {
 
 	var elements [][]byte
 	var typ EncodingRule = pkt.Type()
+	var total int
 	for i := 0; i < v.Len() && err == nil; i++ {
-		tmp := typ.New()
+		tmp := newSubPacket(pkt)
 		subOpts := clearChildOpts(opts)
 		subOpts.incDepth()
 		if err = marshalValue(v.Index(i), tmp, subOpts); err == nil {
 			elements = append(elements, tmp.Data())
+			total += tmp.Len()
+			err = checkOutputLimit(pkt, total)
 		}
 	}
 
@@ -117,13 +133,14 @@ func marshalSetWithExtensions(
 	defer func() { debugExit(newLItem(err)) }()
 
 	typ := pkt.Type()
-	sub := typ.New()
+	sub := newSubPacket(pkt)
 
 	for i := 0; i < len(fields) && err == nil; i++ {
 		if sf := fields[i]; sf.PkgPath == "" {
 			f := derefValuePtr(v.Field(i))
 			var fOpts *Options
-			if fOpts, err = extractOptions(sf, i, optsIsAutoTag(opts)); err == nil {
+			if fOpts, err = extractOptions(sf, i, automaticTagging(opts, fields)); err == nil {
+				fOpts.copyPhase(opts)
 				if i == extIdx {
 					err = marshalSequenceExtensionField(v.Field(i), sub, fOpts)
 					continue
@@ -183,7 +200,22 @@ func unmarshalSet(v reflect.Value, pkt PDU, opts *Options) (err error) {
 			if outerTLV, err = pkt.TLV(); err != nil {
 				return err
 			}
+
+			// advance beyond the SET, such that any subsequent
+			// components of an enclosing SEQUENCE are read.
 			subData := outerTLV.Value
+			if outerTLV.Length < 0 {
+				var idx int
+				start := pkt.Offset()
+				if idx, err = findEOC(pkt.Data()[start:]); err != nil {
+					return compositeErrorf("unmarshalSet: ", err)
+				}
+				subData = pkt.Data()[start : start+idx]
+				pkt.AddOffset(idx + len(indefEoC))
+			} else {
+				pkt.AddOffset(outerTLV.Length)
+			}
+
 			subPkt := pkt.Type().New(subData...)
 			subPkt.SetOffset()
 			pkt = subPkt
@@ -194,9 +226,14 @@ func unmarshalSet(v reflect.Value, pkt PDU, opts *Options) (err error) {
 	var elements []reflect.Value
 
 	subOpts := clearChildOpts(opts)
+	subOpts.incDepth()
 	isCh := isChoice(v, opts)
 
+	strict := StrictDERSetOfOrdering && pkt.Type() == DER
+	var prev []byte
+
 	for pkt.HasMoreData() {
+		start := pkt.Offset()
 		var tmp reflect.Value
 		if elemType.Kind() == reflect.Ptr {
 			tmp = refNew(elemType.Elem())
@@ -210,9 +247,20 @@ func unmarshalSet(v reflect.Value, pkt PDU, opts *Options) (err error) {
 			err = unmarshalValue(pkt, tmp, subOpts)
 		}
 		if err != nil {
-			err = compositeErrorf("unmarshalSet: error unmarshaling SET element: ", err)
+			if err != errorMaxDecodeDepth {
+				err = compositeErrorf("unmarshalSet: error unmarshaling SET element: ", err)
+			}
 			return
 		}
+
+		if strict {
+			cur := pkt.Data()[start:pkt.Offset()]
+			if prev != nil && bcmp(prev, cur) > 0 {
+				err = errorSetOfOrdering
+				return
+			}
+			prev = cur
+		}
 		elements = append(elements, tmp)
 	}
 
@@ -287,6 +335,7 @@ func setPickChoiceAlternative(
 
 			childOpts = clearChildOpts(parentOpts)
 			childOpts.Choices = parentOpts.Choices
+			childOpts.incDepth()
 
 			payload = outer.Value
 			tag = outer.Tag
@@ -366,7 +415,9 @@ func setDecodeChoiceSingle(
 			childOpts.tag = nil
 
 			if err = unmarshalValue(payloadPK, destPtr.Elem(), &childOpts); err != nil {
-				err = choiceErrorf("inner decode failed: ", err.Error())
+				err = choiceErrorf("inner decode failed: ", err)
+				v = tmp
+			} else if err = cd.constrain(tag, destPtr.Elem(), &childOpts); err != nil {
 				v = tmp
 			} else {
 				outChoice := NewChoice(destPtr.Elem().Interface())
@@ -397,7 +448,7 @@ func unmarshalSetWithExtensions(
 	defer func() { newLItem(err) }()
 
 	v = derefValuePtr(v)
-	auto := optsIsAutoTag(opts)
+	auto := automaticTagging(opts, fields)
 	cur := pkt.Offset()
 	if cur < pkt.Len() {
 		raw := pkt.Data()[cur]
@@ -422,6 +473,9 @@ func unmarshalSetWithExtensions(
 		if err2 != nil {
 			return err2
 		}
+		fOpts.copyDepth(opts)
+		fOpts.copyPhase(opts)
+		fOpts.incDepth()
 
 		if i == extIdx {
 			var exts []TLV
@@ -439,12 +493,58 @@ func unmarshalSetWithExtensions(
 			continue
 		}
 
-		if err = unmarshalValue(pkt, f, fOpts); err != nil {
-			return compositeErrorf(
-				"unmarshalSet field ", sf.Name, ": ", err,
-			)
+		if err = unmarshalValue(pkt, f, fOpts); err == errorMaxDecodeDepth {
+			return
+		} else if err != nil {
+			return withFieldPath(sf.Name, err)
 		}
 	}
 
 	return nil
 }
+
+/*
+SortSetOf returns an error following an attempt to reorder elems in place
+such that they conform to the canonical SET OF ordering mandated by [DER]
+and [CER] (see ITU-T Rec. X.690, clause 11.6).
+
+Each element is encoded independently using rule, and the resultant
+encodings are compared as octet strings. Elements which produce identical
+encodings retain their relative order. The input slice is not modified if
+any element fails to encode.
+
+This allows a SET OF to be canonicalized ahead of time without a full
+[Marshal] of the enclosing value. Note that rule must be a binary encoding
+rule, such as [BER], [CER] or [DER].
+*/
+func SortSetOf[T any](elems []T, rule EncodingRule) (err error) {
+	if rule.textual() {
+		err = errorTextualPDU
+		return
+	}
+
+	type keyed struct {
+		key  []byte
+		elem T
+	}
+
+	items := make([]keyed, len(elems))
+	for i := 0; i < len(elems) && err == nil; i++ {
+		var pkt PDU
+		if pkt, err = Marshal(elems[i], With(rule)); err == nil {
+			items[i] = keyed{key: pkt.Data(), elem: elems[i]}
+		}
+	}
+
+	if err != nil {
+		err = compositeErrorf("SortSetOf: error marshaling slice element: ", err)
+		return
+	}
+
+	slices.SortStableFunc(items, func(a, b keyed) int { return bcmp(a.key, b.key) })
+	for i := range items {
+		elems[i] = items[i].elem
+	}
+
+	return
+}
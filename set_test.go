@@ -179,3 +179,158 @@ func TestSet_codecov(_ *testing.T) {
 
 	unmarshalSet(refValueOf(&mine), pkt, nil)
 }
+
+func TestSortSetOf(t *testing.T) {
+	var ints []Integer
+	for _, n := range []int{256, 1, -1, 127, 0} {
+		i, _ := NewInteger(n)
+		ints = append(ints, i)
+	}
+
+	if err := SortSetOf(ints, DER); err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	want := []string{"0", "1", "127", "-1", "256"}
+	for i, w := range want {
+		if got := ints[i].String(); got != w {
+			t.Fatalf("%s failed [index %d]: want %s, got %s", t.Name(), i, w, got)
+		}
+	}
+
+	if err := SortSetOf(ints, JER); err == nil {
+		t.Fatalf("%s failed: expected error for textual rule, got nil", t.Name())
+	}
+
+	bad := []any{Integer{}, make(chan int)}
+	if err := SortSetOf(bad, DER); err == nil {
+		t.Fatalf("%s failed: expected marshal error, got nil", t.Name())
+	}
+}
+
+func TestSet_explicitTaggedField(t *testing.T) {
+	type MySequence struct {
+		Name    PrintableString
+		Values  []OctetString `asn1:"explicit,tag:1,set"`
+		Ordered []OctetString `asn1:"explicit,tag:2,sequence"`
+		Tagged  []OctetString `asn1:"tag:3,sequence"`
+	}
+
+	in := MySequence{
+		Name:    PrintableString("x"),
+		Values:  []OctetString{OctetString("b"), OctetString("a")},
+		Ordered: []OctetString{OctetString("q")},
+		Tagged:  []OctetString{OctetString("r")},
+	}
+
+	for _, rule := range encodingRules {
+		pkt, err := Marshal(in, With(rule))
+		if err != nil {
+			t.Fatalf("%s failed [%s encode]: %v", t.Name(), rule, err)
+		}
+
+		// [1] EXPLICIT SET OF, [2] EXPLICIT SEQUENCE OF and
+		// [3] IMPLICIT SEQUENCE OF, respectively.
+		data := pkt.Data()
+		for _, want := range [][]byte{
+			{0xA1, 0x08, 0x31, 0x06},
+			{0xA2, 0x05, 0x30, 0x03},
+			{0xA3, 0x03, 0x04, 0x01, 'r'},
+		} {
+			if bidx(data, want) < 0 {
+				t.Fatalf("%s failed [%s encode]: % X not found in % X", t.Name(), rule, want, data)
+			}
+		}
+
+		var out MySequence
+		if err = Unmarshal(pkt, &out); err != nil {
+			t.Fatalf("%s failed [%s decode]: %v", t.Name(), rule, err)
+		}
+
+		if len(out.Values) != 2 || len(out.Ordered) != 1 || len(out.Tagged) != 1 ||
+			string(out.Ordered[0]) != "q" || string(out.Tagged[0]) != "r" {
+			t.Fatalf("%s failed [%s decode]: unexpected result: %#v", t.Name(), rule, out)
+		}
+	}
+}
+
+func TestSet_sizeConstraint(t *testing.T) {
+	type Bounded struct {
+		Items []OctetString `asn1:"set,size:1..4"`
+	}
+	type Unbounded struct {
+		Items []OctetString `asn1:"set"`
+	}
+
+	items := []OctetString{
+		OctetString("a"), OctetString("b"), OctetString("c"),
+		OctetString("d"), OctetString("e"),
+	}
+
+	for _, rule := range encodingRules {
+		if _, err := Marshal(Bounded{items}, With(rule)); err == nil {
+			t.Fatalf("%s failed [%s encoding]: expected SIZE error, got nil", t.Name(), rule)
+		} else if _, err = Marshal(Bounded{}, With(rule)); err == nil {
+			t.Fatalf("%s failed [%s encoding]: expected SIZE error for empty SET OF, got nil", t.Name(), rule)
+		}
+
+		pkt, err := Marshal(Bounded{items[:4]}, With(rule))
+		if err != nil {
+			t.Fatalf("%s failed [%s encoding]: %v", t.Name(), rule, err)
+		}
+		var ok Bounded
+		if err = Unmarshal(pkt, &ok); err != nil {
+			t.Fatalf("%s failed [%s decoding]: %v", t.Name(), rule, err)
+		} else if len(ok.Items) != 4 {
+			t.Fatalf("%s failed [%s decoding]: want 4 elements, got %d", t.Name(), rule, len(ok.Items))
+		}
+
+		// five elements, encoded without the constraint, must fail to decode
+		if pkt, err = Marshal(Unbounded{items}, With(rule)); err != nil {
+			t.Fatalf("%s failed [%s encoding]: %v", t.Name(), rule, err)
+		}
+		var over Bounded
+		if err = Unmarshal(pkt, &over); err == nil {
+			t.Fatalf("%s failed [%s decoding]: expected SIZE error, got %d elements",
+				t.Name(), rule, len(over.Items))
+		}
+	}
+
+	if _, err := parseOptions(`set,size:4..1`); err == nil {
+		t.Fatalf("%s failed: expected error for inverted SIZE range, got nil", t.Name())
+	}
+}
+
+func TestStrictDERSetOfOrdering(t *testing.T) {
+	if !DER.Enabled() {
+		t.Skip("DER not enabled")
+	}
+
+	defer func() { StrictDERSetOfOrdering = false }()
+
+	// SET OF OCTET STRING { "b", "a" } -- deliberately unsorted
+	unsorted := []byte{0x31, 0x06, 0x04, 0x01, 'b', 0x04, 0x01, 'a'}
+	sorted := []byte{0x31, 0x06, 0x04, 0x01, 'a', 0x04, 0x01, 'b'}
+	opts := With(&Options{Set: true})
+
+	for _, strict := range []bool{false, true} {
+		StrictDERSetOfOrdering = strict
+
+		var out []OctetString
+		if err := UnmarshalBytes(sorted, DER, &out, opts); err != nil {
+			t.Fatalf("%s failed [strict:%t sorted]: %v", t.Name(), strict, err)
+		}
+
+		err := UnmarshalBytes(unsorted, DER, &out, opts)
+		if strict && err != errorSetOfOrdering {
+			t.Fatalf("%s failed [strict unsorted]: want %v, got %v", t.Name(), errorSetOfOrdering, err)
+		} else if !strict && err != nil {
+			t.Fatalf("%s failed [lax unsorted]: %v", t.Name(), err)
+		}
+
+		// BER does not mandate any ordering
+		if err = UnmarshalBytes(unsorted, BER, &out, opts); err != nil {
+			t.Fatalf("%s failed [strict:%t BER unsorted]: %v", t.Name(), strict, err)
+		}
+	}
+}
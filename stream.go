@@ -0,0 +1,376 @@
+package asn1plus
+
+/*
+stream.go contains components pertaining to the incremental encoding
+and decoding of ASN.1 values written to an [io.Writer], or read from an
+[io.Reader].
+*/
+
+import (
+	"bufio"
+	"io"
+)
+
+/*
+StreamDecoder implements an incremental ASN.1 decoder which reads one
+top-level TLV at a time from an underlying [io.Reader].
+
+Unlike [Unmarshal], which requires the complete encoding to reside
+within a [PDU], a StreamDecoder only holds the bytes of the element
+currently being decoded. This is useful when processing a long series
+of concatenated elements, such as those arriving over a network socket.
+
+Instances of this type should be initialized using [NewStreamDecoder].
+*/
+type StreamDecoder struct {
+	r    *bufio.Reader
+	rule EncodingRule
+}
+
+/*
+NewStreamDecoder returns a freshly initialized instance of *[StreamDecoder]
+which reads elements from r, each of which shall be decoded per rule. The
+input is read by way of a [bufio.Reader], thus r should not be read from by
+the caller while the decoder is in use.
+
+Note that only binary encoding rules, such as [BER], [CER] and [DER], are
+supported.
+*/
+func NewStreamDecoder(r io.Reader, rule EncodingRule) *StreamDecoder {
+	return &StreamDecoder{r: bufio.NewReader(r), rule: rule}
+}
+
+/*
+Decode returns an error following an attempt to read the next top-level
+TLV from the receiver's underlying [io.Reader] and decode it into x, which
+must be a pointer, as with [Unmarshal].
+
+Definite-length elements are read in one pass once their length octets
+have been parsed. Indefinite-length elements, if permitted by the encoding
+rule, are streamed until the corresponding end-of-contents octets and
+are then converted to the equivalent definite-length form prior to being
+decoded.
+
+An [io.EOF] error is returned if the stream ends cleanly before any octet
+of a new element is read. If the stream ends in the midst of an element,
+[io.ErrUnexpectedEOF] is returned.
+*/
+func (r *StreamDecoder) Decode(x any) (err error) {
+	if r == nil || r.r == nil {
+		err = errorNilReceiver
+		return
+	} else if r.rule.textual() {
+		err = errorTextualPDU
+		return
+	} else if !r.rule.Enabled() {
+		err = errorRuleNotImplemented
+		return
+	}
+
+	var buf []byte
+	if buf, err = r.readElement(nil, 0); err == nil {
+		err = Unmarshal(r.rule.New(buf...), x)
+	}
+
+	return
+}
+
+/*
+readElement returns buf, to which a single complete TLV read from the
+underlying [io.Reader] has been appended, alongside an error. If depth is
+zero (0), an [io.EOF] encountered prior to the first octet is returned
+as-is. Indefinite-length elements, including those nested within, are
+appended in definite form, up to [MaxDecodeDepth] levels deep.
+*/
+func (r *StreamDecoder) readElement(buf []byte, depth int) ([]byte, error) {
+	if MaxDecodeDepth > 0 && depth > MaxDecodeDepth {
+		return buf, errorMaxDecodeDepth
+	}
+	start := len(buf)
+
+	// identifier octet(s)
+	b, err := r.r.ReadByte()
+	if err != nil {
+		if !(depth == 0 && err == io.EOF) {
+			err = streamEOF(err)
+		}
+		return buf, err
+	}
+	buf = append(buf, b)
+	if b&longByte == longByte {
+		// high-tag-number form; parseTagIdentifier will
+		// reject any tag exceeding five (5) octets.
+		for i := 0; i < 5; i++ {
+			if b, err = r.r.ReadByte(); err != nil {
+				return buf, streamEOF(err)
+			}
+			if buf = append(buf, b); b&indefByte == 0 {
+				break
+			}
+		}
+	}
+	if _, _, err = parseTagIdentifier(buf[start:]); err != nil {
+		return buf, err
+	}
+	idEnd := len(buf)
+
+	// length octet(s)
+	if b, err = r.r.ReadByte(); err != nil {
+		return buf, streamEOF(err)
+	}
+	buf = append(buf, b)
+	if n := int(b & shortByte); b&indefByte != 0 && n <= 4 {
+		for ; n > 0; n-- {
+			if b, err = r.r.ReadByte(); err != nil {
+				return buf, streamEOF(err)
+			}
+			buf = append(buf, b)
+		}
+	}
+
+	var length int
+	if length, _, err = parseLength(buf[idEnd:]); err != nil {
+		return buf, err
+	}
+
+	if length >= 0 {
+		return r.readContent(buf, length)
+	} else if !r.rule.allowsIndefinite() {
+		return buf, errorIndefiniteProhibited
+	}
+
+	// BER indefinite: stream child elements until EOC, then rewrite
+	// the element in definite form, as is expected by the unmarshaler.
+	var content []byte
+	for {
+		child := len(content)
+		if content, err = r.readElement(content, depth+1); err != nil {
+			return buf, err
+		}
+		if btseq(content[child:], indefEoC) {
+			content = content[:child]
+			break
+		}
+	}
+
+	buf = buf[:idEnd]
+	encodeBCDLengthInto(&buf, len(content))
+
+	return append(buf, content...), nil
+}
+
+/*
+readContent returns buf, to which length content octets read from the
+underlying [io.Reader] have been appended, alongside an error. Octets
+are read in bounded chunks so that a bogus length cannot provoke a
+single oversized allocation.
+*/
+func (r *StreamDecoder) readContent(buf []byte, length int) ([]byte, error) {
+	for length > 0 {
+		n := min(length, streamChunkSize)
+		off := len(buf)
+		buf = append(buf, make([]byte, n)...)
+		if _, err := io.ReadFull(r.r, buf[off:]); err != nil {
+			return buf, streamEOF(err)
+		}
+		length -= n
+	}
+
+	return buf, nil
+}
+
+/*
+StreamEncoder implements an incremental ASN.1 encoder which writes each
+encoded element directly to an underlying [io.Writer].
+
+Unlike [Marshal], which accumulates the complete encoding within a [PDU],
+a StreamEncoder only holds the encoding of the element currently being
+written. Combined with [StreamEncoder.EncodeSequenceOf], this allows very
+large SEQUENCE OF values to be written without buffering them in full.
+
+Instances of this type should be initialized using [NewStreamEncoder].
+*/
+type StreamEncoder struct {
+	w    io.Writer
+	rule EncodingRule
+	open []int // content octets remaining per open container, or -1
+}
+
+/*
+NewStreamEncoder returns a freshly initialized instance of *[StreamEncoder]
+which writes elements to w, each of which shall be encoded per rule.
+
+Note that only binary encoding rules, such as [BER], [CER] and [DER], are
+supported.
+*/
+func NewStreamEncoder(w io.Writer, rule EncodingRule) *StreamEncoder {
+	return &StreamEncoder{w: w, rule: rule}
+}
+
+/*
+Encode returns an error following an attempt to encode x, as with [Marshal],
+and write the resultant TLV to the receiver's underlying [io.Writer].
+
+If a SEQUENCE OF is open (see [StreamEncoder.EncodeSequenceOf]), x is
+written as its next element.
+*/
+func (r *StreamEncoder) Encode(x any) (err error) {
+	if err = r.check(); err != nil {
+		return
+	}
+
+	pkt := r.rule.New()
+	if err = marshalValue(refValueOf(x), pkt, nil); err == nil {
+		err = r.write(pkt.Data())
+	}
+	pkt.Free()
+
+	return
+}
+
+/*
+WriteElement returns an error following an attempt to encode x and write it
+as the next element of the innermost SEQUENCE OF opened by way of
+[StreamEncoder.EncodeSequenceOf].
+
+Unlike [StreamEncoder.Encode], an error is returned if no SEQUENCE OF is open.
+This is the preferred means of streaming an unbounded collection under [BER],
+e.g.:
+
+	enc := NewStreamEncoder(w, BER)
+	enc.EncodeSequenceOf(-1) // indefinite length
+	for _, elem := range elems {
+		enc.WriteElement(elem)
+	}
+	enc.Close() // end-of-contents
+*/
+func (r *StreamEncoder) WriteElement(x any) (err error) {
+	if r != nil && len(r.open) == 0 {
+		err = codecErrorf("StreamEncoder: no open SEQUENCE OF to write to")
+		return
+	}
+
+	return r.Encode(x)
+}
+
+/*
+EncodeSequenceOf returns an error following an attempt to write the header
+of a SEQUENCE OF, after which each element is written by way of subsequent
+calls of [StreamEncoder.Encode]. The SEQUENCE OF must be terminated using
+[StreamEncoder.Close].
+
+As definite-length encodings state the length of their content up front,
+n must declare the total number of content octets to be written, i.e.: the
+sum of the encoded sizes of all elements. The encoder verifies that exactly
+n octets were written upon [StreamEncoder.Close].
+
+If n is negative, the indefinite-length form is used and [StreamEncoder.Close]
+writes the end-of-contents octets. This is only permitted by encoding rules
+which allow indefinite lengths, such as [BER]. As [CER] mandates this form
+for all constructed encodings, it is always used under CER, and n is not
+verified.
+
+Containers may be nested.
+*/
+func (r *StreamEncoder) EncodeSequenceOf(n int) (err error) {
+	if err = r.check(); err != nil {
+		return
+	}
+
+	hdr := []byte{emitHeader(ClassUniversal, TagSequence, true)}
+	if n < 0 || r.rule == CER {
+		if !r.rule.allowsIndefinite() {
+			err = errorIndefiniteProhibited
+			return
+		}
+		hdr = append(hdr, indefByte)
+		n = -1
+	} else {
+		encodeLengthInto(r.rule, &hdr, n)
+	}
+
+	if err = r.write(hdr); err == nil {
+		r.open = append(r.open, n)
+	}
+
+	return
+}
+
+/*
+Close returns an error following an attempt to terminate the innermost
+SEQUENCE OF opened by [StreamEncoder.EncodeSequenceOf].
+
+For the indefinite-length form, the end-of-contents octets are written.
+For the definite-length form, an error is returned if the number of octets
+written does not match the declared length.
+*/
+func (r *StreamEncoder) Close() (err error) {
+	if r == nil || len(r.open) == 0 {
+		err = codecErrorf("StreamEncoder: no open SEQUENCE OF to close")
+		return
+	}
+
+	last := len(r.open) - 1
+	remain := r.open[last]
+	r.open = r.open[:last]
+
+	if remain < 0 {
+		err = r.write(indefEoC)
+	} else if remain > 0 {
+		err = codecErrorf("StreamEncoder: SEQUENCE OF is short by ", remain, " octets")
+	}
+
+	return
+}
+
+/*
+check returns an error if the receiver cannot be used for encoding.
+*/
+func (r *StreamEncoder) check() (err error) {
+	if r == nil || r.w == nil {
+		err = errorNilReceiver
+	} else if r.rule.textual() {
+		err = errorTextualPDU
+	} else if !r.rule.Enabled() {
+		err = errorRuleNotImplemented
+	}
+
+	return
+}
+
+/*
+write returns an error following an attempt to write b to the underlying
+[io.Writer]. The content octets remaining within each open definite-length
+container are reduced accordingly.
+*/
+func (r *StreamEncoder) write(b []byte) (err error) {
+	for _, remain := range r.open {
+		if remain >= 0 && len(b) > remain {
+			err = codecErrorf("StreamEncoder: SEQUENCE OF exceeds declared length by ",
+				len(b)-remain, " octets")
+			return
+		}
+	}
+
+	if _, err = r.w.Write(b); err == nil {
+		for i, remain := range r.open {
+			if remain >= 0 {
+				r.open[i] -= len(b)
+			}
+		}
+	}
+
+	return
+}
+
+/*
+streamEOF returns [io.ErrUnexpectedEOF] if err is [io.EOF], else err.
+*/
+func streamEOF(err error) error {
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+const streamChunkSize = 32 * 1024
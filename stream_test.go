@@ -0,0 +1,263 @@
+package asn1plus
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestStreamDecoder(t *testing.T) {
+	type Entry struct {
+		Name PrintableString
+		Code Enumerated
+	}
+
+	entries := []Entry{
+		{PrintableString("alpha"), 1},
+		{PrintableString("bravo"), 2},
+		{PrintableString("charlie"), 3},
+	}
+
+	var stream []byte
+	for _, e := range entries {
+		pkt, err := Marshal(e, With(BER))
+		if err != nil {
+			t.Fatalf("%s failed [BER marshal]: %v", t.Name(), err)
+		}
+		stream = append(stream, pkt.Data()...)
+	}
+
+	// Append the final entry once more using indefinite length.
+	stream = append(stream, 0x30, 0x80,
+		0x13, 0x05, 'd', 'e', 'l', 't', 'a',
+		0x0A, 0x01, 0x04,
+		0x00, 0x00)
+	entries = append(entries, Entry{PrintableString("delta"), 4})
+
+	pr, pw := io.Pipe()
+	go func() {
+		// deliver the stream in small chunks
+		for i := 0; i < len(stream); i += 3 {
+			pw.Write(stream[i:min(i+3, len(stream))])
+		}
+		pw.Close()
+	}()
+
+	dec := NewStreamDecoder(pr, BER)
+	for i, want := range entries {
+		var got Entry
+		if err := dec.Decode(&got); err != nil {
+			t.Fatalf("%s failed [decode #%d]: %v", t.Name(), i, err)
+		} else if got != want {
+			t.Fatalf("%s failed [decode #%d]:\n\twant: %#v\n\tgot:  %#v", t.Name(), i, want, got)
+		}
+	}
+
+	var extra Entry
+	if err := dec.Decode(&extra); err != io.EOF {
+		t.Fatalf("%s failed: want io.EOF, got %v", t.Name(), err)
+	}
+}
+
+func TestStreamDecoder_codecov(t *testing.T) {
+	var dest PrintableString
+	for idx, tc := range []struct {
+		rule EncodingRule
+		data []byte
+		want error
+	}{
+		{BER, []byte{0x13, 0x05, 'a', 'b'}, io.ErrUnexpectedEOF},
+		{BER, []byte{0x13}, io.ErrUnexpectedEOF},
+		{BER, []byte{0x1F, 0x81}, io.ErrUnexpectedEOF},
+		{BER, []byte{0x13, 0x82, 0x01}, io.ErrUnexpectedEOF},
+		{BER, []byte{0x30, 0x80, 0x13, 0x01, 'a'}, io.ErrUnexpectedEOF},
+		{BER, []byte{0x1F, 0x81, 0x81, 0x81, 0x81, 0x81}, errorTagTooLarge},
+		{BER, []byte{0x13, 0x85, 0x01}, errorLengthTooLarge},
+		{DER, []byte{0x30, 0x80, 0x00, 0x00}, errorIndefiniteProhibited},
+		{JER, []byte(`"abc"`), errorTextualPDU},
+	} {
+		if !tc.rule.textual() && !tc.rule.Enabled() {
+			continue
+		}
+		dec := NewStreamDecoder(bytes.NewReader(tc.data), tc.rule)
+		if err := dec.Decode(&dest); err != tc.want {
+			t.Fatalf("%s[%d] failed: want %v, got %v", t.Name(), idx, tc.want, err)
+		}
+	}
+
+	var nilDec *StreamDecoder
+	if err := nilDec.Decode(&dest); err == nil {
+		t.Fatalf("%s failed: expected error for nil receiver, got nil", t.Name())
+	}
+}
+
+func TestStreamEncoder(t *testing.T) {
+	items := []OctetString{
+		OctetString("alpha"),
+		OctetString("bravo"),
+		OctetString("charlie"),
+	}
+
+	var elems []byte
+	for _, item := range items {
+		pkt, _ := Marshal(item, With(BER))
+		elems = append(elems, pkt.Data()...)
+	}
+	indef := append(append([]byte{0x30, 0x80}, elems...), 0x00, 0x00)
+
+	for _, tc := range []struct {
+		rule EncodingRule
+		n    int
+		want []byte
+	}{
+		{DER, len(elems), append([]byte{0x30, byte(len(elems))}, elems...)},
+		{BER, -1, indef},
+		{CER, -1, indef},
+		{CER, len(elems), indef}, // CER mandates the indefinite form
+	} {
+		if !tc.rule.Enabled() {
+			continue
+		}
+
+		var buf bytes.Buffer
+		enc := NewStreamEncoder(&buf, tc.rule)
+		if err := enc.EncodeSequenceOf(tc.n); err != nil {
+			t.Fatalf("%s failed [%s open]: %v", t.Name(), tc.rule, err)
+		}
+		for _, item := range items {
+			if err := enc.Encode(item); err != nil {
+				t.Fatalf("%s failed [%s encode]: %v", t.Name(), tc.rule, err)
+			}
+		}
+		if err := enc.Close(); err != nil {
+			t.Fatalf("%s failed [%s close]: %v", t.Name(), tc.rule, err)
+		}
+
+		if got := buf.Bytes(); !btseq(got, tc.want) {
+			t.Fatalf("%s failed [%s output]:\n\twant: %X\n\tgot:  %X", t.Name(), tc.rule, tc.want, got)
+		}
+	}
+}
+
+func TestStreamEncoder_codecov(t *testing.T) {
+	enc := NewStreamEncoder(io.Discard, BER)
+	if err := enc.Close(); err == nil {
+		t.Fatalf("%s failed: expected error for close without open, got nil", t.Name())
+	}
+
+	// short definite length
+	enc.EncodeSequenceOf(10)
+	enc.Encode(OctetString("hi"))
+	if err := enc.Close(); err == nil {
+		t.Fatalf("%s failed: expected short length error, got nil", t.Name())
+	}
+
+	// overlong definite length
+	enc.EncodeSequenceOf(2)
+	if err := enc.Encode(OctetString("hi")); err == nil {
+		t.Fatalf("%s failed: expected overlong length error, got nil", t.Name())
+	}
+
+	if DER.Enabled() {
+		if err := NewStreamEncoder(io.Discard, DER).EncodeSequenceOf(-1); err != errorIndefiniteProhibited {
+			t.Fatalf("%s failed: want %v, got %v", t.Name(), errorIndefiniteProhibited, err)
+		}
+	}
+
+	if err := NewStreamEncoder(io.Discard, JER).Encode(OctetString("hi")); err != errorTextualPDU {
+		t.Fatalf("%s failed: want %v, got %v", t.Name(), errorTextualPDU, err)
+	}
+
+	var nilEnc *StreamEncoder
+	if err := nilEnc.Encode(OctetString("hi")); err == nil {
+		t.Fatalf("%s failed: expected error for nil receiver, got nil", t.Name())
+	}
+}
+
+func benchmarkStreamItems() []OctetString {
+	items := make([]OctetString, 10000)
+	for i := range items {
+		items[i] = OctetString("streaming octets")
+	}
+	return items
+}
+
+func BenchmarkStreamEncoder(b *testing.B) {
+	items := benchmarkStreamItems()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		enc := NewStreamEncoder(io.Discard, BER)
+		if err := enc.EncodeSequenceOf(-1); err != nil {
+			b.Fatal(err)
+		}
+		for _, item := range items {
+			if err := enc.Encode(item); err != nil {
+				b.Fatal(err)
+			}
+		}
+		if err := enc.Close(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStreamEncoder_marshal(b *testing.B) {
+	items := benchmarkStreamItems()
+	opts := &Options{Sequence: true}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		pkt, err := Marshal(items, With(BER, opts))
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err = io.Discard.Write(pkt.Data()); err != nil {
+			b.Fatal(err)
+		}
+		pkt.Free()
+	}
+}
+
+func TestStreamEncoder_WriteElement(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewStreamEncoder(&buf, BER)
+
+	if err := enc.WriteElement(OctetString("orphan")); err == nil {
+		t.Fatalf("%s failed: expected error for no open SEQUENCE OF, got nil", t.Name())
+	}
+
+	if err := enc.EncodeSequenceOf(-1); err != nil {
+		t.Fatalf("%s failed [open]: %v", t.Name(), err)
+	}
+
+	var want []OctetString
+	for i := 0; i < 100; i++ {
+		elem := OctetString("element #" + itoa(i))
+		if err := enc.WriteElement(elem); err != nil {
+			t.Fatalf("%s failed [write #%d]: %v", t.Name(), i, err)
+		}
+		want = append(want, elem)
+	}
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("%s failed [close]: %v", t.Name(), err)
+	}
+
+	data := buf.Bytes()
+	if !btseq(data[:2], []byte{0x30, indefByte}) || !btseq(data[len(data)-2:], indefEoC) {
+		t.Fatalf("%s failed: expected indefinite-length SEQUENCE OF, got %X...%X",
+			t.Name(), data[:2], data[len(data)-2:])
+	}
+
+	var got []OctetString
+	if err := Unmarshal(BER.New(data...), &got, With(&Options{Sequence: true})); err != nil {
+		t.Fatalf("%s failed [decode]: %v", t.Name(), err)
+	} else if len(got) != len(want) {
+		t.Fatalf("%s failed [decode]: want %d elements, got %d", t.Name(), len(want), len(got))
+	}
+
+	for i := range want {
+		if !btseq(got[i], want[i]) {
+			t.Fatalf("%s failed [element #%d]: want %q, got %q", t.Name(), i, want[i], got[i])
+		}
+	}
+}
@@ -8,12 +8,21 @@ T61 STRING (also known as a teletex string).
 */
 
 /*
-Deprecated: T61String implements the [ITU-T Rec. T.61] string (tag 20).
+Deprecated: T61String implements the [ITU-T Rec. T.61] string (tag 20),
+also known as TeletexString.
 
 This type is implemented within this package for historical/legacy purposes
 and should not be used in modern systems. Use [UniversalString], [BMPString]
 or [UTF8String].
 
+Values are held as Go (UTF-8) strings, and are converted to and from the
+T.61 repertoire upon encoding and decoding. Accented letters are conveyed
+by way of the non-spacing diacritical mark octets 0xC1 through 0xCF, each
+of which precedes the letter it modifies (e.g.: "é" is encoded as 0xC2,
+'e'), while the remaining Latin supplementary characters (e.g.: "ß", "Ø")
+occupy the upper half of the code table, per the ISO 6937 subset of T.61
+commonly found within legacy X.509 distinguished names.
+
 [ITU-T Rec. T.61]: https://www.itu.int/rec/T-REC-T.61
 */
 type T61String string
@@ -184,7 +193,7 @@ func init() {
 		}
 
 		for _, r := range o {
-			if !isT61Char(r) {
+			if !isT61Char(r) && !t61Encodable(r) {
 				err = primitiveErrorf("T61String: invalid character '", int(r), "'")
 				break
 			}
@@ -193,7 +202,176 @@ func init() {
 		return
 	}
 
-	RegisterTextAlias[T61String](TagT61String,
-		T61StringConstraintPhase,
-		nil, nil, nil, T61Spec)
+	for i, r := range t61Supplementary {
+		if r != 0 {
+			t61SupplementaryOctets[r] = byte(0xA0 + i)
+		}
+	}
+	t61SupplementaryOctets['Ð'] = 0xE2 // Eth, as per ISO 6937
+
+	for _, d := range t61Diacritics {
+		bases, composed := []rune(d.bases), []rune(d.composed)
+		for i := range bases {
+			t61Composed[[2]rune{rune(d.octet), bases[i]}] = composed[i]
+			if _, found := t61Decomposed[composed[i]]; !found {
+				t61Decomposed[composed[i]] = [2]byte{d.octet, byte(bases[i])}
+			}
+		}
+		if _, found := t61CombiningOctets[d.mark]; !found {
+			t61CombiningOctets[d.mark] = d.octet
+		}
+	}
+
+	registerTextAlias[T61String](TagT61String,
+		func() int { return T61StringConstraintPhase },
+		nil, decodeT61String, encodeT61String, T61Spec)
+}
+
+/*
+t61Diacritics contains the non-spacing diacritical marks of T.61, each of
+which precedes the base letter it modifies. The bases and composed fields
+pair each supported base letter with its precomposed Unicode equivalent.
+A base of SPACE yields the spacing form of the mark, where one exists.
+*/
+var t61Diacritics = []struct {
+	octet    byte
+	mark     rune // combining form
+	bases    string
+	composed string
+}{
+	{0xC1, '\u0300', "AEIOUaeiou ", "ÀÈÌÒÙàèìòù`"},
+	{0xC2, '\u0301', "ACEILNORSUYZaceilnorsuyz ", "ÁĆÉÍĹŃÓŔŚÚÝŹáćéíĺńóŕśúýź´"},
+	{0xC3, '\u0302', "ACEGHIJOSUWYaceghijosuwy ", "ÂĈÊĜĤÎĴÔŜÛŴŶâĉêĝĥîĵôŝûŵŷ^"},
+	{0xC4, '\u0303', "AINOUainou ", "ÃĨÑÕŨãĩñõũ~"},
+	{0xC5, '\u0304', "AEIOUaeiou ", "ĀĒĪŌŪāēīōū¯"},
+	{0xC6, '\u0306', "AGUagu ", "ĂĞŬăğŭ˘"},
+	{0xC7, '\u0307', "CEGIZcegz ", "ĊĖĠİŻċėġż˙"},
+	{0xC8, '\u0308', "AEIOUYaeiouy ", "ÄËÏÖÜŸäëïöüÿ¨"},
+	{0xC9, '\u0308', "AEIOUYaeiouy ", "ÄËÏÖÜŸäëïöüÿ¨"}, // umlaut
+	{0xCA, '\u030A', "AUau ", "ÅŮåů˚"},
+	{0xCB, '\u0327', "CGKLNRSTcklnrst ", "ÇĢĶĻŅŖŞŢçķļņŗşţ¸"},
+	{0xCC, '\u0332', "", ""}, // non-spacing underline
+	{0xCD, '\u030B', "OUou ", "ŐŰőű˝"},
+	{0xCE, '\u0328', "AEIUaeiu ", "ĄĘĮŲąęįų˛"},
+	{0xCF, '\u030C', "CDELNRSTZcdelnrstz ", "ČĎĚĽŇŘŠŤŽčďěľňřšťžˇ"},
+}
+
+/*
+t61Supplementary contains the characters of the upper half of the T.61
+code table, i.e.: octets 0xA0 through 0xFF, sans the diacritical marks
+of [t61Diacritics]. Zero values denote octets which are undefined.
+*/
+var t61Supplementary = [96]rune{
+	// 0xA0 - 0xAF
+	'\u00A0', '¡', '¢', '£', '$', '¥', '#', '§', '¤', 0, 0, '«', 0, 0, 0, 0,
+	// 0xB0 - 0xBF
+	'°', '±', '²', '³', '×', 'µ', '¶', '·', '÷', 0, 0, '»', '¼', '½', '¾', '¿',
+	// 0xC0 - 0xCF (diacritical marks)
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	// 0xD0 - 0xDF
+	'―', '¹', '®', '©', '™', '♪', '¬', '¦', 0, 0, 0, 0, '⅛', '⅜', '⅝', '⅞',
+	// 0xE0 - 0xEF
+	'Ω', 'Æ', 'Đ', 'ª', 'Ħ', 0, 'Ĳ', 'Ŀ', 'Ł', 'Ø', 'Œ', 'º', 'Þ', 'Ŧ', 'Ŋ', 'ŉ',
+	// 0xF0 - 0xFF
+	'ĸ', 'æ', 'đ', 'ð', 'ħ', 'ı', 'ĳ', 'ŀ', 'ł', 'ø', 'œ', 'ß', 'þ', 'ŧ', 'ŋ', '\u00AD',
+}
+
+var (
+	t61Composed            = make(map[[2]rune]rune) // {diacritic, base} → composed
+	t61Decomposed          = make(map[rune][2]byte) // composed → {diacritic, base}
+	t61SupplementaryOctets = make(map[rune]byte)    // supplementary → octet
+	t61CombiningOctets     = make(map[rune]byte)    // combining mark → diacritic
+)
+
+/*
+t61Encodable returns a Boolean value indicative of whether r, which is
+not within the basic T.61 repertoire, may nonetheless be encoded by way
+of a diacritical mark or supplementary octet.
+*/
+func t61Encodable(r rune) (ok bool) {
+	if _, ok = t61Decomposed[r]; !ok {
+		_, ok = t61SupplementaryOctets[r]
+	}
+
+	return
+}
+
+/*
+decodeT61String returns the [T61String] decoded from the T.61 content
+octets b alongside an error. Each diacritical mark is combined with the
+base letter which follows it, yielding the precomposed character if one
+exists, else the base letter followed by the equivalent Unicode combining
+mark.
+*/
+func decodeT61String(b []byte) (T61String, error) {
+	sb := newStrBuilder()
+	sb.Grow(len(b))
+
+	for i := 0; i < len(b); i++ {
+		o := b[i]
+		switch {
+		case o < 0xA0:
+			sb.WriteRune(rune(o))
+		case 0xC1 <= o && o <= 0xCF:
+			if i+1 >= len(b) || b[i+1] < 0x20 || b[i+1] > 0x7E {
+				return T61String(``), primitiveErrorf("T61String: diacritical mark ",
+					hexstr([]byte{o}), " lacks a base character")
+			}
+			base := rune(b[i+1])
+			if r, ok := t61Composed[[2]rune{rune(o), base}]; ok {
+				sb.WriteRune(r)
+			} else {
+				sb.WriteRune(base)
+				sb.WriteRune(t61Diacritics[o-0xC1].mark)
+			}
+			i++
+		case t61Supplementary[o-0xA0] != 0:
+			sb.WriteRune(t61Supplementary[o-0xA0])
+		default:
+			return T61String(``), primitiveErrorf("T61String: undefined octet ", hexstr([]byte{o}))
+		}
+	}
+
+	return T61String(sb.String()), nil
+}
+
+/*
+encodeT61String returns the T.61 content octets of t alongside an error.
+Precomposed characters are decomposed into a diacritical mark followed by
+the base letter, as are base letters followed by a Unicode combining mark.
+*/
+func encodeT61String(t T61String) (out []byte, err error) {
+	out = make([]byte, 0, len(t))
+
+	for _, r := range string(t) {
+		if r < 0xA0 {
+			out = append(out, byte(r))
+		} else if oct, ok := t61SupplementaryOctets[r]; ok {
+			out = append(out, oct)
+		} else if dec, ok := t61Decomposed[r]; ok {
+			out = append(out, dec[0], dec[1])
+		} else if oct, ok := t61CombiningOctets[r]; ok && t61Combinable(out) {
+			// move the mark ahead of the base letter
+			base := out[len(out)-1]
+			out = append(out[:len(out)-1], oct, base)
+		} else {
+			err = primitiveErrorf("T61String: character '", string(r), "' (",
+				int(r), ") cannot be encoded")
+			out = nil
+			break
+		}
+	}
+
+	return
+}
+
+/*
+t61Combinable returns a Boolean value indicative of whether the final
+octet of out is a base letter which does not already bear a diacritical
+mark.
+*/
+func t61Combinable(out []byte) bool {
+	n := len(out)
+	return n > 0 && 0x20 <= out[n-1] && out[n-1] <= 0x7E &&
+		!(n > 1 && 0xC1 <= out[n-2] && out[n-2] <= 0xCF)
 }
@@ -135,3 +135,66 @@ func BenchmarkT61StringConstructor(b *testing.B) {
 		}
 	}
 }
+
+func TestT61String_diacritics(t *testing.T) {
+	for _, tc := range []struct {
+		value T61String
+		wire  []byte
+	}{
+		{"Café", []byte{0x43, 0x61, 0x66, 0xC2, 0x65}},
+		{"Łódź", []byte{0xE8, 0xC2, 0x6F, 0x64, 0xC2, 0x7A}},
+		{"Müller", []byte{0x4D, 0xC8, 0x75, 0x6C, 0x6C, 0x65, 0x72}},
+		{"Straße", []byte{0x53, 0x74, 0x72, 0x61, 0xFB, 0x65}},
+		{"Čapek £5", []byte{0xCF, 0x43, 0x61, 0x70, 0x65, 0x6B, 0x20, 0xA3, 0x35}},
+	} {
+		for _, rule := range encodingRules {
+			pkt, err := Marshal(tc.value, With(rule))
+			if err != nil {
+				t.Fatalf("%s failed [%s encoding %q]: %v", t.Name(), rule, tc.value, err)
+			}
+
+			want := append([]byte{TagT61String, byte(len(tc.wire))}, tc.wire...)
+			if got := pkt.Data(); !btseq(got, want) {
+				t.Fatalf("%s failed [%s encoding %q]:\n\twant: %X\n\tgot:  %X",
+					t.Name(), rule, tc.value, want, got)
+			}
+
+			var out T61String
+			if err = Unmarshal(pkt, &out); err != nil {
+				t.Fatalf("%s failed [%s decoding %q]: %v", t.Name(), rule, tc.value, err)
+			} else if out != tc.value {
+				t.Fatalf("%s failed [%s decoding]:\n\twant: %q\n\tgot:  %q",
+					t.Name(), rule, tc.value, out)
+			}
+		}
+	}
+
+	// a base letter followed by a combining mark is encoded
+	// just as the equivalent precomposed character would be.
+	if wire, err := encodeT61String("Cafe\u0301"); err != nil {
+		t.Fatalf("%s failed [combining mark]: %v", t.Name(), err)
+	} else if want := []byte{0x43, 0x61, 0x66, 0xC2, 0x65}; !btseq(wire, want) {
+		t.Fatalf("%s failed [combining mark]:\n\twant: %X\n\tgot:  %X", t.Name(), want, wire)
+	}
+
+	// marks lacking a precomposed form yield a combining mark
+	if out, err := decodeT61String([]byte{0xC2, 0x78}); err != nil {
+		t.Fatalf("%s failed [uncomposed]: %v", t.Name(), err)
+	} else if out != "x\u0301" {
+		t.Fatalf("%s failed [uncomposed]: want %q, got %q", t.Name(), "x\u0301", out)
+	}
+
+	for _, bogus := range [][]byte{
+		{0x61, 0xC2},       // dangling diacritical mark
+		{0xC2, 0xC8, 0x61}, // mark upon a mark
+		{0xA9},             // undefined octet
+	} {
+		if _, err := decodeT61String(bogus); err == nil {
+			t.Fatalf("%s failed [decode %X]: expected error, got nil", t.Name(), bogus)
+		}
+	}
+
+	if _, err := encodeT61String("€"); err == nil {
+		t.Fatalf("%s failed [encode €]: expected error, got nil", t.Name())
+	}
+}
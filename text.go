@@ -43,7 +43,7 @@ func (c *textCodec[T]) write(pkt PDU, o *Options) (n int, err error) {
 	case BER, DER:
 		n, err = bcdTextWrite[T](c, pkt, o)
 	case CER:
-		if len([]byte(c.val)) > 1000 && c.Tag() == TagOctetString {
+		if len([]byte(c.val)) > cerMaxSegment && c.Tag() == TagOctetString {
 			n, err = cerSegmentedOctetStringWrite(c, pkt, o)
 		} else {
 			n, err = bcdTextWrite[T](c, pkt, o)
@@ -62,7 +62,7 @@ func bcdTextWrite[T TextLike](c *textCodec[T], pkt PDU, o *Options) (off int, er
 	}()
 	o = deferImplicit(o)
 
-	cc := c.cg.phase(c.cphase, CodecConstraintEncoding)
+	cc := c.cg.phase(o.constraintPhase(c.cphase), CodecConstraintEncoding)
 	if err = cc(c.val); err == nil {
 
 		var wire []byte
@@ -139,7 +139,7 @@ func bcdTextRead[T TextLike](c *textCodec[T], pkt PDU, tlv TLV, o *Options) (err
 			debugEvent(mask, newLItem(val, "decoded"))
 
 			if err == nil {
-				cc := c.cg.phase(c.cphase, CodecConstraintDecoding)
+				cc := c.cg.phase(o.constraintPhase(c.cphase), CodecConstraintDecoding)
 				if err = cc(val); err == nil {
 					c.val = val
 					pkt.AddOffset(tlv.Length)
@@ -202,6 +202,25 @@ func RegisterTextAlias[T TextLike](
 	spec Constraint,
 	user ...Constraint) {
 
+	registerTextAlias[T](tag, func() int { return cphase },
+		verify, decoder, encoder, spec, user...)
+}
+
+/*
+registerTextAlias implements [RegisterTextAlias]. Unlike the latter, the
+constraint phase is obtained from cphase each time a codec is created,
+thus the package-level phase variables of built-in types, such as
+[PrintableStringConstraintPhase], take effect whenever they are changed.
+*/
+func registerTextAlias[T TextLike](
+	tag int,
+	cphase func() int,
+	verify DecodeVerifier,
+	decoder DecodeOverride[T],
+	encoder EncodeOverride[T],
+	spec Constraint,
+	user ...Constraint) {
+
 	all := append(ConstraintGroup{spec}, user...)
 
 	var verList []DecodeVerifier
@@ -213,7 +232,7 @@ func RegisterTextAlias[T TextLike](
 		newEmpty: func() box {
 			return &textCodec[T]{
 				tag: tag, cg: all,
-				cphase:       cphase,
+				cphase:       cphase(),
 				decodeVerify: verList,
 				decodeHook:   decoder,
 				encodeHook:   encoder}
@@ -222,7 +241,7 @@ func RegisterTextAlias[T TextLike](
 			return &textCodec[T]{
 				val: valueOf[T](v),
 				tag: tag, cg: all,
-				cphase:       cphase,
+				cphase:       cphase(),
 				decodeVerify: verList,
 				decodeHook:   decoder,
 				encodeHook:   encoder}
@@ -13,6 +13,38 @@ import (
 
 var tnow func() time.Time = time.Now
 
+/*
+SetClock replaces the function used by this package to determine the
+current time, which is [time.Now] by default. A nil input value is
+equivalent to calling [ResetClock].
+
+This only affects the package notion of "now", as returned by [Now],
+such as that used by debug timestamps and by time-relative [Constraint]
+functions written in terms of [Now]. It has no effect on [time.Now]
+itself, nor on any values already constructed.
+
+This function is intended for tests and deterministic deployments. It
+is not safe for use concurrently with other package operations.
+*/
+func SetClock(clock func() time.Time) {
+	if clock == nil {
+		clock = time.Now
+	}
+	tnow = clock
+}
+
+/*
+ResetClock restores [time.Now] as the function used by this package to
+determine the current time. See [SetClock].
+*/
+func ResetClock() { tnow = time.Now }
+
+/*
+Now returns the current time as reported by the clock in use by this
+package, which is [time.Now] unless replaced by way of [SetClock].
+*/
+func Now() time.Time { return tnow() }
+
 /*
 Temporal is a date and time interface qualified by instances of the
 following types:
@@ -155,6 +187,33 @@ func truncateBy(r, by Temporal) time.Time {
 	return r.Cast()
 }
 
+/*
+Since returns the signed [time.Duration] elapsed between b and a, i.e.:
+a.Cast().Sub(b.Cast()). The result is positive if a occurs after b and
+negative if a occurs before b.
+
+This complements comparison methods such as [Time.Lt] and [Time.Gt]. As
+with those methods, if either value is a [Date], both values are truncated
+to the day prior to subtraction, thus the time of day of a [DateTime] is
+disregarded when measured against a [Date]. Likewise, if either value is a
+[TimeOfDay], only the time of day of each value is considered.
+
+Zero (0) is returned if either value is nil.
+*/
+func Since(a, b Temporal) time.Duration {
+	if a == nil || b == nil {
+		return 0
+	}
+
+	by := b
+	switch a.(type) {
+	case Date, TimeOfDay:
+		by = a
+	}
+
+	return truncateBy(a, by).Sub(truncateBy(b, by))
+}
+
 /*
 Eq returns a Boolean value indicative of r being equal to t.
 */
@@ -475,6 +534,9 @@ func (r Date) Cast() time.Time { return time.Time(r) }
 
 /*
 DateTime implements the ASN.1 DATE-TIME type (tag 33), which extends from [Time].
+
+By default, values are of whole-second precision. Fractional seconds may be
+enabled through [DateTimeFractionDigits], e.g.: "2025-02-19T20:21:09.125".
 */
 type DateTime Time
 
@@ -488,6 +550,24 @@ for possible settings.
 */
 var DateTimeConstraintPhase = CodecConstraintDecoding
 
+/*
+DateTimeFractionDigits declares the number of fractional second digits
+retained by [NewDateTime] when given a [time.Time] instance, and written
+when encoding [DateTime] values. When zero (0, the default), values are
+truncated to whole seconds and no fraction is written.
+
+When greater than zero (0), exactly that many digits are written, padded
+with zeros or truncated as needed. Values greater than six (6) are treated
+as six (6), as this package retains microsecond precision. When negative,
+up to six (6) digits are written and trailing zeros are removed, with the
+fraction omitted entirely for whole seconds.
+
+This is useful when interoperating with peers that expect a fixed-length
+fraction, e.g.: exactly three (3) digits for millisecond precision. This
+setting has no effect on decoding, which accepts up to six (6) digits.
+*/
+var DateTimeFractionDigits int
+
 /*
 NewDateTime returns an instance of [DateTime] alongside an error following an
 attempt to marshal x.
@@ -504,9 +584,9 @@ func NewDateTime(x any, constraints ...Constraint) (DateTime, error) {
 	case []byte:
 		s = unsafe.String(&tv[0], len(tv))
 	case DateTime:
-		s = tv.String()
+		s = formatDateTimeDigits(tv.Cast(), -1)
 	case time.Time:
-		s = formatDateTime(tv.Truncate(time.Second))
+		s = formatDateTimeDigits(tv.Truncate(dateTimeResolution()), -1)
 	default:
 		err = errorBadTypeForConstructor("DATE-TIME", x)
 	}
@@ -550,10 +630,24 @@ Layout returns string literal "2006-01-02T15:04:05".
 */
 func (r DateTime) Layout() string { return dateTimeLayout }
 
-// parseDateTime parses the fixed-width layout 2006-01-02T15:04:05.
+// parseDateTime parses the fixed-width layout 2006-01-02T15:04:05,
+// optionally followed by a fraction of up to six digits (e.g.: ".123").
 // Returns UTC.  Zero allocs; ~120 ns on modern CPUs.
 func parseDateTime(s string) (time.Time, error) {
-	if len(s) != 19 {
+	var nsec int
+	if len(s) > 19 {
+		next := 19
+		var err error
+		if s[19] != '.' {
+			err = primitiveErrorf("DATE-TIME: invalid format")
+		} else if nsec, next, err = parseGTFraction(s, 19); err != nil || next-20 > 6 {
+			err = primitiveErrorf("DATE-TIME: invalid fraction")
+		}
+		if err != nil || next != len(s) {
+			return time.Time{}, primitiveErrorf("DATE-TIME: invalid format")
+		}
+		s = s[:19]
+	} else if len(s) != 19 {
 		return time.Time{}, primitiveErrorf("DATE-TIME: invalid length")
 	}
 	// quick layout check – cheap and rejects most garbage early
@@ -569,11 +663,37 @@ func parseDateTime(s string) (time.Time, error) {
 	min := toInt(s[14], s[15])
 	sec := toInt(s[17], s[18])
 
-	return time.Date(year, time.Month(month), day, hour, min, sec, 0, time.UTC), nil
+	return time.Date(year, time.Month(month), day, hour, min, sec, nsec, time.UTC), nil
+}
+
+/*
+dateTimeResolution returns the [time.Duration] to which [time.Time] values
+are truncated by [NewDateTime] per [DateTimeFractionDigits].
+*/
+func dateTimeResolution() (res time.Duration) {
+	res = time.Second
+	if digits := DateTimeFractionDigits; digits < 0 || digits > 6 {
+		res = time.Microsecond
+	} else {
+		for ; digits > 0; digits-- {
+			res /= 10
+		}
+	}
+
+	return
 }
 
 func formatDateTime(t time.Time) string {
-	var b [19]byte
+	return formatDateTimeDigits(t, DateTimeFractionDigits)
+}
+
+/*
+formatDateTimeDigits returns the string form of t bearing exactly digits
+fractional digits, or bearing a minimal fraction if digits is negative.
+See [DateTimeFractionDigits].
+*/
+func formatDateTimeDigits(t time.Time, digits int) string {
+	var b [26]byte // 19 base + '.' + 6 frac
 	put2 := func(i, v int) {
 		b[i] = byte('0' + v/10)
 		b[i+1] = byte('0' + v%10)
@@ -593,7 +713,8 @@ func formatDateTime(t time.Time) string {
 	put2(14, t.Minute())
 	b[16] = ':'
 	put2(17, t.Second())
-	return string(b[:]) // one unavoidable copy; still zero allocs on parse path
+	i := putFraction(b[:], 19, t.Nanosecond(), digits)
+	return string(b[:i]) // one unavoidable copy; still zero allocs on parse path
 }
 
 func decDateTime(b []byte) (DateTime, error) {
@@ -856,6 +977,10 @@ func (r TimeOfDay) truncate() time.Time {
 
 /*
 Duration implements the ASN.1 DURATION type (tag 34).
+
+As X.680 DURATION values bear no sign, negative components are rejected
+during construction, encoding and decoding. A zero duration is valid and
+is represented as "PT0S".
 */
 type Duration struct {
 	Years   int
@@ -909,6 +1034,9 @@ func NewDuration(x any, constraints ...Constraint) (Duration, error) {
 	case []byte:
 		s = string(tv)
 	case time.Duration:
+		if tv < 0 {
+			return Duration{}, errorNegativeDuration
+		}
 		s = parseTimeDuration(tv).String()
 	default:
 		return Duration{}, errorBadTypeForConstructor("DURATION", x)
@@ -932,7 +1060,7 @@ func NewDuration(x any, constraints ...Constraint) (Duration, error) {
 
 	var r Duration
 	if err = _r.parseDuration(datePart, timePart); err == nil {
-		err = checkDurationEmpty(_r, err)
+		err = checkDurationEmpty(datePart, timePart, err)
 		if len(constraints) > 0 && err == nil {
 			err = ConstraintGroup(constraints).Constrain(_r)
 		}
@@ -957,14 +1085,13 @@ func MustNewDuration(x any, constraints ...Constraint) Duration {
 	return b
 }
 
-func checkDurationEmpty(r Duration, err error) error {
-	if err == nil &&
-		r.Years == 0 &&
-		r.Months == 0 &&
-		r.Days == 0 &&
-		r.Hours == 0 &&
-		r.Minutes == 0 &&
-		r.Seconds == 0 {
+/*
+checkDurationEmpty returns an error if neither the date nor time parts
+of a DURATION string bear a component. Note that components of zero (0)
+are permitted, thus "PT0S" is valid.
+*/
+func checkDurationEmpty(datePart, timePart string, err error) error {
+	if err == nil && datePart == "" && timePart == "" {
 		err = primitiveErrorf("Duration: must contain at least one component")
 	}
 
@@ -977,6 +1104,15 @@ const (
 	mon  = 30 * day
 )
 
+/*
+negative returns a Boolean value indicative of any component of the
+receiver instance being negative.
+*/
+func (r Duration) negative() bool {
+	return r.Years < 0 || r.Months < 0 || r.Days < 0 ||
+		r.Hours < 0 || r.Minutes < 0 || r.Seconds < 0
+}
+
 /*
 Duration returns an instance of [time.Duration] based upon
 the state of the receiver instance.
@@ -997,6 +1133,12 @@ func (r *Duration) parseDuration(datePart, timePart string) (err error) {
 	parseNumber := func(str string, suffix byte) (float64, string, error) {
 		idx := stridxb(str, suffix)
 		numStr := str[:idx]
+		if len(numStr) == 0 || numStr[0] < '0' || numStr[0] > '9' {
+			if len(numStr) > 0 && numStr[0] == '-' {
+				return 0, str, errorNegativeDuration
+			}
+			return 0, str, primitiveErrorf("error parsing number ", numStr)
+		}
 		numStr = replace(numStr, ",", ".", 1)
 		num, err := pfloat(numStr, 64)
 		if err != nil {
@@ -1014,6 +1156,8 @@ func (r *Duration) parseDuration(datePart, timePart string) (err error) {
 
 // parseTimeDuration decomposes a time.Duration back into an
 // ASN.1 Duration record using the same Y/M/D approximations.
+// A negative td yields negative components, which cannot be
+// encoded; see errorNegativeDuration.
 func parseTimeDuration(td time.Duration) Duration {
 	neg := td < 0
 	if neg {
@@ -1181,6 +1325,12 @@ func (r Duration) Ne(d Duration) bool { return !r.Eq(d) }
 
 /*
 Lt returns a Boolean value indicative of r being less than d.
+
+Note that the comparison is strictly component-by-component, beginning
+with the years, thus "PT1H" is less than "PT61M" only by virtue of the
+hours component. Equivalent durations expressed differently, such as
+"PT60M" and "PT1H", are not considered equal. See [Duration.Cmp] for a
+comparison based upon the total length of each duration.
 */
 func (r Duration) Lt(d Duration) bool {
 	for _, pair := range []struct {
@@ -1223,6 +1373,9 @@ func (r Duration) Le(d Duration) bool { return r.Eq(d) || r.Lt(d) }
 
 /*
 Gt returns a Boolean value indicative of r being greater than d.
+
+As with [Duration.Lt], the comparison is component-by-component. See
+[Duration.Cmp] for a comparison based upon total length.
 */
 func (r Duration) Gt(d Duration) bool {
 	for _, pair := range []struct {
@@ -1263,6 +1416,62 @@ Ge returns a Boolean value indicative of r being greater than or equal to d.
 */
 func (r Duration) Ge(d Duration) bool { return r.Eq(d) || r.Gt(d) }
 
+/*
+Cmp returns an integer comparing the total length of r to that of d,
+as returned by [Duration.Duration]. The result is -1 if r is shorter
+than d, 0 if both are of equal length and +1 if r is longer than d.
+
+Unlike [Duration.Eq], [Duration.Lt] and [Duration.Gt], both values are
+converted to a common time base prior to comparison, thus "PT60M" and
+"PT1H" are equal. Years, months and days are converted using the same
+approximations as [Duration.Duration], i.e.: 365 days per year and 30
+days per month.
+*/
+func (r Duration) Cmp(d Duration) int {
+	rd, dd := r.Duration(), d.Duration()
+
+	var c int
+	if rd < dd {
+		c = -1
+	} else if rd > dd {
+		c = 1
+	}
+
+	return c
+}
+
+/*
+Normalize returns a new instance of [Duration] in which out-of-range time
+components of the receiver are carried into the next larger component:
+sixty (60) seconds become one (1) minute, sixty (60) minutes become one
+(1) hour and twenty-four (24) hours become one (1) day. Any fractional
+part of the seconds component is retained.
+
+Days, months and years are left untouched, as their relationship is
+ambiguous under ISO 8601 (e.g.: a month may have 28 to 31 days).
+
+Normalization is useful prior to the use of comparison methods such as
+[Duration.Eq] and [Duration.Lt], which compare component-by-component.
+For example, "PT90M" and "PT1H30M" are only equal once normalized.
+*/
+func (r Duration) Normalize() Duration {
+	n := r
+
+	carry := int(n.Seconds / 60)
+	n.Seconds -= float64(carry * 60)
+	n.Minutes += carry
+
+	carry = n.Minutes / 60
+	n.Minutes -= carry * 60
+	n.Hours += carry
+
+	carry = n.Hours / 24
+	n.Hours -= carry * 24
+	n.Days += carry
+
+	return n
+}
+
 /*
 AddTo returns a new instance of [time.Time] following a call to
 [time.Time.Add] for the purpose of adding the receiver instance
@@ -1325,6 +1534,35 @@ for possible settings.
 */
 var GeneralizedTimeConstraintPhase = CodecConstraintDecoding
 
+/*
+GeneralizedTimeLenientFraction declares whether [GeneralizedTime] values
+bearing more than six (6) fractional digits should be accepted during
+decoding. When true, excess digits are truncated to microsecond precision,
+which is the precision used internally by this package. When false (the
+default), such values are rejected in keeping with DER compliance.
+
+This is useful when interoperating with encoders that emit nanosecond
+precision, e.g.: "20250101123000.123456789Z".
+*/
+var GeneralizedTimeLenientFraction bool
+
+/*
+GeneralizedTimeFractionDigits declares the number of fractional second
+digits written when encoding [GeneralizedTime] values. When negative (the
+default), up to six (6) digits are written and trailing zeros are removed,
+with the fraction omitted entirely for whole seconds.
+
+When zero (0) or more, exactly that many digits are written, padded with
+zeros or truncated as needed. Zero (0) omits the fraction. Values greater
+than six (6) are treated as six (6), as this package retains microsecond
+precision. Note that DER prohibits trailing zeros within the fraction.
+
+This is useful when interoperating with peers that require a fixed-length
+fraction, e.g.: exactly three (3) digits for millisecond precision. This
+setting has no effect on decoding, which accepts any valid digit count.
+*/
+var GeneralizedTimeFractionDigits int = -1
+
 /*
 Tag returns the integer constant [TagGeneralizedTime].
 */
@@ -1355,6 +1593,8 @@ func NewGeneralizedTime(x any, constraints ...Constraint) (gt GeneralizedTime, e
 			return gt, primitiveErrorf("GeneralizedTime: invalid input")
 		}
 		raw = tv
+	case time.Time:
+		raw = formatGeneralizedTime(tv)
 	default:
 		return gt, errorBadTypeForConstructor("GeneralizedTime", x)
 	}
@@ -1449,12 +1689,13 @@ func parseGTFraction(s string, i int) (nsec, next int, err error) {
 		next++
 	}
 	fd := next - start
-	if fd == 0 || fd > 6 {
+	if fd == 0 || (fd > 6 && !GeneralizedTimeLenientFraction) {
 		err = primitiveErrorf("GeneralizedTime: fraction exceeds fractional limit")
 		return
 	}
+	fd = min(fd, 6) // truncate excess digits, if lenient
 	frac := 0
-	for j := start; j < next; j++ {
+	for j := start; j < start+fd; j++ {
 		frac = frac*10 + int(s[j]-'0')
 	}
 	for ; fd < 6; fd++ {
@@ -1528,6 +1769,20 @@ func parseGeneralizedTime(s string) (time.Time, error) {
 }
 
 func formatGeneralizedTime(t time.Time) string {
+	return formatGeneralizedTimeDigits(t, -1)
+}
+
+/*
+formatGeneralizedTimeDigits returns the string form of t bearing exactly
+digits fractional digits, or bearing a minimal fraction if digits is
+negative. See [GeneralizedTimeFractionDigits].
+
+The time zone of t is preserved: a zero offset is written as "Z", while
+any other offset is written in numeric "+hhmm" or "-hhmm" form following
+the local date and time, as parsed by parseGTTimezone. This is the [BER]
+form; see formatCanonicalGeneralizedTime for that of [CER] and [DER].
+*/
+func formatGeneralizedTimeDigits(t time.Time, digits int) string {
 	var buf [32]byte // 14 base + '.' + 6 frac + 'Z'  → max 22, 32 is safe
 	i := 0
 
@@ -1549,9 +1804,43 @@ func formatGeneralizedTime(t time.Time) string {
 	put2(t.Minute())
 	put2(t.Second())
 
-	// optional fractional seconds (µs precision)
-	nsec := t.Nanosecond()
-	if nsec != 0 {
+	i = putFraction(buf[:], i, t.Nanosecond(), digits)
+
+	if _, off := t.Zone(); off == 0 {
+		buf[i] = 'Z'
+		i++
+	} else {
+		buf[i] = '+'
+		if off < 0 {
+			buf[i], off = '-', -off
+		}
+		i++
+		put2(off / 3600)
+		put2((off % 3600) / 60)
+	}
+
+	return string(buf[:i])
+}
+
+/*
+formatCanonicalGeneralizedTime returns the string form of t required by
+[CER] and [DER], per ITU-T Rec. X.690 clause 11.7: t is converted to UTC,
+and the time zone is always "Z".
+*/
+func formatCanonicalGeneralizedTime(t time.Time) string {
+	return formatGeneralizedTimeDigits(t.UTC(), GeneralizedTimeFractionDigits)
+}
+
+/*
+putFraction writes the fractional seconds of nsec into buf at index i,
+and returns the index following the last byte written. If digits is
+negative, up to six (6) digits are written with trailing zeros removed,
+and nothing is written for whole seconds. Otherwise exactly digits
+digits (at most six) are written, and nothing is written for zero (0).
+buf must have room for seven (7) bytes following i.
+*/
+func putFraction(buf []byte, i, nsec, digits int) int {
+	if (digits < 0 && nsec != 0) || digits > 0 {
 		frac := nsec / 1_000 // to microseconds (max 6 digits)
 		buf[i] = '.'
 		i++
@@ -1561,16 +1850,17 @@ func formatGeneralizedTime(t time.Time) string {
 			buf[i] = byte('0' + (frac/p)%10)
 			i++
 		}
-		// trim right-hand zeros
-		for i > start && buf[i-1] == '0' {
-			i--
+		if digits < 0 {
+			// trim right-hand zeros
+			for i > start && buf[i-1] == '0' {
+				i--
+			}
+		} else {
+			i = start + min(digits, 6)
 		}
 	}
 
-	buf[i] = 'Z'
-	i++
-
-	return string(buf[:i])
+	return i
 }
 
 func decGeneralizedTime(b []byte) (GeneralizedTime, error) {
@@ -1579,7 +1869,7 @@ func decGeneralizedTime(b []byte) (GeneralizedTime, error) {
 }
 
 func encGeneralizedTime(d GeneralizedTime) ([]byte, error) {
-	return []byte(formatGeneralizedTime(time.Time(d))), nil
+	return []byte(formatGeneralizedTimeDigits(time.Time(d), GeneralizedTimeFractionDigits)), nil
 }
 
 /*
@@ -1658,8 +1948,18 @@ type temporalCodec[T Temporal] struct {
 	decodeVerify []DecodeVerifier
 	encodeHook   EncodeOverride[T]
 	decodeHook   DecodeOverride[T]
+	canonical    bool // default encodeHook in use; see canonicalTemporalFormats
 }
 
+/*
+canonicalTemporalFormats contains the string formatters, keyed by tag, of
+those temporal types whose [CER] and [DER] encodings are more restrictive
+than those produced by their default encode hooks (e.g.: UTC is required).
+These are only used in place of the default encode hooks, never in place
+of hooks supplied to [RegisterTemporalAlias].
+*/
+var canonicalTemporalFormats = make(map[int]func(time.Time) string)
+
 func (c *temporalCodec[T]) write(pkt PDU, o *Options) (n int, err error) {
 	switch pkt.Type() {
 	case BER, CER, DER:
@@ -1673,10 +1973,15 @@ func (c *temporalCodec[T]) write(pkt PDU, o *Options) (n int, err error) {
 func bcdTemporalWrite[T Temporal](c *temporalCodec[T], pkt PDU, o *Options) (off int, err error) {
 	o = deferImplicit(o)
 
-	cc := c.cg.phase(c.cphase, CodecConstraintEncoding)
+	cc := c.cg.phase(o.constraintPhase(c.cphase), CodecConstraintEncoding)
 	if err = cc(c.val); err == nil {
 		var wire []byte
-		if wire, err = c.encodeHook(c.val); err == nil {
+		if format, ok := canonicalTemporalFormats[c.tag]; ok && c.canonical && pkt.Type().In(CER, DER) {
+			wire = []byte(format(c.val.Cast()))
+		} else {
+			wire, err = c.encodeHook(c.val)
+		}
+		if err == nil {
 			tag, cls := effectiveHeader(c.tag, 0, o)
 			start := pkt.Offset()
 			tlv := pkt.Type().newTLV(cls, tag, len(wire), false, wire...)
@@ -1715,7 +2020,7 @@ func bcdTemporalRead[T Temporal](c *temporalCodec[T], pkt PDU, tlv TLV, o *Optio
 		if err = decodeVerify(); err == nil {
 			var out T
 			if out, err = c.decodeHook(wire); err == nil {
-				cc := c.cg.phase(c.cphase, CodecConstraintDecoding)
+				cc := c.cg.phase(o.constraintPhase(c.cphase), CodecConstraintDecoding)
 				if err = cc(out); err == nil {
 					c.val = out
 					pkt.AddOffset(tlv.Length)
@@ -1773,6 +2078,7 @@ func RegisterTemporalAlias[T Temporal](
 ) {
 	all := append(ConstraintGroup{spec}, user...)
 
+	canonical := encoder == nil
 	encoder, decoder = fillTemporalHooks[T](encoder, decoder)
 
 	var verList []DecodeVerifier
@@ -1789,6 +2095,7 @@ func RegisterTemporalAlias[T Temporal](
 				decodeVerify: verList,
 				decodeHook:   decoder,
 				encodeHook:   encoder,
+				canonical:    canonical,
 			}
 		},
 		newWith: func(v any) box {
@@ -1800,6 +2107,7 @@ func RegisterTemporalAlias[T Temporal](
 				decodeVerify: verList,
 				decodeHook:   decoder,
 				encodeHook:   encoder,
+				canonical:    canonical,
 			}
 		},
 	}
@@ -1877,13 +2185,16 @@ func (c *durationCodec[T]) write(pkt PDU, o *Options) (n int, err error) {
 func bcdDurationWrite[T any](c *durationCodec[T], pkt PDU, o *Options) (off int, err error) {
 	o = deferImplicit(o)
 
-	cc := c.cg.phase(c.cphase, CodecConstraintEncoding)
+	cc := c.cg.phase(o.constraintPhase(c.cphase), CodecConstraintEncoding)
 	if err = cc(c.val); err == nil {
 		var wire []byte
 		if c.encodeHook != nil {
 			wire, err = c.encodeHook(c.val)
+		} else if dur := toDuration(c.val); dur.negative() {
+			// X.680 DURATION bears no sign
+			err = errorNegativeDuration
 		} else {
-			wire = []byte(toDuration(c.val).String())
+			wire = []byte(dur.String())
 		}
 
 		if err == nil {
@@ -1943,7 +2254,7 @@ func bcdDurationRead[T any](c *durationCodec[T], pkt PDU, tlv TLV, o *Options) e
 			}
 
 			if err == nil {
-				cc := c.cg.phase(c.cphase, CodecConstraintDecoding)
+				cc := c.cg.phase(o.constraintPhase(c.cphase), CodecConstraintDecoding)
 				if err = cc(out); err == nil {
 					c.val = out
 					pkt.AddOffset(tlv.Length)
@@ -2029,6 +2340,7 @@ func RegisterDurationAlias[T any](
 }
 
 func init() {
+	canonicalTemporalFormats[TagGeneralizedTime] = formatCanonicalGeneralizedTime
 	RegisterTemporalAlias[Date](TagDate,
 		DateConstraintPhase,
 		nil, nil, nil, nil)
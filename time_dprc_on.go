@@ -23,6 +23,18 @@ for possible settings.
 */
 var UTCTimeConstraintPhase = CodecConstraintDecoding
 
+/*
+UTCTimeYearPivot declares the two-digit year at which decoded [UTCTime]
+values change centuries. Two-digit years less than the pivot are mapped
+to 20xx, while all others are mapped to 19xx.
+
+The default of fifty (50) honors the X.509 convention (see RFC 5280,
+Section 4.1.2.5.1), i.e.: 50-99 ⇒ 19xx and 00-49 ⇒ 20xx. A value of
+zero (0) maps all years to 19xx, while one hundred (100) maps all years
+to 20xx.
+*/
+var UTCTimeYearPivot = 50
+
 /*
 Tag returns the integer constant [TagUTCTime].
 */
@@ -116,6 +128,8 @@ func NewUTCTime(x any, constraints ...Constraint) (UTCTime, error) {
 	switch tv := x.(type) {
 	case string:
 		raw = tv // keep the Z and UTC offset intact for fast path
+	case time.Time:
+		raw = formatUTCTime(tv)
 	default:
 		err = errorBadTypeForConstructor("UTC TIME", x)
 	}
@@ -175,12 +189,13 @@ func parseUTCCore(s string) (yy, mm, dd, hr, mn, sc, next int, err error) {
 		}
 	}
 
-	if len(s) >= 12 && utcDigit(s[11]) {
+	// seconds, if present, must be two digits
+	hasSec := utcDigit(s[10])
+	if hasSec && !(len(s) >= 12 && utcDigit(s[11])) {
 		err = errorBadUTCTime
 		return
 	}
 
-	hasSec := utcDigit(s[10])
 	yy = utcToInt(s[0], s[1])
 	mm = utcToInt(s[2], s[3])
 	dd = utcToInt(s[4], s[5])
@@ -246,8 +261,8 @@ func parseUTCTime(s string) (utc time.Time, err error) {
 	if yy, mo, dd, hr, mn, sc, i, err = parseUTCCore(s); err == nil {
 		var loc *time.Location
 		if loc, err = parseUTCTimezone(s, i); err == nil {
-			// two-digit year mapping (50-99 ⇒ 19xx, 00-49 ⇒ 20xx)
-			if yy < 50 {
+			// two-digit year mapping per UTCTimeYearPivot
+			if yy < UTCTimeYearPivot {
 				yy += 2000
 			} else {
 				yy += 1900
@@ -260,20 +275,56 @@ func parseUTCTime(s string) (utc time.Time, err error) {
 	return
 }
 
-func formatUTCTime(t time.Time) string {
-	var b [11]byte // YYMMDDhhmm + 'Z'
-	put2 := func(idx, v int) {
-		b[idx] = byte('0' + v/10)
-		b[idx+1] = byte('0' + v%10)
+/*
+formatUTCTime returns the string form of t. Seconds are only written
+if non-zero. As with GeneralizedTime, the time zone of t is preserved:
+a zero offset is written as "Z", while any other offset is written in
+numeric "+hhmm" or "-hhmm" form. See also formatCanonicalUTCTime.
+*/
+func formatUTCTime(t time.Time) string { return formatUTCTimeSeconds(t, false) }
+
+/*
+formatCanonicalUTCTime returns the string form of t required by [CER]
+and [DER], per ITU-T Rec. X.690 clause 11.8: t is converted to UTC, the
+seconds are always written and the time zone is always "Z".
+*/
+func formatCanonicalUTCTime(t time.Time) string { return formatUTCTimeSeconds(t.UTC(), true) }
+
+/*
+formatUTCTimeSeconds implements formatUTCTime and formatCanonicalUTCTime.
+If sec is true, the seconds are written even if zero (0).
+*/
+func formatUTCTimeSeconds(t time.Time, sec bool) string {
+	var b [17]byte // YYMMDDhhmmss + '+hhmm'
+	i := 0
+	put2 := func(v int) {
+		b[i] = byte('0' + v/10)
+		b[i+1] = byte('0' + v%10)
+		i += 2
 	}
-	yy := t.Year() % 100
-	put2(0, yy)
-	put2(2, int(t.Month()))
-	put2(4, t.Day())
-	put2(6, t.Hour())
-	put2(8, t.Minute())
-	b[10] = 'Z'
-	return string(b[:])
+	put2(t.Year() % 100)
+	put2(int(t.Month()))
+	put2(t.Day())
+	put2(t.Hour())
+	put2(t.Minute())
+	if sec || t.Second() != 0 {
+		put2(t.Second())
+	}
+
+	if _, off := t.Zone(); off == 0 {
+		b[i] = 'Z'
+		i++
+	} else {
+		b[i] = '+'
+		if off < 0 {
+			b[i], off = '-', -off
+		}
+		i++
+		put2(off / 3600)
+		put2((off % 3600) / 60)
+	}
+
+	return string(b[:i])
 }
 
 func decUTCTime(b []byte) (UTCTime, error) {
@@ -340,6 +391,7 @@ func fillTemporalHooks[T Temporal](
 }
 
 func init() {
+	canonicalTemporalFormats[TagUTCTime] = formatCanonicalUTCTime
 	RegisterTemporalAlias[UTCTime](TagUTCTime,
 		UTCTimeConstraintPhase,
 		nil, nil, nil, nil)
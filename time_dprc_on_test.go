@@ -8,6 +8,19 @@ import (
 	"time"
 )
 
+func init() {
+	type fuzzUTCRecord struct {
+		When  UTCTime
+		Count Integer
+		Flags BitString
+		Name  PrintableString
+	}
+
+	fuzzTargets = append(fuzzTargets,
+		func() any { return new(UTCTime) },
+		func() any { return new(fuzzUTCRecord) })
+}
+
 func TestMustNewUTCTime_MustPanic(t *testing.T) {
 	defer func() {
 		if r := recover(); r == nil {
@@ -254,8 +267,9 @@ func Test_parseUTCCore_InvalidDigits(t *testing.T) {
 		{"25010112304X"},
 		{"25010112304X"},
 		{"25010112307?"},
-		{"970104123455Z"},
-		{"9701041234554783957349Z"},
+		{"970104123455"},
+		{"97010412345Z"},
+		{"24010203041"},
 	}
 
 	for _, c := range cases {
@@ -267,3 +281,101 @@ func Test_parseUTCCore_InvalidDigits(t *testing.T) {
 		})
 	}
 }
+
+func TestUTCTime_roundTrip(t *testing.T) {
+	for _, rule := range encodingRules {
+		for idx, value := range []string{
+			`9805061703Z`,
+			`980506170306Z`,
+			`2401011230Z`,
+			`620506170306-0500`,
+			`6205061703+0130`,
+		} {
+			utc, err := NewUTCTime(value)
+			if err != nil {
+				t.Fatalf("%s[%s][%d] failed [ctor]: %v", t.Name(), rule, idx, err)
+			}
+
+			pkt, err := Marshal(utc, With(rule))
+			if err != nil {
+				t.Fatalf("%s[%s][%d] failed [encode]: %v", t.Name(), rule, idx, err)
+			}
+
+			// CER and DER normalize to UTC, so only the instant survives
+			var out UTCTime
+			if err = Unmarshal(pkt, &out); err != nil {
+				t.Fatalf("%s[%s][%d] failed [decode]: %v", t.Name(), rule, idx, err)
+			} else if got := out.String(); rule == BER && got != value {
+				t.Fatalf("%s[%s][%d] failed:\n\twant: %s\n\tgot:  %s",
+					t.Name(), rule, idx, value, got)
+			} else if !out.Cast().Equal(utc.Cast()) {
+				t.Fatalf("%s[%s][%d] failed: instant changed", t.Name(), rule, idx)
+			}
+		}
+	}
+}
+
+func TestUTCTimeYearPivot(t *testing.T) {
+	defer func(p int) { UTCTimeYearPivot = p }(UTCTimeYearPivot)
+
+	for idx, tc := range []struct {
+		pivot int
+		input string
+		want  int
+	}{
+		{50, `4912312359Z`, 2049},
+		{50, `5001010000Z`, 1950},
+		{70, `6912312359Z`, 2069},
+		{70, `7001010000Z`, 1970},
+		{0, `0001010000Z`, 1900},
+		{100, `9912312359Z`, 2099},
+	} {
+		UTCTimeYearPivot = tc.pivot
+		utc, err := NewUTCTime(tc.input)
+		if err != nil {
+			t.Fatalf("%s[%d] failed: %v", t.Name(), idx, err)
+		} else if got := utc.Cast().Year(); got != tc.want {
+			t.Fatalf("%s[%d] failed: want %d, got %d", t.Name(), idx, tc.want, got)
+		}
+	}
+}
+
+func TestUTCTime_canonicalEncoding(t *testing.T) {
+	for idx, tc := range []struct {
+		input string
+		ber   string
+		der   string
+	}{
+		{`2401020304Z`, `2401020304Z`, `240102030400Z`},
+		{`240102030405+0200`, `240102030405+0200`, `240102010405Z`},
+		{`2401020034-0130`, `2401020034-0130`, `240102020400Z`},
+	} {
+		utc, err := NewUTCTime(tc.input)
+		if err != nil {
+			t.Fatalf("%s[%d] failed [ctor]: %v", t.Name(), idx, err)
+		}
+
+		for _, rule := range encodingRules {
+			want := tc.der
+			if rule == BER {
+				want = tc.ber
+			}
+			wire := append([]byte{TagUTCTime, byte(len(want))}, want...)
+
+			pkt, err := Marshal(utc, With(rule))
+			if err != nil {
+				t.Fatalf("%s[%d] failed [%s encode]: %v", t.Name(), idx, rule, err)
+			} else if !btseq(pkt.Data(), wire) {
+				t.Fatalf("%s[%d] failed [%s encode]:\n\twant: %s\n\tgot:  %s",
+					t.Name(), idx, rule, hexstr(wire), hexstr(pkt.Data()))
+			}
+		}
+	}
+
+	// truncated seconds must not provoke a panic
+	var u UTCTime
+	pkt := DER.New(0x17, 0x0B, '2', '4', '0', '1', '0', '2', '0', '3', '0', '4', '1')
+	if err := Unmarshal(pkt, &u); err == nil {
+		t.Fatalf("%s failed [truncated]: expected error, got nil", t.Name())
+	}
+}
@@ -109,6 +109,13 @@ func ExampleDuration_AddTo() {
 	// Output: Time 2026-06-04 19:08:37 +0000 UTC
 }
 
+func ExampleDuration_Normalize() {
+	d1, _ := NewDuration("PT90M")
+	d2, _ := NewDuration("PT1H30M")
+	fmt.Println(d1.Eq(d2), d1.Normalize().Eq(d2))
+	// Output: false true
+}
+
 func ExampleDuration_Duration() {
 	d1, err := NewDuration("P1Y2M3DT4H5M30S")
 	if err != nil {
@@ -423,6 +430,274 @@ func TestFormatGeneralizedTime_FractionalSeconds(t *testing.T) {
 	}
 }
 
+func TestGeneralizedTime_lenientFraction(t *testing.T) {
+	raw := "20250101123000.123456789Z"
+
+	// strict by default
+	if _, err := parseGeneralizedTime(raw); err == nil {
+		t.Fatalf("%s failed: expected error for %s, got nil", t.Name(), raw)
+	}
+
+	GeneralizedTimeLenientFraction = true
+	defer func() { GeneralizedTimeLenientFraction = false }()
+
+	gt, err := NewGeneralizedTime(raw)
+	if err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+
+	if got, want := gt.Cast().Nanosecond(), 123_456_000; got != want {
+		t.Fatalf("%s failed [nsec]: want %d, got %d", t.Name(), want, got)
+	} else if got, want := gt.String(), "20250101123000.123456Z"; got != want {
+		t.Fatalf("%s failed [string]: want %s, got %s", t.Name(), want, got)
+	}
+
+	var out GeneralizedTime
+	pkt := BER.New(append([]byte{TagGeneralizedTime, byte(len(raw))}, raw...)...)
+	if err = Unmarshal(pkt, &out); err != nil {
+		t.Fatalf("%s failed [BER decode]: %v", t.Name(), err)
+	} else if !out.Eq(gt) {
+		t.Fatalf("%s failed [BER decode]: want %s, got %s", t.Name(), gt, out)
+	}
+}
+
+func TestGeneralizedTime_preserveOffset(t *testing.T) {
+	for _, raw := range []string{
+		`20240229155703-0500`,
+		`20240229155703.25+0200`,
+		`20240229155703+0530`,
+		`20240229155703Z`,
+	} {
+		gt, err := NewGeneralizedTime(raw)
+		if err != nil {
+			t.Fatalf("%s failed [%s]: %v", t.Name(), raw, err)
+		} else if got := gt.String(); got != raw {
+			t.Fatalf("%s failed [string]: want %s, got %s", t.Name(), raw, got)
+		}
+
+		pkt, err := Marshal(gt, With(BER))
+		if err != nil {
+			t.Fatalf("%s failed [BER encode %s]: %v", t.Name(), raw, err)
+		} else if got := string(pkt.Data()[2:]); got != raw {
+			t.Fatalf("%s failed [BER encode]: want %s, got %s", t.Name(), raw, got)
+		}
+
+		var out GeneralizedTime
+		if err = Unmarshal(pkt, &out); err != nil {
+			t.Fatalf("%s failed [BER decode %s]: %v", t.Name(), raw, err)
+		} else if got := out.String(); got != raw || !out.Eq(gt) {
+			t.Fatalf("%s failed [BER decode]: want %s, got %s", t.Name(), raw, got)
+		}
+	}
+}
+
+func TestGeneralizedTime_canonicalEncoding(t *testing.T) {
+	for idx, tc := range []struct {
+		input string
+		der   string
+	}{
+		{`20240229155703+0200`, `20240229135703Z`},
+		{`20240229155703.25-0530`, `20240229212703.25Z`},
+		{`20240229235959-0100`, `20240301005959Z`},
+		{`20240229155703Z`, `20240229155703Z`},
+	} {
+		gt, err := NewGeneralizedTime(tc.input)
+		if err != nil {
+			t.Fatalf("%s[%d] failed [ctor]: %v", t.Name(), idx, err)
+		}
+
+		for _, rule := range encodingRules {
+			want := tc.der
+			if rule == BER {
+				want = tc.input
+			}
+			wire := append([]byte{TagGeneralizedTime, byte(len(want))}, want...)
+
+			pkt, err := Marshal(gt, With(rule))
+			if err != nil {
+				t.Fatalf("%s[%d] failed [%s encode]: %v", t.Name(), idx, rule, err)
+			} else if !btseq(pkt.Data(), wire) {
+				t.Fatalf("%s[%d] failed [%s encode]:\n\twant: %s\n\tgot:  %s",
+					t.Name(), idx, rule, hexstr(wire), hexstr(pkt.Data()))
+			}
+
+			var out GeneralizedTime
+			if err = Unmarshal(pkt, &out); err != nil {
+				t.Fatalf("%s[%d] failed [%s decode]: %v", t.Name(), idx, rule, err)
+			} else if !out.Cast().Equal(gt.Cast()) {
+				t.Fatalf("%s[%d] failed [%s decode]: instant changed", t.Name(), idx, rule)
+			}
+		}
+	}
+}
+
+func TestSetClock(t *testing.T) {
+	fixed := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	SetClock(func() time.Time { return fixed })
+	defer ResetClock()
+
+	// values must be no more than one day old
+	within24h := func(x any) (err error) {
+		o, _ := x.(GeneralizedTime)
+		if Now().Sub(o.Cast()) > 24*time.Hour {
+			err = fmt.Errorf("Constraint violation: value is more than one day old")
+		}
+		return
+	}
+
+	if _, err := NewGeneralizedTime(`20250301000000Z`, within24h); err != nil {
+		t.Fatalf("%s failed [recent]: %v", t.Name(), err)
+	}
+	if _, err := NewGeneralizedTime(`20250227000000Z`, within24h); err == nil {
+		t.Fatalf("%s failed [stale]: expected error, got nil", t.Name())
+	}
+	if now := Now(); !now.Equal(fixed) {
+		t.Fatalf("%s failed: want %s, got %s", t.Name(), fixed, now)
+	}
+
+	SetClock(nil)
+	if now := Now(); now.Equal(fixed) {
+		t.Fatalf("%s failed: clock not reset by nil input", t.Name())
+	}
+}
+
+func TestSince(t *testing.T) {
+	d, _ := NewDate("2025-02-19")
+	dt1, _ := NewDateTime("2025-02-19T20:21:09")
+	dt2, _ := NewDateTime("2025-02-21T08:00:00")
+	tod, _ := NewTimeOfDay("08:00:00")
+
+	for idx, tc := range []struct {
+		a, b Temporal
+		want time.Duration
+	}{
+		{dt2, dt1, 35*time.Hour + 38*time.Minute + 51*time.Second},
+		{dt1, dt2, -(35*time.Hour + 38*time.Minute + 51*time.Second)},
+		{dt1, d, 0},
+		{d, dt1, 0},
+		{dt2, d, 48 * time.Hour},
+		{d, dt2, -48 * time.Hour},
+		{dt1, tod, 12*time.Hour + 21*time.Minute + 9*time.Second},
+		{tod, dt2, 0},
+		{nil, d, 0},
+	} {
+		if got := Since(tc.a, tc.b); got != tc.want {
+			t.Fatalf("%s[%d] failed: want %s, got %s", t.Name(), idx, tc.want, got)
+		}
+	}
+}
+
+func TestGeneralizedTime_fractionDigits(t *testing.T) {
+	gt, _ := NewGeneralizedTime("20250101123000.12Z")
+	whole, _ := NewGeneralizedTime("20250101123000Z")
+
+	defer func() { GeneralizedTimeFractionDigits = -1 }()
+
+	for idx, tc := range []struct {
+		digits int
+		in     GeneralizedTime
+		want   string
+	}{
+		{-1, gt, "20250101123000.12Z"},
+		{-1, whole, "20250101123000Z"},
+		{0, gt, "20250101123000Z"},
+		{3, gt, "20250101123000.120Z"},
+		{3, whole, "20250101123000.000Z"},
+		{6, gt, "20250101123000.120000Z"},
+		{9, gt, "20250101123000.120000Z"},
+	} {
+		GeneralizedTimeFractionDigits = tc.digits
+
+		pkt, err := Marshal(tc.in, With(BER))
+		if err != nil {
+			t.Fatalf("%s[%d] failed [BER encode]: %v", t.Name(), idx, err)
+		}
+
+		if got := string(pkt.Data()[2:]); got != tc.want {
+			t.Fatalf("%s[%d] failed [BER encode]: want %s, got %s", t.Name(), idx, tc.want, got)
+		}
+
+		var out GeneralizedTime
+		if err = Unmarshal(pkt, &out); err != nil {
+			t.Fatalf("%s[%d] failed [BER decode]: %v", t.Name(), idx, err)
+		}
+	}
+}
+
+func TestDateTime_fractionDigits(t *testing.T) {
+	defer func() { DateTimeFractionDigits = 0 }()
+
+	// fractions are opt-in: time.Time input is truncated to whole
+	// seconds unless DateTimeFractionDigits says otherwise.
+	ref := time.Date(2025, 2, 19, 20, 21, 9, 125_456_789, time.UTC)
+	for idx, tc := range []struct {
+		digits int
+		want   time.Duration
+	}{
+		{0, 0},
+		{3, 125 * time.Millisecond},
+		{-1, 125_456 * time.Microsecond},
+	} {
+		DateTimeFractionDigits = tc.digits
+		dt, err := NewDateTime(ref)
+		if err != nil {
+			t.Fatalf("%s[%d] failed: %v", t.Name(), idx, err)
+		} else if got := time.Duration(dt.Cast().Nanosecond()); got != tc.want {
+			t.Fatalf("%s[%d] failed: want fraction %v, got %v", t.Name(), idx, tc.want, got)
+		}
+	}
+
+	dt, err := NewDateTime("2025-02-19T20:21:09.125")
+	if err != nil {
+		t.Fatalf("%s failed: %v", t.Name(), err)
+	}
+	whole, _ := NewDateTime("2025-02-19T20:21:09")
+
+	for idx, tc := range []struct {
+		digits int
+		in     DateTime
+		want   string
+	}{
+		{-1, dt, "2025-02-19T20:21:09.125"},
+		{-1, whole, "2025-02-19T20:21:09"},
+		{0, dt, "2025-02-19T20:21:09"},
+		{2, dt, "2025-02-19T20:21:09.12"},
+		{3, dt, "2025-02-19T20:21:09.125"},
+		{3, whole, "2025-02-19T20:21:09.000"},
+		{6, dt, "2025-02-19T20:21:09.125000"},
+	} {
+		DateTimeFractionDigits = tc.digits
+
+		pkt, err := Marshal(tc.in, With(BER))
+		if err != nil {
+			t.Fatalf("%s[%d] failed [BER encode]: %v", t.Name(), idx, err)
+		}
+
+		got := string(pkt.Data()[3:]) // high tag number form
+		if got != tc.want {
+			t.Fatalf("%s[%d] failed [BER encode]: want %s, got %s", t.Name(), idx, tc.want, got)
+		} else if tc.digits > 0 && len(got)-len("2025-02-19T20:21:09.") != tc.digits {
+			t.Fatalf("%s[%d] failed: want %d fractional digits, got %s", t.Name(), idx, tc.digits, got)
+		}
+
+		var out DateTime
+		if err = Unmarshal(pkt, &out); err != nil {
+			t.Fatalf("%s[%d] failed [BER decode]: %v", t.Name(), idx, err)
+		}
+	}
+
+	for idx, bogus := range []string{
+		"2025-02-19T20:21:09.",
+		"2025-02-19T20:21:09,125",
+		"2025-02-19T20:21:09.1234567",
+		"2025-02-19T20:21:09.12a",
+	} {
+		if _, err := NewDateTime(bogus); err == nil {
+			t.Fatalf("%s[%d] failed: expected error for %q, got nil", t.Name(), idx, bogus)
+		}
+	}
+}
+
 func TestParseTimeDuration_NegativeDuration(t *testing.T) {
 	// Example duration: -1 year, -2 months, -3 days, -4 hours, -5 minutes, -6.5 seconds
 	td := -(year*time.Duration(1) +
@@ -444,6 +719,107 @@ func TestParseTimeDuration_NegativeDuration(t *testing.T) {
 	}
 }
 
+func TestDuration_negativeAndZero(t *testing.T) {
+	neg := parseTimeDuration(-time.Hour)
+	if neg.Hours != -1 {
+		t.Fatalf("%s failed: want -1 hours, got %#v", t.Name(), neg)
+	}
+
+	for _, rule := range encodingRules {
+		if _, err := Marshal(neg, With(rule)); err != errorNegativeDuration {
+			t.Fatalf("%s failed [%s encode]: want %v, got %v", t.Name(), rule, errorNegativeDuration, err)
+		}
+
+		// "PT-1H" hand-encoded
+		var out Duration
+		pkt := rule.New(0x1F, 0x22, 0x05, 'P', 'T', '-', '1', 'H')
+		if err := Unmarshal(pkt, &out); err == nil {
+			t.Fatalf("%s failed [%s decode]: expected error, got nil", t.Name(), rule)
+		}
+
+		zero, err := NewDuration(time.Duration(0))
+		if err != nil {
+			t.Fatalf("%s failed [%s zero]: %v", t.Name(), rule, err)
+		}
+		if pkt, err = Marshal(zero, With(rule)); err != nil {
+			t.Fatalf("%s failed [%s zero encode]: %v", t.Name(), rule, err)
+		} else if got := string(pkt.Data()[3:]); got != "PT0S" {
+			t.Fatalf("%s failed [%s zero encode]: want PT0S, got %s", t.Name(), rule, got)
+		} else if err = Unmarshal(pkt, &out); err != nil || out != zero {
+			t.Fatalf("%s failed [%s zero decode]: %v (%#v)", t.Name(), rule, err, out)
+		}
+	}
+
+	if _, err := NewDuration(-time.Hour); err != errorNegativeDuration {
+		t.Fatalf("%s failed: want %v, got %v", t.Name(), errorNegativeDuration, err)
+	}
+	for _, bogus := range []string{"P-1Y", "PT-30M", "P+1D", "P", "PT"} {
+		if _, err := NewDuration(bogus); err == nil {
+			t.Fatalf("%s failed: expected error for %q, got nil", t.Name(), bogus)
+		}
+	}
+}
+
+func TestDuration_Normalize(t *testing.T) {
+	for idx, tc := range []struct {
+		in, want Duration
+	}{
+		{Duration{Minutes: 90}, Duration{Hours: 1, Minutes: 30}},
+		{Duration{Seconds: 59.5}, Duration{Seconds: 59.5}},
+		{Duration{Seconds: 90.25}, Duration{Minutes: 1, Seconds: 30.25}},
+		{Duration{Hours: 23, Minutes: 59, Seconds: 60}, Duration{Days: 1}},
+		{Duration{Hours: 49, Minutes: 120, Seconds: 3601.5},
+			Duration{Days: 2, Hours: 4, Seconds: 1.5}},
+		{Duration{Years: 1, Months: 14, Days: 45}, Duration{Years: 1, Months: 14, Days: 45}},
+	} {
+		if got := tc.in.Normalize(); !got.Eq(tc.want) {
+			t.Fatalf("%s[%d] failed: want %#v, got %#v", t.Name(), idx, tc.want, got)
+		} else if got.Duration() != tc.in.Duration() {
+			t.Fatalf("%s[%d] failed: magnitude changed: %s vs %s",
+				t.Name(), idx, tc.in.Duration(), got.Duration())
+		}
+	}
+}
+
+func TestDuration_Cmp(t *testing.T) {
+	for idx, tc := range []struct {
+		a, b string
+		want int
+	}{
+		{"PT60M", "PT1H", 0},
+		{"PT3600S", "PT1H", 0},
+		{"P1D", "PT24H", 0},
+		{"PT1H", "PT61M", -1},
+		{"PT2H", "PT61M", 1},
+		{"P1M", "P31D", -1},
+		{"P1Y", "P12M", 1},
+	} {
+		a, err := NewDuration(tc.a)
+		if err != nil {
+			t.Fatalf("%s[%d] failed: %v", t.Name(), idx, err)
+		}
+		b, err := NewDuration(tc.b)
+		if err != nil {
+			t.Fatalf("%s[%d] failed: %v", t.Name(), idx, err)
+		}
+
+		if got := a.Cmp(b); got != tc.want {
+			t.Fatalf("%s[%d] failed: %s vs %s: want %d, got %d",
+				t.Name(), idx, tc.a, tc.b, tc.want, got)
+		} else if rev := b.Cmp(a); rev != -tc.want {
+			t.Fatalf("%s[%d] failed: %s vs %s: want %d, got %d",
+				t.Name(), idx, tc.b, tc.a, -tc.want, rev)
+		}
+	}
+
+	// field comparison is strict by design
+	a, _ := NewDuration("PT60M")
+	b, _ := NewDuration("PT1H")
+	if a.Eq(b) || !a.Lt(b) {
+		t.Fatalf("%s failed: unexpected field comparison result", t.Name())
+	}
+}
+
 func TestDuration_Lt_FieldComparisons(t *testing.T) {
 	base := Duration{
 		Years:   1,
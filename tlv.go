@@ -158,6 +158,90 @@ func encodeTLV(t TLV, opts *Options) (out []byte) {
 	return out
 }
 
+/*
+Encode returns the encoding of the receiver instance per rule alongside
+an error. rule must be a binary encoding rule, such as [BER] or [DER].
+
+This allows instances of [TLV] to be constructed manually -- for instance,
+when assembling test vectors -- and serialized without a [PDU]. The length
+octets are derived from the Value field, thus Length need not be set. If
+Length is negative, the indefinite form is used and end-of-contents octets
+are appended to Value, provided the receiver is compound and rule permits
+indefinite lengths.
+
+See also [ParseTLV].
+*/
+func (r TLV) Encode(rule EncodingRule) (out []byte, err error) {
+	if rule.textual() {
+		err = errorTextualPDU
+		return
+	} else if !rule.Enabled() {
+		err = errorRuleNotImplemented
+		return
+	} else if r.Tag < 0 || !validClass(r.Class) {
+		err = tLVErrorf("Encode: invalid class ", r.Class, " or tag ", r.Tag)
+		return
+	}
+
+	r.typ = rule
+	if r.Length >= 0 {
+		r.Length = len(r.Value)
+		out = encodeTLV(r, nil)
+	} else if !rule.allowsIndefinite() {
+		err = tLVErrorf(errorIndefiniteProhibited)
+	} else if !r.Compound {
+		err = tLVErrorf("Encode: indefinite length requires a compound TLV")
+	} else {
+		r.typ = BER // identical header; BER emits the indefinite length octet
+		out = append(encodeTLV(r, nil), indefEoC...)
+	}
+
+	return
+}
+
+/*
+ParseTLV returns the [TLV] found at the beginning of data, alongside the
+number of octets consumed and an error. data is interpreted per rule, which
+must be a binary encoding rule, such as [BER] or [DER], and whose constraints
+upon length octets are honored (e.g.: indefinite lengths are refused under
+[DER]).
+
+The Value field of the return instance does not reference data. Where the
+indefinite form is used, Length is negative, Value excludes the end-of-contents
+octets and the number of octets consumed includes them.
+
+See also [TLV.Encode].
+*/
+func ParseTLV(data []byte, rule EncodingRule) (tlv TLV, n int, err error) {
+	if rule.textual() {
+		err = errorTextualPDU
+		return
+	} else if !rule.Enabled() {
+		err = errorRuleNotImplemented
+		return
+	}
+
+	pkt := rule.New(data...)
+	defer pkt.Free()
+	pkt.SetOffset(0)
+
+	if tlv, err = pkt.TLV(); err != nil {
+		return
+	}
+	hdrLen := pkt.Offset()
+
+	var full []byte
+	if full, err = parseFullBytes(data, 0, rule); err == nil {
+		n = len(full)
+		if tlv.Length < 0 {
+			tlv.Value = full[hdrLen : n-len(indefEoC)]
+		}
+		tlv.Value = append([]byte{}, tlv.Value...)
+	}
+
+	return
+}
+
 func getTLVResolveOverride(class, tag int, compound bool, opts *Options) (int, int, error) {
 	var err error
 	if opts != nil && (opts.HasClass() || opts.HasTag()) {
@@ -270,7 +354,7 @@ func getTLV(r PDU, opts *Options) (tlv TLV, err error) {
 			end := off + length
 			if end > len(d) {
 				err = tLVErrorf(errorTruncDefLen,
-					": ", end, " > ", len(d), ")")
+					": ", end, " > ", len(d))
 				return
 			}
 			valueBytes = d[off:end]
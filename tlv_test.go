@@ -299,3 +299,74 @@ func TestEncodeTLV_codecov(t *testing.T) {
 	tlvVerifyLengthState(&BERPacket{offset: 1}, []byte{0x02, 0x81, 0x7F, 0x83, 0x01, 0xe4, 0x1e, 0x2a}, nil)
 	writeTLV(&BERPacket{}, TLV{Length: -1}, &Options{Indefinite: true})
 }
+
+func TestTLV_EncodeParseRoundTrip(t *testing.T) {
+	prim := TLV{Class: ClassUniversal, Tag: TagOctetString, Value: []byte("hello")}
+	child, _ := prim.Encode(BER)
+	comp := TLV{Class: ClassContextSpecific, Tag: 2, Compound: true, Value: child}
+
+	for _, rule := range encodingRules {
+		for idx, tlv := range []TLV{prim, comp} {
+			data, err := tlv.Encode(rule)
+			if err != nil {
+				t.Fatalf("%s[%d] failed [%s encode]: %v", t.Name(), idx, rule, err)
+			}
+
+			// trailing octets must not be consumed
+			got, n, err := ParseTLV(append(data, 0x05, 0x00), rule)
+			if err != nil {
+				t.Fatalf("%s[%d] failed [%s parse]: %v", t.Name(), idx, rule, err)
+			} else if n != len(data) {
+				t.Fatalf("%s[%d] failed [%s consumed]: want %d, got %d", t.Name(), idx, rule, len(data), n)
+			} else if got.Class != tlv.Class || got.Tag != tlv.Tag ||
+				got.Compound != tlv.Compound || got.Length != len(tlv.Value) ||
+				!btseq(got.Value, tlv.Value) || got.Type() != rule {
+				t.Fatalf("%s[%d] failed [%s round-trip]:\n\twant: %s\n\tgot:  %s", t.Name(), idx, rule, tlv, got)
+			}
+		}
+	}
+
+	// indefinite length
+	indef := comp
+	indef.Length = -1
+	data, err := indef.Encode(BER)
+	if err != nil {
+		t.Fatalf("%s failed [BER indefinite encode]: %v", t.Name(), err)
+	}
+	got, n, err := ParseTLV(data, BER)
+	if err != nil {
+		t.Fatalf("%s failed [BER indefinite parse]: %v", t.Name(), err)
+	} else if n != len(data) || got.Length >= 0 || !btseq(got.Value, child) {
+		t.Fatalf("%s failed [BER indefinite round-trip]: consumed %d of %d, got %s", t.Name(), n, len(data), got)
+	}
+}
+
+func TestTLV_EncodeParse_codecov(t *testing.T) {
+	if _, err := (TLV{Tag: -1}).Encode(BER); err == nil {
+		t.Fatalf("%s failed: expected error for negative tag, got nil", t.Name())
+	}
+	if _, err := (TLV{Tag: 4, Length: -1}).Encode(BER); err == nil {
+		t.Fatalf("%s failed: expected error for primitive indefinite, got nil", t.Name())
+	}
+	if _, err := (TLV{Tag: 4}).Encode(JER); err != errorTextualPDU {
+		t.Fatalf("%s failed: want %v, got %v", t.Name(), errorTextualPDU, err)
+	}
+	if _, _, err := ParseTLV([]byte{0x04, 0x01}, JER); err != errorTextualPDU {
+		t.Fatalf("%s failed: want %v, got %v", t.Name(), errorTextualPDU, err)
+	}
+	if _, _, err := ParseTLV([]byte{0x04, 0x05, 0x01}, BER); err == nil {
+		t.Fatalf("%s failed: expected truncation error, got nil", t.Name())
+	}
+	if _, _, err := ParseTLV(nil, BER); err == nil {
+		t.Fatalf("%s failed: expected error for no data, got nil", t.Name())
+	}
+
+	if DER.Enabled() {
+		if _, err := (TLV{Tag: 16, Compound: true, Length: -1}).Encode(DER); err == nil {
+			t.Fatalf("%s failed: expected DER indefinite error, got nil", t.Name())
+		}
+		if _, _, err := ParseTLV([]byte{0x30, 0x80, 0x00, 0x00}, DER); err == nil {
+			t.Fatalf("%s failed: expected DER indefinite error, got nil", t.Name())
+		}
+	}
+}
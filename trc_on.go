@@ -8,6 +8,7 @@ import (
 	"os"
 	"reflect"
 	"runtime"
+	"slices"
 	"sync"
 	"time"
 )
@@ -202,6 +203,141 @@ type discardTracer struct{}
 func (*discardTracer) Trace(_ TraceRecord)      {}
 func (*discardTracer) Enabled(_ EventType) bool { return false }
 
+/*
+FieldAnnotation describes the byte range, relative to the start of a
+[PDU] buffer, occupied by the encoding of a single named struct field.
+
+Instances of this type are recorded when [Marshal] is given the option
+returned by [WithFieldAnnotations]. See [FieldAnnotations] for details.
+*/
+type FieldAnnotation struct {
+	Start int    // offset of the first byte of the field's TLV
+	End   int    // offset immediately following the field's TLV
+	Name  string // Go struct field name
+}
+
+/*
+FieldAnnotations returns slices of [FieldAnnotation] recorded for pkt,
+sorted by offset. When pkt was produced by [Marshal] alongside the option
+returned by [WithFieldAnnotations], the byte range of each encoded SEQUENCE
+field is recorded alongside the Go field name, and the Dump method of pkt
+will label each such value accordingly.
+
+Annotations are retained until pkt is freed.
+*/
+func FieldAnnotations(pkt PDU) (anns []FieldAnnotation) {
+	annMu.Lock()
+	defer annMu.Unlock()
+
+	if pkt != nil {
+		anns = append(anns, annotations[pkt.ID()]...)
+		slices.SortStableFunc(anns, func(a, b FieldAnnotation) int {
+			return a.Start - b.Start
+		})
+	}
+
+	return
+}
+
+var (
+	annMu       sync.Mutex
+	annotating  = make(map[string]struct{})
+	annotations = make(map[string][]FieldAnnotation)
+)
+
+/*
+debugEnableAnnotations enables the recording of annotations for pkt, as
+well as for any sub-packets spawned from it by way of newSubPacket.
+*/
+func debugEnableAnnotations(pkt PDU) {
+	annMu.Lock()
+	defer annMu.Unlock()
+
+	if pkt.ID() != "" {
+		annotating[pkt.ID()] = struct{}{}
+	}
+}
+
+/*
+debugInheritAnnotations enables the recording of annotations for sub if
+they are enabled for its parent, pkt.
+*/
+func debugInheritAnnotations(pkt, sub PDU) {
+	annMu.Lock()
+	defer annMu.Unlock()
+
+	if _, on := annotating[pkt.ID()]; on && sub.ID() != "" {
+		annotating[sub.ID()] = struct{}{}
+	}
+}
+
+/*
+debugAnnotate records the field name for the bytes written to pkt since
+offset start.
+*/
+func debugAnnotate(pkt PDU, start int, name string) {
+	annMu.Lock()
+	defer annMu.Unlock()
+
+	if _, on := annotating[pkt.ID()]; on && pkt.Len() > start {
+		annotations[pkt.ID()] = append(annotations[pkt.ID()],
+			FieldAnnotation{Start: start, End: pkt.Len(), Name: name})
+	}
+}
+
+/*
+debugMergeAnnotations moves the annotations recorded for src into dst,
+shifting each by offset, which is the position at which the contents of
+src were written within dst.
+*/
+func debugMergeAnnotations(src, dst PDU, offset int) {
+	annMu.Lock()
+	defer annMu.Unlock()
+
+	delete(annotating, src.ID())
+	anns, found := annotations[src.ID()]
+	if !found {
+		return
+	}
+	delete(annotations, src.ID())
+
+	if dst.ID() != "" {
+		for _, ann := range anns {
+			ann.Start += offset
+			ann.End += offset
+			annotations[dst.ID()] = append(annotations[dst.ID()], ann)
+		}
+	}
+}
+
+/*
+debugForgetAnnotations discards any annotations recorded for pkt.
+*/
+func debugForgetAnnotations(pkt PDU) {
+	annMu.Lock()
+	defer annMu.Unlock()
+	delete(annotating, pkt.ID())
+	delete(annotations, pkt.ID())
+}
+
+/*
+debugFieldLabels returns a map of field names keyed by TLV start offset
+for use in [PDU] dumps.
+*/
+func debugFieldLabels(pkt PDU) (labels map[int]string) {
+	annMu.Lock()
+	defer annMu.Unlock()
+
+	if anns := annotations[pkt.ID()]; len(anns) > 0 {
+		labels = make(map[int]string, len(anns))
+		for _, ann := range anns {
+			labels[ann.Start] = ann.Name
+		}
+	}
+
+	return
+}
+
 var (
 	rndMu       sync.Mutex
 	rnd         = rand.New(rand.NewSource(tnow().UnixNano()))
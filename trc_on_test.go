@@ -95,3 +95,74 @@ func TestLoglevels_codecov(t *testing.T) {
 		fmtArg(val)
 	}
 }
+
+func TestFieldAnnotations(t *testing.T) {
+	type SubSequence struct {
+		Flag Boolean
+		Note OctetString
+	}
+
+	type MySequence struct {
+		Name PrintableString
+		Age  Integer
+		Sub  SubSequence
+	}
+
+	age, _ := NewInteger(48)
+	mine := MySequence{
+		Name: PrintableString("Jesse"),
+		Age:  age,
+		Sub:  SubSequence{Flag: true, Note: OctetString("hi")},
+	}
+
+	for _, rule := range encodingRules {
+		// annotations are only recorded upon request
+		pkt, err := Marshal(mine, With(rule))
+		if err != nil {
+			t.Fatalf("%s failed [%s encoding]: %v", t.Name(), rule, err)
+		} else if anns := FieldAnnotations(pkt); len(anns) != 0 {
+			t.Fatalf("%s failed [%s annotations]: unexpected %v", t.Name(), rule, anns)
+		}
+		pkt.Free()
+
+		if pkt, err = Marshal(mine, With(rule), WithFieldAnnotations()); err != nil {
+			t.Fatalf("%s failed [%s encoding]: %v", t.Name(), rule, err)
+		}
+
+		want := []FieldAnnotation{
+			{Start: 2, End: 9, Name: "Name"},
+			{Start: 9, End: 12, Name: "Age"},
+			{Start: 12, End: 21, Name: "Sub"},
+			{Start: 14, End: 17, Name: "Flag"},
+			{Start: 17, End: 21, Name: "Note"},
+		}
+		if got := FieldAnnotations(pkt); !deepEq(got, want) {
+			t.Fatalf("%s failed [%s annotations]:\n\twant: %v\n\tgot:  %v",
+				t.Name(), rule, want, got)
+		}
+
+		bld := newStrBuilder()
+		if err = pkt.Dump(&bld); err != nil {
+			t.Fatalf("%s failed [%s dump]: %v", t.Name(), rule, err)
+		}
+
+		dump := bld.String()
+		for _, label := range []string{
+			"PrintableString, len=5, field=Name",
+			"INTEGER, len=1, field=Age",
+			"SEQUENCE, len=7, field=Sub",
+			"BOOLEAN, len=1, field=Flag",
+			"OCTET STRING, len=2, field=Note",
+		} {
+			if !cntns(dump, label) {
+				t.Fatalf("%s failed [%s dump]: missing %q in:\n%s",
+					t.Name(), rule, label, dump)
+			}
+		}
+
+		pkt.Free()
+		if anns := FieldAnnotations(pkt); len(anns) != 0 {
+			t.Fatalf("%s failed [%s free]: annotations retained", t.Name(), rule)
+		}
+	}
+}
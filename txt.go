@@ -0,0 +1,216 @@
+package asn1plus
+
+/*
+txt.go contains components shared by the character-based encoding
+rules, such as XER, JER and GSER.
+*/
+
+import "reflect"
+
+/*
+textPacket implements the components shared by the [PDU] qualifiers of
+the character-based encoding rules, namely [XERPacket], [JERPacket] and
+[GSERPacket], each of which extends from this type.
+
+Such instances contain character-based content and, as such, do not
+implement the tag/length/value model. Methods which rely upon TLV parsing
+return an error.
+*/
+type textPacket struct {
+	id     string
+	data   []byte
+	offset int
+}
+
+func newTextPacket(src ...byte) textPacket {
+	return textPacket{
+		id:   makePacketID(),
+		data: append([]byte{}, src...),
+	}
+}
+
+func (r textPacket) ID() string                 { return r.id }
+func (r textPacket) Class() (int, error)        { return -1, errorTextualPDU }
+func (r textPacket) Tag() (int, error)          { return -1, errorTextualPDU }
+func (r textPacket) Compound() (bool, error)    { return false, errorTextualPDU }
+func (r textPacket) FullBytes() ([]byte, error) { return r.data, nil }
+func (r textPacket) Hex() string                { return string(r.data) }
+func (r textPacket) Len() int                   { return len(r.data) }
+func (r textPacket) HasMoreData() bool          { return r.offset < len(r.data) }
+
+func (r textPacket) Bytes() ([]byte, error) {
+	if r.offset < 0 || r.offset > len(r.data) {
+		return nil, errorOutOfBounds
+	}
+	return r.data[r.offset:], nil
+}
+
+func (r *textPacket) Data() []byte          { return r.data }
+func (r *textPacket) Offset() int           { return r.offset }
+func (r *textPacket) PeekTLV() (TLV, error) { return TLV{}, errorTextualPDU }
+func (r *textPacket) TLV() (TLV, error)     { return TLV{}, errorTextualPDU }
+func (r *textPacket) WriteTLV(_ TLV) error  { return errorTextualPDU }
+func (r *textPacket) reset()                { r.data, r.offset = r.data[:0], 0 }
+
+func (r *textPacket) Append(data ...byte) {
+	if r != nil && len(data) > 0 {
+		r.data = append(r.data, data...)
+	}
+}
+
+func (r *textPacket) Free() {
+	if r != nil {
+		*r = textPacket{}
+	}
+}
+
+/*
+textPrimitive returns the [Primitive] representation of v, either directly
+or by way of a registered adapter. A nil [Primitive] is returned if v is
+neither.
+*/
+func textPrimitive(v reflect.Value, opts *Options) (prim Primitive, err error) {
+	if !v.CanInterface() {
+		return
+	}
+
+	if isPrimitive(v.Interface()) {
+		var ok bool
+		if prim, ok = v.Interface().(Primitive); !ok {
+			prim, _ = toPtr(v).Interface().(Primitive)
+		}
+		return
+	}
+
+	opts = deferImplicit(opts)
+	if ad, ok := adapterForValue(v, opts.Identifier); ok {
+		codec := ad.newCodec()
+		if err = ad.fromGo(v.Interface(), codec, opts); err == nil {
+			prim = codec
+			if bx, ok := codec.(box); ok {
+				if p, ok := bx.getVal().(Primitive); ok {
+					prim = p
+				}
+			}
+		}
+	}
+
+	return
+}
+
+/*
+textTypeName returns the textual name for type t bearing ASN.1 tag tag.
+Types defined outside of this package retain their own name, while types
+of this package (and unnamed Go types) assume the ASN.1 type name.
+*/
+func textTypeName(t reflect.Type, tag int) (name string) {
+	if name = t.Name(); t.PkgPath() != "" && t.PkgPath() != textPkgPath {
+		return
+	}
+
+	if n, ok := TagNames[tag]; ok {
+		name = replaceAll(n, " ", "_")
+	}
+
+	return
+}
+
+/*
+textTypeNameOf returns the textual name for type t, which may be either
+a [Primitive] or composite type.
+*/
+func textTypeNameOf(t reflect.Type) (name string) {
+	if prim, ok := refNew(t).Interface().(Primitive); ok {
+		name = textTypeName(t, prim.Tag())
+	} else {
+		name = textCompositeName(t, "SEQUENCE", "SET_OF", nil)
+	}
+
+	return
+}
+
+/*
+textCompositeName returns the textual name for composite type t. If t
+is unnamed, seq or set is returned, depending on opts.
+*/
+func textCompositeName(t reflect.Type, seq, set string, opts *Options) (name string) {
+	if name = t.Name(); name == "" {
+		name = seq
+		if t.Kind() == reflect.Slice && !(opts != nil && opts.Sequence) {
+			name = set
+		} else if t.Kind() == reflect.Struct && opts != nil && opts.Set {
+			name = set
+		}
+	}
+
+	return
+}
+
+/*
+textFieldName returns the identifier for struct field sf. The "name:"
+option, if present within opts, takes precedence over the Go name.
+*/
+func textFieldName(sf reflect.StructField, opts *Options) (name string) {
+	if name = sf.Name; opts != nil && opts.Name != "" {
+		name = opts.Name
+	}
+
+	return
+}
+
+/*
+textEachField executes fn for each exported field of struct v which is to
+be rendered by a character-based encoding rule. Fields bearing COMPONENTS
+OF are flattened, while RawContent, extension and omitted fields are not
+visited at all.
+*/
+func textEachField(v reflect.Value, opts *Options, fn func(string, reflect.Value, *Options) error) (err error) {
+	typ := v.Type()
+	fields := structFields(typ)
+	rawIdx := findRawContentIndex(typ, fields)
+	auto := automaticTagging(opts, fields)
+
+	for i := 0; i < len(fields) && err == nil; i++ {
+		field := fields[i]
+		if field.PkgPath != "" || rawIdx == i {
+			continue
+		}
+
+		var fOpts *Options
+		if fOpts, err = extractOptions(field, i, auto); err != nil || fOpts.Extension {
+			continue
+		}
+		fOpts.copyPhase(opts)
+
+		fv := v.Field(i)
+		if fOpts.ComponentsOf {
+			if !field.Anonymous {
+				err = errorComponentsNotAnonymous
+			} else {
+				err = textEachField(componentsOfValue(fv, false), fOpts, fn)
+			}
+		} else if !textOmitField(fv, fOpts) {
+			if err = applyFieldConstraints(fv.Interface(), fOpts, '^'); err == nil {
+				err = fn(textFieldName(field, fOpts), fv, fOpts)
+			}
+		}
+	}
+
+	return
+}
+
+/*
+textOmitField returns a Boolean value indicative of whether field value
+fv should be left out of character-based output.
+*/
+func textOmitField(fv reflect.Value, opts *Options) (omit bool) {
+	if optsIsAbsent(opts) {
+		omit = true
+	} else if optsIsOptional(opts) || optsIsOmit(opts) {
+		omit = fv.IsZero()
+	}
+
+	return
+}
+
+var textPkgPath = refTypeOf(Integer{}).PkgPath()
@@ -223,8 +223,8 @@ func init() {
 		return
 	}
 
-	RegisterTextAlias[UniversalString](TagUniversalString,
-		UniversalStringConstraintPhase,
+	registerTextAlias[UniversalString](TagUniversalString,
+		func() int { return UniversalStringConstraintPhase },
 		universalStringDecoderVerify,
 		decodeUniversalString,
 		encodeUniversalString,
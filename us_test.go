@@ -105,6 +105,55 @@ func TestUniversalString_UCS4Conversion(t *testing.T) {
 	}
 }
 
+func TestUniversalString_roundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		value string
+		wire  []byte // content octets
+	}{
+		{"A", []byte{0x00, 0x00, 0x00, 0x41}},
+		{"漢", []byte{0x00, 0x00, 0x6F, 0x22}},
+		{"A😀", []byte{0x00, 0x00, 0x00, 0x41, 0x00, 0x01, 0xF6, 0x00}},
+		{"𝄞", []byte{0x00, 0x01, 0xD1, 0x1E}}, // U+1D11E
+	} {
+		us, err := NewUniversalString(tc.value)
+		if err != nil {
+			t.Fatalf("%s failed [NewUniversalString %q]: %v", t.Name(), tc.value, err)
+		}
+
+		for _, rule := range encodingRules {
+			var pkt PDU
+			if pkt, err = Marshal(us, With(rule)); err != nil {
+				t.Fatalf("%s failed [%s encoding %q]: %v", t.Name(), rule, tc.value, err)
+			}
+
+			want := append([]byte{TagUniversalString, byte(len(tc.wire))}, tc.wire...)
+			if got := pkt.Data(); !btseq(got, want) {
+				t.Fatalf("%s failed [%s encoding %q]:\n\twant: %X\n\tgot:  %X",
+					t.Name(), rule, tc.value, want, got)
+			}
+
+			var out UniversalString
+			if err = Unmarshal(pkt, &out); err != nil {
+				t.Fatalf("%s failed [%s decoding %q]: %v", t.Name(), rule, tc.value, err)
+			} else if out.String() != tc.value {
+				t.Fatalf("%s failed [%s decoding]:\n\twant: %q\n\tgot:  %q",
+					t.Name(), rule, tc.value, out.String())
+			}
+		}
+	}
+
+	for _, bogus := range [][]byte{
+		{0x1C, 0x03, 0x00, 0x00, 0x41},       // not a multiple of four
+		{0x1C, 0x04, 0x00, 0x00, 0xD8, 0x3D}, // surrogate code point
+		{0x1C, 0x04, 0x00, 0x11, 0x00, 0x00}, // beyond U+10FFFF
+	} {
+		var out UniversalString
+		if err := Unmarshal(BER.New(bogus...), &out); err == nil {
+			t.Fatalf("%s failed [decoding %X]: expected error, got nil", t.Name(), bogus)
+		}
+	}
+}
+
 func TestUniversalString_codecov(t *testing.T) {
 	_, _ = NewUniversalString(struct{}{})
 
@@ -146,7 +146,7 @@ func init() {
 		return
 	}
 
-	RegisterTextAlias[UTF8String](TagUTF8String,
-		UTF8StringConstraintPhase,
+	registerTextAlias[UTF8String](TagUTF8String,
+		func() int { return UTF8StringConstraintPhase },
 		nil, nil, nil, UTF8Spec)
 }
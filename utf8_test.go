@@ -167,3 +167,13 @@ func BenchmarkUTF8StringConstructor(b *testing.B) {
 		}
 	}
 }
+
+func TestUTF8String_encodingPhase(t *testing.T) {
+	defer func(phase int) { UTF8StringConstraintPhase = phase }(UTF8StringConstraintPhase)
+
+	bogus := UTF8String("\xff\xfe") // invalid UTF-8
+	UTF8StringConstraintPhase = CodecConstraintBoth
+	if pkt, err := Marshal(bogus, With(BER)); err == nil {
+		t.Fatalf("%s failed [both phases]: expected error, got %s", t.Name(), pkt.Hex())
+	}
+}
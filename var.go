@@ -122,13 +122,16 @@ var CompoundNames = map[bool]string{
 var (
 	berOID,
 	derOID,
-	cerOID ObjectIdentifier
+	cerOID,
+	xerOID,
+	jerOID ObjectIdentifier
 )
 
 var (
 	ptrClassUniversal       = new(int)
 	ptrClassContextSpecific = new(int)
 	rawContentType          = refTypeOf(RawContent(nil))
+	rawValueType            = refTypeOf(RawValue(nil))
 	choicePtrType           = refTypeOf((*Choice)(nil)).Elem()
 	choiceIfaceType         = refTypeOf(Choice(nil))
 	taggedChoiceType        = refTypeOf(NewChoice(nil, 0))
@@ -144,6 +147,7 @@ var boolKeywords = map[string]struct{}{
 	"indefinite":    {},
 	"omitempty":     {},
 	"optional":      {},
+	"raw":           {},
 	"sequence":      {},
 	"set":           {},
 	"...":           {},
@@ -180,9 +184,17 @@ var marshalHandlers []func(reflect.Value, PDU, *Options) (bool, error)
 
 const hexDigits = "0123456789ABCDEF"
 
+/*
+cerMaxSegment is the maximum number of content octets permitted within
+a single primitive string TLV under [CER], beyond which the constructed
+(segmented) form is required, per § 9.2 of ITU-T Rec. X.690.
+*/
+const cerMaxSegment = 1000
+
 func init() {
 	marshalHandlers = []func(reflect.Value, PDU, *Options) (bool, error){
 		marshalChoice,     // Choice (recursion) path
+		marshalInlineEnum, // In-line ENUMERATED path
 		marshalPrimitive,  // ASN.1 Primitive path
 		marshalViaAdapter, // Adapter path
 	}
@@ -193,6 +205,8 @@ func init() {
 	berOID, _ = NewObjectIdentifier(2, 1, 1)
 	cerOID, _ = NewObjectIdentifier(2, 1, 2, 0)
 	derOID, _ = NewObjectIdentifier(2, 1, 2, 1)
+	xerOID, _ = NewObjectIdentifier(2, 1, 5, 0)
+	jerOID, _ = NewObjectIdentifier(2, 1, 6, 0)
 
 	// TODO
 	//perOID, _ = NewObjectIdentifier(2, 1, 3, 0, 0)
@@ -135,7 +135,7 @@ func init() {
 		return
 	}
 
-	RegisterTextAlias[VisibleString](TagVisibleString,
-		VisibleStringConstraintPhase,
+	registerTextAlias[VisibleString](TagVisibleString,
+		func() int { return VisibleStringConstraintPhase },
 		nil, nil, nil, VisibleSpec)
 }
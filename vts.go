@@ -192,8 +192,8 @@ func init() {
 		return
 	}
 
-	RegisterTextAlias[VideotexString](TagVideotexString,
-		VideotexStringConstraintPhase,
+	registerTextAlias[VideotexString](TagVideotexString,
+		func() int { return VideotexStringConstraintPhase },
 		videotexDecoderVerify,
 		videotexDecoder, nil,
 		VideotexSpec)
@@ -0,0 +1,371 @@
+//go:build !asn1_no_xer
+
+package asn1plus
+
+/*
+xer.go contains XER-focused components.
+*/
+
+import (
+	"io"
+	"reflect"
+	"strings"
+)
+
+/*
+XERPacket encapsulates an [ITU-T Rec. X.693] XER-encoded (XML) byte
+slice and an offset.
+
+Unlike [BERPacket] and its descendants, instances of this type contain
+character-based content and, as such, do not implement the tag/length/value
+model. Methods which rely upon TLV parsing will return an error.
+
+At present, XER support is limited to encoding (marshaling) only.
+
+[ITU-T Rec. X.693]: https://www.itu.int/rec/T-REC-X.693
+*/
+type XERPacket textPacket
+
+/*
+Type returns [XER], identifying the receiver as an ASN.1 XML Encoding
+Rules [PDU] qualifier.
+*/
+func (r XERPacket) Type() EncodingRule { return XER }
+
+/*
+ID returns the unique string identifier associated with the receiver instance.
+
+Note that if this package is not compiled or run with "-tags asn1_debug", this
+method will always return a zero string.
+*/
+func (r XERPacket) ID() string { return textPacket(r).ID() }
+
+/*
+Class returns -1 alongside an error, as XER does not implement ASN.1 class
+identifiers in its encoding.
+*/
+func (r XERPacket) Class() (int, error) { return textPacket(r).Class() }
+
+/*
+Tag returns -1 alongside an error, as XER does not implement ASN.1 tag
+identifiers in its encoding.
+*/
+func (r XERPacket) Tag() (int, error) { return textPacket(r).Tag() }
+
+/*
+Compound returns false alongside an error, as XER does not implement ASN.1
+compound identifiers in its encoding.
+*/
+func (r XERPacket) Compound() (bool, error) { return textPacket(r).Compound() }
+
+/*
+Bytes returns the XML content of the underlying buffer, from the current
+offset onward, alongside an error.
+*/
+func (r XERPacket) Bytes() ([]byte, error) { return textPacket(r).Bytes() }
+
+/*
+FullBytes returns the complete XML content of the underlying buffer
+alongside an error.
+*/
+func (r XERPacket) FullBytes() ([]byte, error) { return textPacket(r).FullBytes() }
+
+/*
+Hex returns the XML text within the receiver instance. Unlike binary
+encoding rules, there is no need to represent XER content as hexadecimal.
+*/
+func (r XERPacket) Hex() string { return textPacket(r).Hex() }
+
+/*
+Dump returns an error following an attempt to write the receiver
+instance into w as indented XML.
+
+The variadic input values are accepted for interface compatibility,
+but are ignored as XML elements are never wrapped.
+*/
+func (r *XERPacket) Dump(w io.Writer, _ ...int) error {
+	return dumpXER(w, string(r.data))
+}
+
+/*
+Len returns the integer length of the underlying byte buffer within
+the receiver instance.
+*/
+func (r XERPacket) Len() int { return textPacket(r).Len() }
+
+/*
+HasMoreData returns a Boolean value indicative of whether there are more bytes remaining
+to be processed.
+*/
+func (r XERPacket) HasMoreData() bool { return textPacket(r).HasMoreData() }
+
+/*
+Data returns the underlying byte slice.
+*/
+func (r *XERPacket) Data() []byte { return (*textPacket)(r).Data() }
+
+/*
+Append appends data to the receiver instance.
+*/
+func (r *XERPacket) Append(data ...byte) { (*textPacket)(r).Append(data...) }
+
+/*
+Offset returns the current offset position index of the underlying value within the receiver
+instance.
+*/
+func (r *XERPacket) Offset() int { return (*textPacket)(r).Offset() }
+
+/*
+SetOffset replaces the current offset position index of the underlying value within the receiver
+instance with a user-supplied value.
+
+Supplying an integer of negative one (-1) will set the offset to the final character in the
+underlying buffer if non-zero in length.
+
+If no variadic input is provided, the offset position index is set to zero (0).
+*/
+func (r *XERPacket) SetOffset(offset ...int) { r.offset = setPacketOffset(r, offset...) }
+
+/*
+AddOffset increments or decrements the current offset according to n. Though
+negative input is permitted, the product of offset + n must not be negative
+itself, nor may it exceed the receiver's buffer length.
+*/
+func (r *XERPacket) AddOffset(n int) { r.offset = incPacketOffset(r, n) }
+
+/*
+Free frees the receiver instance.
+*/
+func (r *XERPacket) Free() { (*textPacket)(r).Free() }
+
+func (r *XERPacket) reset() { (*textPacket)(r).reset() }
+
+/*
+PeekTLV returns an empty [TLV] alongside an error, as XER does not implement
+the tag/length/value model.
+*/
+func (r *XERPacket) PeekTLV() (TLV, error) { return (*textPacket)(r).PeekTLV() }
+
+/*
+TLV returns an empty [TLV] alongside an error, as XER does not implement
+the tag/length/value model.
+*/
+func (r *XERPacket) TLV() (TLV, error) { return (*textPacket)(r).TLV() }
+
+/*
+WriteTLV returns an error, as XER does not implement the tag/length/value
+model.
+*/
+func (r *XERPacket) WriteTLV(tlv TLV) error { return (*textPacket)(r).WriteTLV(tlv) }
+
+func newXERPacket(src ...byte) PDU {
+	r := XERPacket(newTextPacket(src...))
+	return &r
+}
+
+/*
+marshalXER returns an error following an attempt to write v into pkt
+as an XML document. The outermost element is named after the type of
+v.
+*/
+func marshalXER(v reflect.Value, pkt PDU, opts *Options) (err error) {
+	debugEnter(v, opts, pkt)
+	defer func() { debugExit(newLItem(err)) }()
+
+	bld := newStrBuilder()
+	if err = xerWriteElement(&bld, "", v, opts); err == nil {
+		pkt.Append([]byte(bld.String())...)
+	}
+
+	return
+}
+
+/*
+xerWriteElement writes v into bld as an XML element. If name is a zero
+string, the element name is derived from the type of v.
+*/
+func xerWriteElement(bld *strings.Builder, name string, v reflect.Value, opts *Options) (err error) {
+	if !v.IsValid() {
+		err = errorNilValue
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			err = errorNilValue
+			return
+		}
+	}
+
+	if v.CanInterface() {
+		if ch, ok := v.Interface().(Choice); ok {
+			err = xerWriteChoice(bld, name, ch)
+			return
+		}
+	}
+
+	if k := v.Kind(); k == reflect.Ptr || k == reflect.Interface {
+		err = xerWriteElement(bld, name, v.Elem(), opts)
+		return
+	}
+
+	var prim Primitive
+	if prim, err = textPrimitive(v, opts); err != nil || prim != nil {
+		if err == nil {
+			if name == "" {
+				name = textTypeName(v.Type(), prim.Tag())
+			}
+			xerWritePrimitive(bld, name, prim)
+		}
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if name == "" {
+			name = textCompositeName(v.Type(), "SEQUENCE", "SET", opts)
+		}
+		bld.WriteString("<" + name + ">")
+		if err = xerWriteFields(bld, v, opts); err == nil {
+			bld.WriteString("</" + name + ">")
+		}
+	case reflect.Slice, reflect.Array:
+		if name == "" {
+			name = textCompositeName(v.Type(), "SEQUENCE_OF", "SET_OF", opts)
+		}
+		bld.WriteString("<" + name + ">")
+		for i := 0; i < v.Len() && err == nil; i++ {
+			err = xerWriteElement(bld, "", v.Index(i), nil)
+		}
+		if err == nil {
+			bld.WriteString("</" + name + ">")
+		}
+	default:
+		err = compositeErrorf("XER: unsupported type ", v.Kind().String())
+	}
+
+	return
+}
+
+/*
+xerWriteFields writes each exported field of struct v into bld as
+child elements named after the respective field.
+*/
+func xerWriteFields(bld *strings.Builder, v reflect.Value, opts *Options) error {
+	return textEachField(v, opts, func(name string, fv reflect.Value, fOpts *Options) error {
+		return xerWriteElement(bld, name, fv, fOpts)
+	})
+}
+
+/*
+xerWriteChoice writes the selected alternative of ch into bld. The
+alternative is itself wrapped within an element named after its type.
+*/
+func xerWriteChoice(bld *strings.Builder, name string, ch Choice) (err error) {
+	alt := refValueOf(ch.Value())
+	if !alt.IsValid() {
+		err = errorNilValue
+		return
+	}
+
+	if name != "" {
+		bld.WriteString("<" + name + ">")
+	}
+	if err = xerWriteElement(bld, "", alt, nil); err == nil && name != "" {
+		bld.WriteString("</" + name + ">")
+	}
+
+	return
+}
+
+/*
+xerWritePrimitive writes prim into bld as an XML element named name.
+*/
+func xerWritePrimitive(bld *strings.Builder, name string, prim Primitive) {
+	switch prim.Tag() {
+	case TagNull:
+		bld.WriteString("<" + name + "/>")
+		return
+	case TagBoolean:
+		bld.WriteString("<" + name + "><" + prim.String() + "/></" + name + ">")
+		return
+	}
+
+	var content string
+	switch prim.Tag() {
+	case TagOctetString:
+		content = uc(hexstr([]byte(prim.String())))
+	case TagBitString:
+		content = trimR(trimL(prim.String(), "'"), "'B")
+	default:
+		content = xerEscaper.Replace(prim.String())
+	}
+
+	bld.WriteString("<" + name + ">" + content + "</" + name + ">")
+}
+
+/*
+dumpXER writes the XML document doc into w, placing each element on
+its own line and indenting child elements by two spaces per level.
+*/
+func dumpXER(w io.Writer, doc string) (err error) {
+	var toks []string
+	for len(doc) > 0 {
+		end := stridxb(doc, '<')
+		if end == 0 {
+			if end = stridxb(doc, '>') + 1; end == 0 {
+				end = len(doc)
+			}
+		} else if end < 0 {
+			end = len(doc)
+		}
+		toks = append(toks, doc[:end])
+		doc = doc[end:]
+	}
+
+	isTag := func(i int) bool { return i < len(toks) && hasPfx(toks[i], "<") }
+	isClose := func(i int) bool { return isTag(i) && hasPfx(toks[i], "</") }
+
+	var depth int
+	for i := 0; i < len(toks) && err == nil; i++ {
+		var line string
+		switch {
+		case isClose(i):
+			if depth > 0 {
+				depth--
+			}
+			line = toks[i]
+		case !isTag(i), hasSfx(toks[i], "/>"):
+			line = toks[i]
+		case !isTag(i+1) && isClose(i+2):
+			line = toks[i] + toks[i+1] + toks[i+2]
+			i += 2
+		case isClose(i + 1):
+			line = toks[i] + toks[i+1]
+			i++
+		default:
+			line = toks[i]
+			depth++
+			_, err = w.Write([]byte(strrpt("  ", depth-1) + line + "\n"))
+			continue
+		}
+		_, err = w.Write([]byte(strrpt("  ", depth) + line + "\n"))
+	}
+
+	return
+}
+
+var xerEscaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	`"`, "&quot;",
+	"'", "&apos;",
+)
+
+func init() {
+	activeEncodingRules |= XER
+	pDUConstructors[XER] = newXERPacket
+	textMarshalers[XER] = marshalXER
+}
@@ -0,0 +1,124 @@
+//go:build !asn1_no_xer
+
+package asn1plus
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+func ExampleXERPacket() {
+	type MySequence struct {
+		Name PrintableString
+		Age  Integer
+	}
+
+	nint, _ := NewInteger(48)
+	mine := MySequence{PrintableString("Jesse"), nint}
+
+	pkt, err := Marshal(mine, With(XER))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(pkt.Hex())
+	// Output: <MySequence><Name>Jesse</Name><Age>48</Age></MySequence>
+}
+
+func ExampleXERPacket_Dump() {
+	type SubSequence struct {
+		Flag    Boolean
+		Payload OctetString
+	}
+
+	type MySequence struct {
+		Name  PrintableString
+		Sub   SubSequence
+		Bits  BitString
+		Empty Null
+	}
+
+	mine := MySequence{
+		Name:  PrintableString("Jesse"),
+		Sub:   SubSequence{Flag: true, Payload: OctetString("hi")},
+		Bits:  BitString{Bytes: []byte{0xA0}, BitLength: 4},
+		Empty: Null{},
+	}
+
+	pkt, err := Marshal(mine, With(XER))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if err = pkt.Dump(os.Stdout); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// <MySequence>
+	//   <Name>Jesse</Name>
+	//   <Sub>
+	//     <Flag>
+	//       <true/>
+	//     </Flag>
+	//     <Payload>6869</Payload>
+	//   </Sub>
+	//   <Bits>1010</Bits>
+	//   <Empty/>
+	// </MySequence>
+}
+
+func TestXER_codecov(t *testing.T) {
+	type Inner struct {
+		Note  PrintableString `asn1:"optional"`
+		Count Enumerated
+	}
+
+	type Outer struct {
+		Items    []Integer `asn1:"sequence"`
+		Inner    Inner
+		Text     UTF8String
+		Optional *Integer `asn1:"optional"`
+	}
+
+	i1, _ := NewInteger(1)
+	i2, _ := NewInteger(2)
+	outer := Outer{
+		Items: []Integer{i1, i2},
+		Inner: Inner{Count: 3},
+		Text:  UTF8String("a<b"),
+	}
+
+	pkt, err := Marshal(outer, With(XER))
+	if err != nil {
+		t.Fatalf("%s failed [XER marshal]: %v", t.Name(), err)
+	}
+
+	want := `<Outer><Items><INTEGER>1</INTEGER><INTEGER>2</INTEGER></Items>` +
+		`<Inner><Count>3</Count></Inner><Text>a&lt;b</Text></Outer>`
+	if got := pkt.Hex(); got != want {
+		t.Fatalf("%s failed [XER output]:\n\twant: %s\n\tgot:  %s", t.Name(), want, got)
+	}
+
+	if pkt.Type() != XER || pkt.Type().String() != `XER` || !pkt.Type().OID().Eq(xerOID) {
+		t.Fatalf("%s failed [XER identity]", t.Name())
+	}
+
+	if _, err = pkt.TLV(); err == nil {
+		t.Fatalf("%s failed: expected TLV error, got nil", t.Name())
+	}
+	if err = pkt.WriteTLV(TLV{}); err == nil {
+		t.Fatalf("%s failed: expected WriteTLV error, got nil", t.Name())
+	}
+
+	var dest Outer
+	if err = Unmarshal(pkt, &dest); err == nil {
+		t.Fatalf("%s failed: expected XER unmarshal error, got nil", t.Name())
+	}
+
+	if _, err = Marshal(outer, With(XER, Options{Indefinite: true})); err == nil {
+		t.Fatalf("%s failed: expected indefinite-length error, got nil", t.Name())
+	}
+}